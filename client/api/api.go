@@ -3,6 +3,7 @@ package api
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"strings"
@@ -31,11 +32,19 @@ import (
 	log "github.com/micro/go-micro/v2/logger"
 	"github.com/micro/go-micro/v2/sync/memory"
 	"github.com/micro/micro/v2/client/api/auth"
+	"github.com/micro/micro/v2/client/api/cache"
+	"github.com/micro/micro/v2/client/api/grpcweb"
+	"github.com/micro/micro/v2/client/api/openapi"
+	"github.com/micro/micro/v2/client/api/policy"
+	"github.com/micro/micro/v2/client/api/ratelimit"
+	"github.com/micro/micro/v2/client/api/routes"
+	"github.com/micro/micro/v2/internal/accesslog"
 	"github.com/micro/micro/v2/internal/handler"
 	"github.com/micro/micro/v2/internal/helper"
 	rrmicro "github.com/micro/micro/v2/internal/resolver/api"
 	"github.com/micro/micro/v2/internal/stats"
 	"github.com/micro/micro/v2/plugin"
+	pbstats "github.com/micro/micro/v2/service/debug/stats/proto"
 )
 
 var (
@@ -44,6 +53,8 @@ var (
 	Handler               = "meta"
 	Resolver              = "micro"
 	RPCPath               = "/rpc"
+	OpenAPIPath           = "/openapi.json"
+	DocsPath              = "/docs"
 	APIPath               = "/"
 	ProxyPath             = "/{service:[a-zA-Z0-9]+}"
 	Namespace             = "go.micro"
@@ -69,6 +80,9 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 	}
 	if len(ctx.String("resolver")) > 0 {
 		Resolver = ctx.String("resolver")
+	} else if Handler == grpcweb.Handler {
+		// grpc-web requests are made to /[package].[Service]/[Method]
+		Resolver = "grpc"
 	}
 	if len(ctx.String("enable_rpc")) > 0 {
 		EnableRPC = ctx.Bool("enable_rpc")
@@ -166,8 +180,13 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 	r := mux.NewRouter()
 	h = r
 
+	// create the policy enforcer, the timeouts, retries and circuit breakers of the services are
+	// read from config. The breakers are shown in the stats.
+	policies := policy.New(Namespace + "." + Type)
+
 	if ctx.Bool("enable_stats") {
 		st := stats.New()
+		st.Collect("breakers", func() interface{} { return policies.Breakers() })
 		r.HandleFunc("/stats", st.StatsHandler)
 		h = st.ServeHTTP(r)
 		st.Start()
@@ -187,10 +206,28 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 	// strip favicon.ico
 	r.HandleFunc("/favicon.ico", func(w http.ResponseWriter, r *http.Request) {})
 
+	// serve the openapi document of the services and optionally swagger ui to browse it. The paths
+	// take precedence over the services of the same name.
+	docOpts := openapi.Options{Prefix: apiNamespace, Title: "Micro API", Version: ctx.App.Version}
+	if ctx.Bool("enable_openapi") || ctx.Bool("enable_docs") {
+		log.Infof("Registering OpenAPI Document at %s", OpenAPIPath)
+		r.HandleFunc(OpenAPIPath, openapi.Handler(service.Options().Registry, docOpts))
+	}
+	if ctx.Bool("enable_docs") {
+		dir := ctx.String("docs_assets")
+		if err := openapi.CheckUIDir(dir); err != nil {
+			log.Fatalf("Error serving the api docs, set --docs_assets to the dir of the swagger-ui-dist package: %v", err)
+		}
+		log.Infof("Registering API Docs at %s", DocsPath)
+		r.PathPrefix(DocsPath).Handler(openapi.UIHandler(OpenAPIPath, docOpts.Title, dir))
+	}
+
 	// register rpc handler
 	if EnableRPC {
 		log.Infof("Registering RPC Handler at %s", RPCPath)
 		r.HandleFunc(RPCPath, handler.RPC)
+		r.HandleFunc(RPCPath+"/stream", handler.Stream)
+		r.HandleFunc(RPCPath+"/stream/ticket", handler.Ticket)
 	}
 
 	// resolver options
@@ -211,6 +248,21 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 		rr = grpc.NewResolver(ropts...)
 	}
 
+	// the routes in the routing file take precedence over the handler, their endpoints are resolved
+	// before falling back to the resolver
+	if len(ctx.String("routes")) > 0 {
+		table, err := routes.Load(ctx.String("routes"))
+		if err != nil {
+			log.Fatalf("Error loading routes from %v: %v", ctx.String("routes"), err)
+		}
+		log.Infof("Registering API Routes from %s", ctx.String("routes"))
+		rr = table.Resolver(rr)
+		r.MatcherFunc(func(req *http.Request, _ *mux.RouteMatch) bool {
+			route, _ := table.Match(req)
+			return route != nil
+		}).Handler(table.Handler(service.Client()))
+	}
+
 	switch Handler {
 	case "rpc":
 		log.Infof("Registering API RPC Handler at %s", APIPath)
@@ -224,7 +276,8 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 			ahandler.WithRouter(rt),
 			ahandler.WithClient(service.Client()),
 		)
-		r.PathPrefix(APIPath).Handler(rp)
+		// streaming endpoints are served as server-sent events or over websockets
+		r.PathPrefix(APIPath).Handler(handler.Streams(rt, service.Client(), rp))
 	case "api":
 		log.Infof("Registering API Request Handler at %s", APIPath)
 		rt := regRouter.NewRouter(
@@ -264,6 +317,19 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 			ahandler.WithClient(service.Client()),
 		)
 		r.PathPrefix(ProxyPath).Handler(ht)
+	case grpcweb.Handler:
+		log.Infof("Registering API gRPC-Web Handler at %s", APIPath)
+		rt := regRouter.NewRouter(
+			router.WithHandler(grpcweb.Handler),
+			router.WithResolver(rr),
+			router.WithRegistry(service.Options().Registry),
+		)
+		gw := grpcweb.NewHandler(
+			ahandler.WithNamespace(apiNamespace),
+			ahandler.WithRouter(rt),
+			ahandler.WithClient(service.Client()),
+		)
+		r.PathPrefix(APIPath).Handler(gw)
 	case "web":
 		log.Infof("Registering API Web Handler at %s", APIPath)
 		rt := regRouter.NewRouter(
@@ -283,7 +349,8 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 			router.WithResolver(rr),
 			router.WithRegistry(service.Options().Registry),
 		)
-		r.PathPrefix(APIPath).Handler(handler.Meta(service, rt, Namespace+"."+Type))
+		meta := handler.Meta(service, rt, Namespace+"."+Type)
+		r.PathPrefix(APIPath).Handler(handler.Streams(rt, service.Client(), meta))
 	}
 
 	// reverse wrap handler
@@ -292,9 +359,64 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 		h = plugins[i-1].Handler()(h)
 	}
 
-	// create the auth wrapper and the server
+	// create the rate limiter, the limits are read from config. The counters are shared by the
+	// replicas of the api if they're kept in the store.
+	counter := ratelimit.NewCounter()
+	if ctx.Bool("ratelimit_store") {
+		counter = ratelimit.NewStoreCounter(service.Options().Store)
+	}
+	limiter := ratelimit.New(counter, Namespace+"."+Type)
+	limitExit := make(chan bool)
+	go limiter.Watch(service.Client(), limitExit)
+	defer close(limitExit)
+
+	// create the response cache, the endpoints are cached by rules in config or their metadata in
+	// the registry. The responses are shared by the replicas of the api if they're kept in the store.
+	cacheStore := cache.NewStore()
+	if ctx.Bool("cache_store") {
+		cacheStore = cache.NewStoreStore(service.Options().Store)
+	}
+	responseCache := cache.New(cacheStore, service.Options().Registry, Namespace+"."+Type)
+	cacheExit := make(chan bool)
+	go responseCache.Watch(service.Client(), cacheExit)
+	defer close(cacheExit)
+	if err := micro.RegisterSubscriber(cache.Topic, service.Server(), responseCache.Handle); err != nil {
+		log.Errorf("Error subscribing to cache invalidations: %v", err)
+	}
+
+	// watch the policies and report the breakers to the debug stats service, the node is identified
+	// as it is in the registry so the breakers are merged into its stats
+	srvOpts := service.Server().Options()
+	policyExit := make(chan bool)
+	go policies.Watch(service.Client(), policyExit)
+	go policies.Report(service.Client(), &pbstats.Service{
+		Name:    srvOpts.Name,
+		Version: srvOpts.Version,
+		Node: &pbstats.Node{
+			Id:      srvOpts.Name + "-" + srvOpts.Id,
+			Address: srvOpts.Address,
+		},
+	}, policyExit)
+	defer close(policyExit)
+
+	// create the access logger, the requests are tagged with a request id whether they're logged
+	var accessLog io.Writer
+	if ctx.Bool("enable_access_log") {
+		accessLog = os.Stdout
+	}
+	accessLogger := accesslog.New(accessLog, ctx.Float64("access_log_sample"))
+
+	// create the auth wrapper and the server, the auth wrapper is applied after the access logger so
+	// it resolves the endpoint and account before the requests are rate limited, cached and then have
+	// the policies applied to them
 	authWrapper := auth.Wrapper(rr, Namespace+"."+Type)
-	api := httpapi.NewServer(Address, server.WrapHandler(authWrapper))
+	api := httpapi.NewServer(Address,
+		server.WrapHandler(policies.Wrapper()),
+		server.WrapHandler(responseCache.Wrapper()),
+		server.WrapHandler(limiter.Wrapper()),
+		server.WrapHandler(authWrapper),
+		server.WrapHandler(accessLogger.Wrapper()),
+	)
 
 	api.Init(opts...)
 	api.Handle("/", h)
@@ -331,7 +453,7 @@ func Commands(options ...micro.Option) []*cli.Command {
 			},
 			&cli.StringFlag{
 				Name:    "handler",
-				Usage:   "Specify the request handler to be used for mapping HTTP requests to services; {api, event, http, rpc}",
+				Usage:   "Specify the request handler to be used for mapping HTTP requests to services; {api, event, http, rpc, grpcweb}",
 				EnvVars: []string{"MICRO_API_HANDLER"},
 			},
 			&cli.StringFlag{
@@ -360,6 +482,50 @@ func Commands(options ...micro.Option) []*cli.Command {
 				EnvVars: []string{"MICRO_API_ENABLE_CORS"},
 				Value:   true,
 			},
+			&cli.BoolFlag{
+				Name:    "enable_openapi",
+				Usage:   "Serve the openapi document of the services at /openapi.json",
+				EnvVars: []string{"MICRO_API_ENABLE_OPENAPI"},
+			},
+			&cli.BoolFlag{
+				Name:    "enable_docs",
+				Usage:   "Enable swagger ui at /docs to browse the openapi document of the services, a service named docs is no longer served at the path",
+				EnvVars: []string{"MICRO_API_ENABLE_DOCS"},
+			},
+			&cli.StringFlag{
+				Name:    "docs_assets",
+				Usage:   "Set the dir of the swagger-ui-dist package the assets of swagger ui are served from, required by --enable_docs",
+				EnvVars: []string{"MICRO_API_DOCS_ASSETS"},
+			},
+			&cli.BoolFlag{
+				Name:    "ratelimit_store",
+				Usage:   "Keep the rate limit counters in the store so they're shared by the replicas of the api",
+				EnvVars: []string{"MICRO_API_RATELIMIT_STORE"},
+			},
+			&cli.BoolFlag{
+				Name:    "cache_store",
+				Usage:   "Keep the cached responses in the store so they're shared by the replicas of the api",
+				EnvVars: []string{"MICRO_API_CACHE_STORE"},
+			},
+			&cli.StringFlag{
+				Name:    "routes",
+				Usage:   "Set the json file of routes which map http methods and paths to service endpoints",
+				EnvVars: []string{"MICRO_API_ROUTES"},
+			},
+		},
+		Subcommands: []*cli.Command{
+			{
+				Name:   "docs",
+				Usage:  "Generate the openapi document of the services served by the api",
+				Action: docs,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Write the document to the file rather than stdout",
+					},
+				},
+			},
 		},
 	}
 
@@ -12,7 +12,10 @@ import (
 	"github.com/micro/go-micro/v2/auth"
 	"github.com/micro/go-micro/v2/logger"
 	"github.com/micro/go-micro/v2/util/ctx"
+	"github.com/micro/micro/v2/internal/accesslog"
+	"github.com/micro/micro/v2/internal/api"
 	inauth "github.com/micro/micro/v2/internal/auth"
+	"github.com/micro/micro/v2/internal/handler"
 	"github.com/micro/micro/v2/internal/namespace"
 )
 
@@ -62,12 +65,29 @@ func (a authWrapper) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	// Set the metadata so we can access it in micro api / web
 	req = req.WithContext(ctx.FromRequest(req))
 
-	// Extract the token from the request
+	// Extract the token from the request. The tokens passed explicitly, rather than in the cookies
+	// of the browser, can authenticate requests from other origins once they're verified.
 	var token string
+	var explicit bool
 	if header := req.Header.Get("Authorization"); len(header) > 0 {
 		// Extract the auth token from the request
 		if strings.HasPrefix(header, auth.BearerScheme) {
 			token = header[len(auth.BearerScheme):]
+			explicit = true
+		}
+	} else if t := req.URL.Query().Get(handler.TicketParam); len(t) > 0 && handler.IsStreamRequest(req) {
+		// Browsers can't set the headers of event sources and websockets so the streams pass a
+		// single use ticket which is redeemed for the token. The ticket is stripped from the url
+		// so it doesn't go any further.
+		q := req.URL.Query()
+		q.Del(handler.TicketParam)
+		req.URL.RawQuery = q.Encode()
+		req.RequestURI = req.URL.RequestURI()
+
+		if tok, err := handler.RedeemTicket(t); err == nil {
+			token = tok
+			explicit = true
+			req.Header.Set("Authorization", auth.BearerScheme+token)
 		}
 	} else {
 		// Get the token out the cookies if not provided in headers
@@ -81,14 +101,26 @@ func (a authWrapper) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	// account doesn't necesserially mean a forbidden request
 	acc, _ := a.auth.Inspect(token)
 
+	// Set the account in the context so it can be used by the wrapped handler, e.g. to rate limit
+	if acc != nil {
+		req = req.WithContext(auth.ContextWithAccount(req.Context(), acc))
+		if explicit {
+			req = req.WithContext(handler.WithVerifiedToken(req.Context()))
+		}
+	}
+
 	// Ensure the accounts issuer matches the namespace being requested
 	if acc != nil && len(acc.Issuer) > 0 && acc.Issuer != ns {
 		http.Error(w, "Account not issued by "+ns, 403)
 		return
 	}
 
-	// construct the resource name, e.g. home => go.micro.web.home
+	// construct the resource name, e.g. home => go.micro.web.home. The routes resolve the full
+	// names of the services, e.g. go.micro.service.users, which aren't prefixed.
 	resName := a.servicePrefix + "." + endpoint.Name
+	if api.IsRouted(req.Context()) {
+		resName = endpoint.Name
+	}
 
 	// determine the resource path. there is an inconsistency in how resolvers
 	// use method, some use it as Users.ReadUser (the rpc method), and others
@@ -98,6 +130,16 @@ func (a authWrapper) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		resEndpoint = endpoint.Method
 	}
 
+	// annotate the access log of the request with the resolved service and account
+	if e := accesslog.FromContext(req.Context()); e != nil {
+		e.Service = endpoint.Name
+		e.Endpoint = resEndpoint
+		e.Namespace = ns
+		if acc != nil {
+			e.Account = acc.ID
+		}
+	}
+
 	// Perform the verification check to see if the account has access to
 	// the resource they're requesting
 	res := &auth.Resource{Type: "service", Name: resName, Endpoint: resEndpoint}
@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/v2/api/resolver"
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/micro/v2/client/api/routes"
+	"github.com/micro/micro/v2/internal/handler"
+)
+
+// testAuth grants the accounts named by the tokens access to the resources of the rules
+type testAuth struct {
+	auth.Auth
+	// rules are the accounts which can access each resource
+	rules map[string][]string
+}
+
+func (a *testAuth) Options() auth.Options {
+	return auth.Options{}
+}
+
+func (a *testAuth) Inspect(token string) (*auth.Account, error) {
+	if len(token) == 0 {
+		return nil, auth.ErrInvalidToken
+	}
+	return &auth.Account{ID: token}, nil
+}
+
+func (a *testAuth) Verify(acc *auth.Account, res *auth.Resource, opts ...auth.VerifyOption) error {
+	for _, id := range a.rules[res.Name] {
+		if acc != nil && acc.ID == id {
+			return nil
+		}
+	}
+	return auth.ErrForbidden
+}
+
+// testResolver resolves the paths to the greeter of the gateway without its prefix, e.g.
+// /greeter => greeter and /v1/greeter => v1.greeter
+type testResolver struct {
+	resolver.Resolver
+}
+
+func (r *testResolver) Resolve(req *http.Request, opts ...resolver.ResolveOption) (*resolver.Endpoint, error) {
+	name := "greeter"
+	if strings.HasPrefix(req.URL.Path, "/v1/") {
+		name = "v1.greeter"
+	}
+	return &resolver.Endpoint{Name: name, Path: req.URL.Path, Domain: "micro"}, nil
+}
+
+func TestWrapperRoutes(t *testing.T) {
+	table, err := routes.Parse([]byte(`{"routes": [
+		{"method": "GET", "path": "/v1/users/{id}", "service": "go.micro.service.users", "endpoint": "Users.Read"}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a := &testAuth{rules: map[string][]string{
+		"go.micro.service.users":  {"john"},
+		"go.micro.api.greeter":    {"jane"},
+		"go.micro.api.v1.greeter": {"jane"},
+		"v1.greeter":              {"john"},
+	}}
+	h := authWrapper{
+		handler:       http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		auth:          a,
+		resolver:      table.Resolver(&testResolver{}),
+		servicePrefix: "go.micro.api",
+	}
+
+	tests := []struct {
+		path    string
+		account string
+		status  int
+	}{
+		// the routes are verified against the rules of the services they're routed to
+		{"/v1/users/1", "john", http.StatusOK},
+		{"/v1/users/1", "jane", http.StatusForbidden},
+		{"/v1/users/1", "", http.StatusUnauthorized},
+		// the services of the gateway are prefixed
+		{"/greeter", "jane", http.StatusOK},
+		{"/greeter", "john", http.StatusForbidden},
+		{"/v1/greeter", "jane", http.StatusOK},
+		{"/v1/greeter", "john", http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		req := httptest.NewRequest("GET", tc.path, nil)
+		if len(tc.account) > 0 {
+			req.Header.Set("Authorization", auth.BearerScheme+tc.account)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != tc.status {
+			t.Errorf("Expected %v for %v of %v, got %v", tc.status, tc.path, tc.account, w.Code)
+		}
+	}
+}
+
+func TestWrapperTickets(t *testing.T) {
+	a := &testAuth{rules: map[string][]string{"go.micro.api.greeter": {"john"}}}
+
+	var served *http.Request
+	h := authWrapper{
+		handler:       http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { served = r }),
+		auth:          a,
+		resolver:      &testResolver{},
+		servicePrefix: "go.micro.api",
+	}
+
+	ticket, err := handler.IssueTicket("john")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream := func(ticket string) *http.Request {
+		req := httptest.NewRequest("GET", "/greeter/stream?foo=bar&"+handler.TicketParam+"="+ticket, nil)
+		req.Header.Set("Accept", "text/event-stream")
+		return req
+	}
+
+	// the ticket is redeemed for the token and stripped from the url
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, stream(ticket))
+	if w.Code != http.StatusOK || served == nil {
+		t.Fatalf("Expected the ticket to authenticate the stream, got %v", w.Code)
+	}
+	if served.URL.RawQuery != "foo=bar" || strings.Contains(served.RequestURI, ticket) {
+		t.Errorf("Expected the ticket to be stripped from the url, got %v", served.RequestURI)
+	}
+	if acc, ok := auth.AccountFromContext(served.Context()); !ok || acc.ID != "john" {
+		t.Errorf("Expected the account of the ticket, got %v", acc)
+	}
+
+	// the tickets are single use
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, stream(ticket))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected a redeemed ticket to be rejected, got %v", w.Code)
+	}
+
+	// the tickets of requests which aren't streams aren't redeemed
+	ticket, err = handler.IssueTicket("john")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/greeter?"+handler.TicketParam+"="+ticket, nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected the ticket of a request which isn't a stream to be ignored, got %v", w.Code)
+	}
+}
+
+func TestWrapperOrigin(t *testing.T) {
+	h := authWrapper{
+		handler:       http.HandlerFunc(handler.Ticket),
+		auth:          &testAuth{rules: map[string][]string{"go.micro.api.greeter": {"john"}}},
+		resolver:      &testResolver{},
+		servicePrefix: "go.micro.api",
+	}
+
+	// the tokens of the headers are verified so they're trusted from other origins
+	req := httptest.NewRequest("POST", "http://localhost:8080/rpc/stream/ticket", nil)
+	req.Header.Set("Origin", "http://example.com")
+	req.Header.Set("Authorization", auth.BearerScheme+"john")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected a verified token to be trusted from another origin, got %v", w.Code)
+	}
+
+	// the cookies of the gateway aren't
+	req = httptest.NewRequest("POST", "http://localhost:8080/rpc/stream/ticket", nil)
+	req.Header.Set("Origin", "http://example.com")
+	req.AddCookie(&http.Cookie{Name: "micro-token", Value: "john"})
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected the cookie not to be trusted from another origin, got %v", w.Code)
+	}
+}
@@ -0,0 +1,410 @@
+// Package cache caches the responses of the endpoints served by the api gateway. The endpoints are
+// cached by rules in config or by the cache metadata of the endpoints in the registry.
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/api/resolver"
+	"github.com/micro/go-micro/v2/api/server"
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/micro/v2/internal/api"
+	"github.com/micro/micro/v2/internal/handler"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// the metadata of registry endpoints which caches their responses, e.g. cache_ttl=60,
+// cache_stale=300, cache_vary=X-Locale and cache_shared=true
+const (
+	MetadataTTL    = "cache_ttl"
+	MetadataStale  = "cache_stale"
+	MetadataVary   = "cache_vary"
+	MetadataShared = "cache_shared"
+)
+
+// the values responses can vary by besides the request, other values are the names of headers. The
+// responses always vary by namespace, and by account unless they're shared.
+const (
+	VaryAccount   = "account"
+	VaryNamespace = "namespace"
+)
+
+// ServiceTTL is how long the cache metadata of the endpoints of a service is kept for
+var ServiceTTL = time.Minute
+
+// Rule caches the responses of the endpoints it matches
+type Rule struct {
+	// Service and Endpoint match the resolved service and endpoint, e.g. go.micro.api.users and
+	// Users.Read. Blank or * matches any and a trailing * matches a prefix.
+	Service  string `json:"service"`
+	Endpoint string `json:"endpoint"`
+	// TTL is the number of seconds responses are fresh for
+	TTL int `json:"ttl"`
+	// Stale is the number of seconds stale responses are served for while they're revalidated
+	Stale int `json:"stale"`
+	// Vary is what the responses vary by besides the request; account, namespace or headers
+	Vary []string `json:"vary"`
+	// Shared responses are served to every account of the namespace, e.g. public catalogues. The
+	// responses of authenticated requests are otherwise cached per account.
+	Shared bool `json:"shared"`
+}
+
+// Rules are the endpoints cached by the gateway, the first rule which matches a request is used
+type Rules struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// Validate the rules
+func (r *Rules) Validate() error {
+	for i, rule := range r.Rules {
+		if rule.TTL <= 0 {
+			return fmt.Errorf("Rule %d has an invalid ttl %v", i, rule.TTL)
+		}
+		if rule.Stale < 0 {
+			return fmt.Errorf("Rule %d has an invalid stale %v", i, rule.Stale)
+		}
+	}
+	return nil
+}
+
+// metadataRule returns the rule of the cache metadata of an endpoint, nil if it isn't cached
+func metadataRule(md map[string]string) *Rule {
+	ttl, err := strconv.Atoi(md[MetadataTTL])
+	if err != nil || ttl <= 0 {
+		return nil
+	}
+
+	rule := &Rule{TTL: ttl, Shared: md[MetadataShared] == "true"}
+	if stale, err := strconv.Atoi(md[MetadataStale]); err == nil && stale > 0 {
+		rule.Stale = stale
+	}
+	for _, v := range strings.Split(md[MetadataVary], ",") {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			rule.Vary = append(rule.Vary, v)
+		}
+	}
+	return rule
+}
+
+// serviceRules are the rules of the endpoints of a service read from the registry
+type serviceRules struct {
+	rules   map[string]*Rule
+	expires time.Time
+}
+
+// Cache caches the responses of the gateway
+type Cache struct {
+	sync.RWMutex
+	rules    *Rules
+	store    Store
+	registry registry.Registry
+	prefix   string
+	// services are the rules of the endpoints in the registry
+	services map[string]*serviceRules
+	// revalidating are the keys of the stale responses being revalidated
+	revalidating map[string]bool
+}
+
+// New returns a cache which holds the responses in the store. The prefix is that of the gateway, the
+// rules match the names of services with or without it.
+func New(s Store, r registry.Registry, prefix string) *Cache {
+	return &Cache{
+		rules:        &Rules{},
+		store:        s,
+		registry:     r,
+		prefix:       prefix,
+		services:     make(map[string]*serviceRules),
+		revalidating: make(map[string]bool),
+	}
+}
+
+// Update the rules, e.g. when they're changed in config
+func (c *Cache) Update(rules *Rules) {
+	c.Lock()
+	c.rules = rules
+	c.Unlock()
+}
+
+// Rules returns the current rules
+func (c *Cache) Rules() *Rules {
+	c.RLock()
+	defer c.RUnlock()
+	return c.rules
+}
+
+// Invalidate removes the cached responses of the endpoint of the service, or all the endpoints of
+// the service if the endpoint is blank
+func (c *Cache) Invalidate(service, endpoint string) error {
+	if len(service) == 0 {
+		return fmt.Errorf("missing service")
+	}
+	prefix := service + "/"
+	if len(endpoint) > 0 {
+		prefix += endpoint + "/"
+	}
+	return c.store.Delete(prefix)
+}
+
+// registryRule returns the rule of the cache metadata of the endpoint in the registry
+func (c *Cache) registryRule(domain, service, endpoint string) *Rule {
+	if c.registry == nil {
+		return nil
+	}
+
+	key := domain + "/" + service
+	c.RLock()
+	srv, ok := c.services[key]
+	c.RUnlock()
+
+	if !ok || time.Now().After(srv.expires) {
+		srv = &serviceRules{rules: make(map[string]*Rule), expires: time.Now().Add(ServiceTTL)}
+
+		services, err := c.registry.GetService(service, registry.GetDomain(domain))
+		if err != nil && err != registry.ErrNotFound {
+			logger.Errorf("Error reading the cache metadata of %v: %v", service, err)
+		}
+		for _, s := range services {
+			for _, ep := range s.Endpoints {
+				if rule := metadataRule(ep.Metadata); rule != nil {
+					srv.rules[ep.Name] = rule
+				}
+			}
+		}
+
+		c.Lock()
+		c.services[key] = srv
+		c.Unlock()
+	}
+
+	return srv.rules[endpoint]
+}
+
+// rule returns the rule of the request along with its service and endpoint, the rules in config
+// take precedence over the registry metadata
+func (c *Cache) rule(req *http.Request) (*Rule, string, string) {
+	ep, ok := req.Context().Value(resolver.Endpoint{}).(*resolver.Endpoint)
+	if !ok || len(ep.Name) == 0 {
+		return nil, "", ""
+	}
+
+	service := api.ServiceName(req.Context(), c.prefix, ep.Name)
+	endpoint := ep.Path
+	if len(endpoint) == 0 {
+		endpoint = ep.Method
+	}
+
+	for _, r := range c.Rules().Rules {
+		if !api.MatchService(r.Service, c.prefix, service) {
+			continue
+		}
+		if api.Match(r.Endpoint, endpoint) {
+			return r, service, endpoint
+		}
+	}
+
+	domain := ep.Domain
+	if len(domain) == 0 {
+		domain = namespace.DefaultNamespace
+	}
+	return c.registryRule(domain, service, endpoint), service, endpoint
+}
+
+// key returns the key of the response to the request. The key is prefixed by the service and
+// endpoint so their responses can be invalidated. The responses of a namespace are never served to
+// another, nor those of an account to another unless the rule shares them.
+func key(req *http.Request, rule *Rule, service, endpoint string, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%s\n%s\n", req.Method, req.URL.Path, req.URL.RawQuery)
+	h.Write(body)
+
+	fmt.Fprintf(h, "\nnamespace=%s", req.Header.Get(namespace.NamespaceKey))
+	if acc, ok := auth.AccountFromContext(req.Context()); ok && !rule.Shared {
+		fmt.Fprintf(h, "\naccount=%s", acc.ID)
+	}
+
+	for _, v := range rule.Vary {
+		var value string
+		switch strings.ToLower(v) {
+		case VaryAccount:
+			if acc, ok := auth.AccountFromContext(req.Context()); ok {
+				value = acc.ID
+			}
+		case VaryNamespace:
+			value = req.Header.Get(namespace.NamespaceKey)
+		default:
+			value = req.Header.Get(v)
+		}
+		fmt.Fprintf(h, "\n%s=%s", v, value)
+	}
+
+	return service + "/" + endpoint + "/" + hex.EncodeToString(h.Sum(nil))
+}
+
+// fetch returns the response of the handler to the request
+func fetch(h http.Handler, req *http.Request) *Entry {
+	rec := api.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Status == 0 {
+		rec.Status = http.StatusOK
+	}
+
+	body := rec.Body.Bytes()
+	return &Entry{
+		Status:  rec.Status,
+		Header:  rec.Header(),
+		Body:    body,
+		ETag:    fmt.Sprintf(`"%x"`, sha1.Sum(body)),
+		Created: time.Now().UnixNano(),
+	}
+}
+
+// serve writes the response, the body isn't written if the client has the response with the etag
+func serve(w http.ResponseWriter, req *http.Request, e *Entry, status string) {
+	for k, v := range e.Header {
+		w.Header()[k] = v
+	}
+	w.Header().Set("X-Cache", status)
+	if e.Status != http.StatusOK {
+		w.WriteHeader(e.Status)
+		w.Write(e.Body)
+		return
+	}
+
+	w.Header().Set("ETag", e.ETag)
+	w.Header().Set("Age", strconv.Itoa(int(e.age(time.Now()).Seconds())))
+
+	for _, tag := range strings.Split(req.Header.Get("If-None-Match"), ",") {
+		if t := strings.TrimSpace(tag); t == e.ETag || t == "*" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	w.WriteHeader(e.Status)
+	if req.Method != "HEAD" {
+		w.Write(e.Body)
+	}
+}
+
+// detached is the context of a request without its cancellation, so stale responses can be
+// revalidated after the request has been served
+type detached struct {
+	context.Context
+}
+
+func (d detached) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (d detached) Done() <-chan struct{}       { return nil }
+func (d detached) Err() error                  { return nil }
+
+// revalidate fetches the response to the request and caches it, the response is only fetched once
+// at a time
+func (c *Cache) revalidate(h http.Handler, req *http.Request, key string, rule *Rule, body []byte) {
+	c.Lock()
+	if c.revalidating[key] {
+		c.Unlock()
+		return
+	}
+	c.revalidating[key] = true
+	c.Unlock()
+
+	defer func() {
+		c.Lock()
+		delete(c.revalidating, key)
+		c.Unlock()
+	}()
+
+	r := req.Clone(detached{req.Context()})
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	if e := fetch(h, r); e.Status == http.StatusOK {
+		if err := c.store.Set(key, e, ttl(rule)); err != nil {
+			logger.Errorf("Error caching response: %v", err)
+		}
+	}
+}
+
+// ttl returns how long the responses of the rule are kept for
+func ttl(rule *Rule) time.Duration {
+	return time.Duration(rule.TTL+rule.Stale) * time.Second
+}
+
+// Wrapper caches the responses of the handler. It's expected to be wrapped by the auth wrapper
+// which resolves the endpoint and account of the request.
+func (c *Cache) Wrapper() server.Wrapper {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			switch req.Method {
+			case "GET", "HEAD", "POST":
+			default:
+				h.ServeHTTP(w, req)
+				return
+			}
+			if handler.IsStreamRequest(req) {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			rule, service, endpoint := c.rule(req)
+			if rule == nil {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			// the body is part of the key, e.g. of rpc requests, so it's read and replaced
+			var body []byte
+			if req.Body != nil {
+				b, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				body = b
+				req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			}
+
+			k := key(req, rule, service, endpoint, body)
+
+			// clients can bypass the cache, the response is still cached
+			if !strings.Contains(req.Header.Get("Cache-Control"), "no-cache") {
+				e, err := c.store.Get(k)
+				if err != nil {
+					logger.Errorf("Error reading cached response: %v", err)
+				}
+
+				if e != nil {
+					age := e.age(time.Now())
+					if age < time.Duration(rule.TTL)*time.Second {
+						serve(w, req, e, "HIT")
+						return
+					}
+					if age < ttl(rule) {
+						serve(w, req, e, "STALE")
+						go c.revalidate(h, req, k, rule, body)
+						return
+					}
+				}
+			}
+
+			e := fetch(h, req)
+			if e.Status == http.StatusOK {
+				if err := c.store.Set(k, e, ttl(rule)); err != nil {
+					logger.Errorf("Error caching response: %v", err)
+				}
+			}
+			serve(w, req, e, "MISS")
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/api/resolver"
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// testHandler counts the requests it serves
+type testHandler struct {
+	calls int32
+}
+
+func (h *testHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := atomic.AddInt32(&h.calls, 1)
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"calls":` + string(rune('0'+n)) + `}`))
+}
+
+func testRequest(endpoint string, header map[string]string) *http.Request {
+	req := httptest.NewRequest("POST", "/users/read", strings.NewReader(`{"id":"1"}`))
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	ep := &resolver.Endpoint{Name: "go.micro.api.users", Method: endpoint}
+	return req.WithContext(context.WithValue(req.Context(), resolver.Endpoint{}, ep))
+}
+
+func TestCache(t *testing.T) {
+	h := &testHandler{}
+	c := New(NewStore(), nil, "go.micro.api")
+	c.Update(&Rules{Rules: []*Rule{
+		{Service: "users", Endpoint: "Users.Read", TTL: 60, Vary: []string{"X-Locale"}},
+	}})
+	wrapped := c.Wrapper()(h)
+
+	w := httptest.NewRecorder()
+	wrapped.ServeHTTP(w, testRequest("Users.Read", nil))
+	if w.Header().Get("X-Cache") != "MISS" || w.Body.String() != `{"calls":1}` {
+		t.Fatalf("Expected a miss, got %v %v", w.Header().Get("X-Cache"), w.Body.String())
+	}
+	etag := w.Header().Get("ETag")
+
+	w = httptest.NewRecorder()
+	wrapped.ServeHTTP(w, testRequest("Users.Read", nil))
+	if w.Header().Get("X-Cache") != "HIT" || w.Body.String() != `{"calls":1}` {
+		t.Fatalf("Expected a hit, got %v %v", w.Header().Get("X-Cache"), w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	wrapped.ServeHTTP(w, testRequest("Users.Read", map[string]string{"If-None-Match": etag}))
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("Expected not modified, got %v", w.Code)
+	}
+
+	// the responses vary by the header
+	w = httptest.NewRecorder()
+	wrapped.ServeHTTP(w, testRequest("Users.Read", map[string]string{"X-Locale": "en-GB"}))
+	if w.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("Expected a miss for another locale, got %v", w.Header().Get("X-Cache"))
+	}
+
+	// endpoints without a rule aren't cached
+	w = httptest.NewRecorder()
+	wrapped.ServeHTTP(w, testRequest("Users.Update", nil))
+	if w.Header().Get("X-Cache") != "" {
+		t.Errorf("Expected the response not to be cached, got %v", w.Header().Get("X-Cache"))
+	}
+
+	if err := c.Invalidate("go.micro.api.users", "Users.Read"); err != nil {
+		t.Fatalf("Error invalidating: %v", err)
+	}
+	w = httptest.NewRecorder()
+	wrapped.ServeHTTP(w, testRequest("Users.Read", nil))
+	if w.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("Expected a miss after invalidating, got %v", w.Header().Get("X-Cache"))
+	}
+}
+
+func TestCacheStale(t *testing.T) {
+	h := &testHandler{}
+	s := NewStore()
+	c := New(s, nil, "go.micro.api")
+	c.Update(&Rules{Rules: []*Rule{{Service: "go.micro.api.users", TTL: 60, Stale: 60}}})
+	wrapped := c.Wrapper()(h)
+
+	wrapped.ServeHTTP(httptest.NewRecorder(), testRequest("Users.Read", nil))
+
+	// age the response past its ttl
+	for _, e := range s.(*memoryStore).entries {
+		e.entry.Created = time.Now().Add(-time.Second * 90).UnixNano()
+	}
+
+	w := httptest.NewRecorder()
+	wrapped.ServeHTTP(w, testRequest("Users.Read", nil))
+	if w.Header().Get("X-Cache") != "STALE" || w.Body.String() != `{"calls":1}` {
+		t.Fatalf("Expected a stale response, got %v %v", w.Header().Get("X-Cache"), w.Body.String())
+	}
+
+	// the response is revalidated in the background
+	for i := 0; i < 100 && atomic.LoadInt32(&h.calls) < 2; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+	time.Sleep(time.Millisecond * 10)
+
+	w = httptest.NewRecorder()
+	wrapped.ServeHTTP(w, testRequest("Users.Read", nil))
+	if w.Header().Get("X-Cache") != "HIT" || w.Body.String() != `{"calls":2}` {
+		t.Errorf("Expected the revalidated response, got %v %v", w.Header().Get("X-Cache"), w.Body.String())
+	}
+}
+
+// accountRequest is a request of the account in the namespace
+func accountRequest(id, ns string) *http.Request {
+	req := testRequest("Users.Read", map[string]string{namespace.NamespaceKey: ns})
+	if len(id) == 0 {
+		return req
+	}
+	return req.WithContext(auth.ContextWithAccount(req.Context(), &auth.Account{ID: id}))
+}
+
+func TestCacheAccounts(t *testing.T) {
+	h := &testHandler{}
+	c := New(NewStore(), nil, "go.micro.api")
+	c.Update(&Rules{Rules: []*Rule{{Service: "users", Endpoint: "Users.Read", TTL: 60}}})
+	wrapped := c.Wrapper()(h)
+
+	tests := []struct {
+		account, namespace, cache string
+	}{
+		{"john", "micro", "MISS"},
+		{"john", "micro", "HIT"},
+		// the responses of an account aren't served to another or to anonymous callers
+		{"jane", "micro", "MISS"},
+		{"", "micro", "MISS"},
+		// nor to the same account in another namespace
+		{"john", "foo", "MISS"},
+	}
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		wrapped.ServeHTTP(w, accountRequest(tc.account, tc.namespace))
+		if w.Header().Get("X-Cache") != tc.cache {
+			t.Errorf("Expected a %v for %v in %v, got %v", tc.cache, tc.account, tc.namespace, w.Header().Get("X-Cache"))
+		}
+	}
+
+	// shared responses are served to every account of the namespace
+	c.Update(&Rules{Rules: []*Rule{{Service: "users", Endpoint: "Users.Read", TTL: 60, Shared: true}}})
+	for _, tc := range []struct{ account, namespace, cache string }{
+		{"john", "bar", "MISS"},
+		{"jane", "bar", "HIT"},
+		{"jane", "baz", "MISS"},
+	} {
+		w := httptest.NewRecorder()
+		wrapped.ServeHTTP(w, accountRequest(tc.account, tc.namespace))
+		if w.Header().Get("X-Cache") != tc.cache {
+			t.Errorf("Expected a shared %v for %v in %v, got %v", tc.cache, tc.account, tc.namespace, w.Header().Get("X-Cache"))
+		}
+	}
+}
+
+type testRegistry struct {
+	registry.Registry
+}
+
+func (r *testRegistry) GetService(name string, opts ...registry.GetOption) ([]*registry.Service, error) {
+	return []*registry.Service{{
+		Name: name,
+		Endpoints: []*registry.Endpoint{
+			{Name: "Users.Read", Metadata: map[string]string{MetadataTTL: "60", MetadataVary: "account, namespace", MetadataShared: "true"}},
+			{Name: "Users.Update"},
+		},
+	}}, nil
+}
+
+func TestRegistryRule(t *testing.T) {
+	c := New(NewStore(), &testRegistry{}, "go.micro.api")
+
+	rule, _, _ := c.rule(testRequest("Users.Read", nil))
+	if rule == nil || rule.TTL != 60 || len(rule.Vary) != 2 || rule.Vary[1] != VaryNamespace || !rule.Shared {
+		t.Fatalf("Expected the rule of the endpoint metadata, got %+v", rule)
+	}
+	if rule, _, _ := c.rule(testRequest("Users.Update", nil)); rule != nil {
+		t.Errorf("Expected no rule, got %+v", rule)
+	}
+
+	// the rules in config take precedence
+	c.Update(&Rules{Rules: []*Rule{{Endpoint: "Users.Read", TTL: 10}}})
+	if rule, _, _ := c.rule(testRequest("Users.Read", nil)); rule == nil || rule.TTL != 10 {
+		t.Errorf("Expected the rule in config, got %+v", rule)
+	}
+}
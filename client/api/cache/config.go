@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/logger"
+)
+
+var (
+	// ConfigNamespace and ConfigPath are where the rules are read from in the config service, e.g.
+	// micro config set api.cache '{"rules": [{"service": "go.micro.api.users", "ttl": 60}]}'
+	ConfigNamespace = "global"
+	ConfigPath      = "api.cache"
+	// ConfigInterval is how often the rules are read from config
+	ConfigInterval = time.Second * 30
+	// Topic is the topic invalidations are published to, e.g.
+	// micro publish go.micro.api.cache '{"service": "go.micro.api.users"}'
+	Topic = "go.micro.api.cache"
+)
+
+// Invalidation removes the cached responses of the endpoint of the service, or all its endpoints if
+// the endpoint is blank
+type Invalidation struct {
+	Service  string `json:"service"`
+	Endpoint string `json:"endpoint"`
+}
+
+// parseRules parses the rules from the json in config. Values set with micro config set are stored
+// as json strings so the string is parsed if the rules are one.
+func parseRules(data string) (*Rules, error) {
+	if len(data) == 0 || data == "null" {
+		return &Rules{}, nil
+	}
+
+	var s string
+	if err := json.Unmarshal([]byte(data), &s); err == nil {
+		data = s
+	}
+
+	rules := &Rules{}
+	if err := json.Unmarshal([]byte(data), rules); err != nil {
+		return nil, err
+	}
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// load reads the rules from the config service
+func (c *Cache) load(srv pb.ConfigService) error {
+	rsp, err := srv.Read(context.TODO(), &pb.ReadRequest{Namespace: ConfigNamespace, Path: ConfigPath})
+	if err != nil {
+		// there are no rules if the config hasn't been set
+		if e := errors.FromError(err); e != nil && e.Code == 404 {
+			c.Update(&Rules{})
+			return nil
+		}
+		return err
+	}
+
+	var data string
+	if rsp.Change != nil && rsp.Change.ChangeSet != nil {
+		data = rsp.Change.ChangeSet.Data
+	}
+
+	rules, err := parseRules(data)
+	if err != nil {
+		return err
+	}
+	c.Update(rules)
+	return nil
+}
+
+// Watch reads the rules from the config service every ConfigInterval so they can be changed without
+// restarting the gateway. The current rules are kept if they can't be read.
+func (c *Cache) Watch(cl client.Client, exit chan bool) {
+	srv := pb.NewConfigService("go.micro.config", cl)
+
+	t := time.NewTicker(ConfigInterval)
+	defer t.Stop()
+
+	for {
+		if err := c.load(srv); err != nil {
+			logger.Errorf("Error loading cache rules from config: %v", err)
+		}
+
+		select {
+		case <-exit:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// Handle the invalidations published to the topic. Every replica of the gateway subscribes so their
+// caches in memory are invalidated.
+func (c *Cache) Handle(ctx context.Context, inv *Invalidation) error {
+	if err := c.Invalidate(inv.Service, inv.Endpoint); err != nil {
+		logger.Errorf("Error invalidating cache of %v: %v", inv.Service, err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package cache
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+// storePrefix is prefixed to the keys of the entries written to the store
+const storePrefix = "cache/"
+
+// Entry is a cached response
+type Entry struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+	ETag   string      `json:"etag"`
+	// Created is when the response was cached, in unix nanoseconds
+	Created int64 `json:"created"`
+}
+
+// age returns how long ago the response was cached
+func (e *Entry) age(now time.Time) time.Duration {
+	return now.Sub(time.Unix(0, e.Created))
+}
+
+// Store holds the cached responses
+type Store interface {
+	// Get the entry with the key, nil if it isn't cached
+	Get(key string) (*Entry, error)
+	// Set the entry with the key, it's removed after the ttl
+	Set(key string, e *Entry, ttl time.Duration) error
+	// Delete the entries with the prefix
+	Delete(prefix string) error
+}
+
+type memoryEntry struct {
+	entry   *Entry
+	expires time.Time
+}
+
+type memoryStore struct {
+	sync.RWMutex
+	entries map[string]*memoryEntry
+}
+
+// NewStore returns a store which holds the responses in memory, the responses are local to the
+// gateway
+func NewStore() Store {
+	s := &memoryStore{entries: make(map[string]*memoryEntry)}
+	go s.prune()
+	return s
+}
+
+func (s *memoryStore) Get(key string) (*Entry, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	e, ok := s.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		return nil, nil
+	}
+	return e.entry, nil
+}
+
+func (s *memoryStore) Set(key string, e *Entry, ttl time.Duration) error {
+	s.Lock()
+	s.entries[key] = &memoryEntry{entry: e, expires: time.Now().Add(ttl)}
+	s.Unlock()
+	return nil
+}
+
+func (s *memoryStore) Delete(prefix string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	for key := range s.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.entries, key)
+		}
+	}
+	return nil
+}
+
+// prune removes the expired entries
+func (s *memoryStore) prune() {
+	t := time.NewTicker(time.Minute)
+	defer t.Stop()
+
+	for range t.C {
+		now := time.Now()
+
+		s.Lock()
+		for key, e := range s.entries {
+			if now.After(e.expires) {
+				delete(s.entries, key)
+			}
+		}
+		s.Unlock()
+	}
+}
+
+type storeStore struct {
+	store store.Store
+}
+
+// NewStoreStore returns a store which holds the responses in the store so they're shared by the
+// replicas of the gateway
+func NewStoreStore(s store.Store) Store {
+	return &storeStore{store: s}
+}
+
+func (s *storeStore) Get(key string) (*Entry, error) {
+	recs, err := s.store.Read(storePrefix + key)
+	if err == store.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	e := &Entry{}
+	if err := json.Unmarshal(recs[0].Value, e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *storeStore) Set(key string, e *Entry, ttl time.Duration) error {
+	bytes, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return s.store.Write(&store.Record{Key: storePrefix + key, Value: bytes, Expiry: ttl})
+}
+
+func (s *storeStore) Delete(prefix string) error {
+	keys, err := s.store.List(store.ListPrefix(storePrefix + prefix))
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := s.store.Delete(key); err != nil && err != store.ErrNotFound {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/micro/cli/v2"
+	"github.com/micro/go-micro/v2/config/cmd"
+	"github.com/micro/micro/v2/client/api/openapi"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// docs generates the openapi document served by the api at /openapi.json from the registry, e.g.
+// micro api docs --output openapi.json
+func docs(ctx *cli.Context) error {
+	typ := Type
+	if len(ctx.String("type")) > 0 {
+		typ = ctx.String("type")
+	}
+	ns := Namespace
+	if len(ctx.String("namespace")) > 0 {
+		ns = strings.TrimSuffix(ctx.String("namespace"), "."+typ)
+	}
+
+	opts := openapi.Options{Prefix: ns + "." + typ, Title: "Micro API", Version: ctx.App.Version}
+	doc, err := openapi.Generate(*cmd.DefaultOptions().Registry, namespace.DefaultNamespace, opts)
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if output := ctx.String("output"); len(output) > 0 {
+		return ioutil.WriteFile(output, bytes, 0644)
+	}
+	fmt.Println(string(bytes))
+	return nil
+}
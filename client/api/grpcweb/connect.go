@@ -0,0 +1,145 @@
+package grpcweb
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/micro/go-micro/v2/errors"
+)
+
+const (
+	// connectStreamContentType is the content type of connect streaming requests, unary requests
+	// are plain json
+	connectStreamContentType = "application/connect+json"
+	// jsonContentType is the content type connect requests are forwarded to services with
+	jsonContentType = "application/grpc+json"
+	// flagEndStream is the flag of the last frame of a connect stream
+	flagEndStream byte = 0x02
+)
+
+// connectCodes are the names of the grpc status codes used by connect
+var connectCodes = map[int]string{
+	codeUnknown:           "unknown",
+	codeInvalidArgument:   "invalid_argument",
+	codeDeadlineExceeded:  "deadline_exceeded",
+	codeNotFound:          "not_found",
+	codeAlreadyExists:     "already_exists",
+	codePermissionDenied:  "permission_denied",
+	codeResourceExhausted: "resource_exhausted",
+	codeUnimplemented:     "unimplemented",
+	codeInternal:          "internal",
+	codeUnavailable:       "unavailable",
+	codeUnauthenticated:   "unauthenticated",
+}
+
+// connectStatus are the http statuses of the codes of unary responses
+var connectStatus = map[int]int{
+	codeInvalidArgument:   400,
+	codeUnauthenticated:   401,
+	codePermissionDenied:  403,
+	codeNotFound:          404,
+	codeAlreadyExists:     409,
+	codeResourceExhausted: 429,
+	codeUnimplemented:     501,
+	codeUnavailable:       503,
+	codeDeadlineExceeded:  504,
+}
+
+type connectError struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// newConnectError returns the error returned to connect clients and its http status
+func newConnectError(err error) (*connectError, int) {
+	code, msg := status(err)
+	st, ok := connectStatus[code]
+	if !ok {
+		st = 500
+	}
+	return &connectError{Code: connectCodes[code], Message: msg}, st
+}
+
+// serveConnect serves a unary connect request, the json is forwarded to the service as is
+func (h *grpcWebHandler) serveConnect(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	writeError := func(err error) {
+		e, st := newConnectError(err)
+		w.WriteHeader(st)
+		json.NewEncoder(w).Encode(e)
+	}
+
+	srv, endpoint, err := h.route(r)
+	if err != nil {
+		writeError(err)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(errors.BadRequest(h.opts.Namespace, err.Error()))
+		return
+	}
+	if len(body) == 0 {
+		body = []byte("{}")
+	}
+
+	req := json.RawMessage(body)
+	newRsp := func() interface{} { return &json.RawMessage{} }
+
+	var rsp *json.RawMessage
+	send := func(r interface{}) error {
+		rsp = r.(*json.RawMessage)
+		return nil
+	}
+
+	// unary requests to streams are answered with the last response
+	if err := h.forward(r, srv, endpoint, jsonContentType, &req, newRsp, send); err != nil {
+		writeError(err)
+		return
+	}
+	if rsp == nil {
+		rsp = &json.RawMessage{'{', '}'}
+	}
+	w.Write(*rsp)
+}
+
+// serveConnectStream serves a connect streaming request, the messages are framed like grpc-web
+// and the stream is ended by a frame with the error if there is one
+func (h *grpcWebHandler) serveConnectStream(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", connectStreamContentType)
+	rw := &writer{w: w}
+
+	end := func(err error) {
+		var end struct {
+			Error *connectError `json:"error,omitempty"`
+		}
+		if err != nil {
+			end.Error, _ = newConnectError(err)
+		}
+		b, _ := json.Marshal(end)
+		rw.write(frame(flagEndStream, b))
+	}
+
+	srv, endpoint, err := h.route(r)
+	if err != nil {
+		end(err)
+		return
+	}
+
+	msg, err := readBody(r.Body, false)
+	if err != nil {
+		end(errors.BadRequest(h.opts.Namespace, err.Error()))
+		return
+	}
+
+	req := json.RawMessage(msg)
+	newRsp := func() interface{} { return &json.RawMessage{} }
+	send := func(rsp interface{}) error {
+		return rw.write(frame(flagData, *rsp.(*json.RawMessage)))
+	}
+
+	end(h.forward(r, srv, endpoint, jsonContentType, &req, newRsp, send))
+}
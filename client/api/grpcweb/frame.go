@@ -0,0 +1,154 @@
+package grpcweb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/micro/go-micro/v2/errors"
+)
+
+const (
+	// flagData and flagTrailer are the flags of the frames of a grpc-web body
+	flagData    byte = 0x00
+	flagTrailer byte = 0x80
+	// headerSize is the size of the flag and length which prefix a frame
+	headerSize = 5
+)
+
+// the grpc status codes sent to clients
+const (
+	codeOK                = 0
+	codeUnknown           = 2
+	codeInvalidArgument   = 3
+	codeDeadlineExceeded  = 4
+	codeNotFound          = 5
+	codeAlreadyExists     = 6
+	codePermissionDenied  = 7
+	codeResourceExhausted = 8
+	codeUnimplemented     = 12
+	codeInternal          = 13
+	codeUnavailable       = 14
+	codeUnauthenticated   = 16
+)
+
+// isText returns true if the content type is grpc-web-text, the base64 encoded variant used by
+// clients which can't read binary streams
+func isText(contentType string) bool {
+	return strings.HasPrefix(contentType, "application/grpc-web-text")
+}
+
+// readBody reads the message from the body of a request, base64 decoding it if it's text
+func readBody(r io.Reader, text bool) ([]byte, error) {
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if text {
+		if body, err = decodeText(body); err != nil {
+			return nil, err
+		}
+	}
+
+	// grpc-web doesn't support client streaming so the body is a single message
+	if len(body) < headerSize {
+		return nil, fmt.Errorf("invalid frame")
+	}
+	if body[0] != flagData {
+		return nil, fmt.Errorf("unsupported frame flag %x", body[0])
+	}
+	length := binary.BigEndian.Uint32(body[1:headerSize])
+	if uint32(len(body)-headerSize) < length {
+		return nil, fmt.Errorf("invalid frame length %v", length)
+	}
+	return body[headerSize : headerSize+length], nil
+}
+
+// decodeText decodes a base64 body. The body can be several padded chunks so it's decoded four
+// characters at a time.
+func decodeText(body []byte) ([]byte, error) {
+	body = bytes.Join(bytes.Fields(body), nil)
+	if len(body)%4 != 0 {
+		return nil, fmt.Errorf("invalid base64 body")
+	}
+
+	out := make([]byte, 0, len(body)/4*3)
+	buf := make([]byte, 3)
+	for i := 0; i < len(body); i += 4 {
+		n, err := base64.StdEncoding.Decode(buf, body[i:i+4])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, buf[:n]...)
+	}
+	return out, nil
+}
+
+// frame returns the data prefixed by the flag and its length
+func frame(flag byte, data []byte) []byte {
+	f := make([]byte, headerSize+len(data))
+	f[0] = flag
+	binary.BigEndian.PutUint32(f[1:headerSize], uint32(len(data)))
+	copy(f[headerSize:], data)
+	return f
+}
+
+// trailer returns the grpc status as the trailer frame sent after the messages
+func trailer(code int, message string) []byte {
+	t := fmt.Sprintf("grpc-status: %d\r\ngrpc-message: %s\r\n", code, encodeMessage(message))
+	return frame(flagTrailer, []byte(t))
+}
+
+// encodeMessage percent encodes the status message as required by grpc
+func encodeMessage(msg string) string {
+	var b strings.Builder
+	for i := 0; i < len(msg); i++ {
+		c := msg[i]
+		if c < 0x20 || c > 0x7e || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// status returns the grpc status of the error returned by a service
+func status(err error) (int, string) {
+	if err == nil {
+		return codeOK, ""
+	}
+
+	e := errors.FromError(err)
+	if e == nil {
+		return codeUnknown, err.Error()
+	}
+
+	switch e.Code {
+	case 400:
+		return codeInvalidArgument, e.Detail
+	case 401:
+		return codeUnauthenticated, e.Detail
+	case 403:
+		return codePermissionDenied, e.Detail
+	case 404:
+		return codeNotFound, e.Detail
+	case 408, 504:
+		return codeDeadlineExceeded, e.Detail
+	case 409:
+		return codeAlreadyExists, e.Detail
+	case 429:
+		return codeResourceExhausted, e.Detail
+	case 500:
+		return codeInternal, e.Detail
+	case 501:
+		return codeUnimplemented, e.Detail
+	case 503:
+		return codeUnavailable, e.Detail
+	}
+	return codeUnknown, e.Detail
+}
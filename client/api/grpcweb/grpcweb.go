@@ -0,0 +1,198 @@
+// Package grpcweb is an api handler which serves gRPC-Web and Connect style JSON requests from
+// browsers by forwarding them to gRPC services, the responses of server streaming methods are
+// streamed back to the browser
+package grpcweb
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/micro/go-micro/v2/api"
+	"github.com/micro/go-micro/v2/api/handler"
+	"github.com/micro/go-micro/v2/client"
+	raw "github.com/micro/go-micro/v2/codec/bytes"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/util/ctx"
+)
+
+const (
+	// Handler is the name of the handler
+	Handler = "grpcweb"
+	// protoContentType is the content type grpc-web requests are forwarded to services with
+	protoContentType = "application/grpc+proto"
+)
+
+type grpcWebHandler struct {
+	opts handler.Options
+}
+
+// NewHandler returns a grpc-web handler. The requests are routed by the router, which is expected
+// to use the grpc resolver, e.g. /greeter.Greeter/Hello is routed to the greeter service.
+func NewHandler(opts ...handler.Option) handler.Handler {
+	return &grpcWebHandler{opts: handler.NewOptions(opts...)}
+}
+
+// endpointName returns the endpoint of the grpc method requested, e.g. /greeter.Greeter/Hello is
+// Greeter.Hello
+func endpointName(path string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", false
+	}
+	svc := parts[0][strings.LastIndex(parts[0], ".")+1:]
+	return svc + "." + parts[1], true
+}
+
+// isStream returns true if the endpoint of the service is a stream
+func isStream(srv *api.Service, endpoint string) bool {
+	for _, s := range srv.Services {
+		for _, ep := range s.Endpoints {
+			if ep.Name == endpoint {
+				return ep.Metadata["stream"] == "true"
+			}
+		}
+	}
+	return false
+}
+
+// route returns the service and endpoint of the request
+func (h *grpcWebHandler) route(r *http.Request) (*api.Service, string, error) {
+	endpoint, ok := endpointName(r.URL.Path)
+	if !ok {
+		return nil, "", errors.New(h.opts.Namespace, "unknown method "+r.URL.Path, 501)
+	}
+	srv, err := h.opts.Router.Route(r)
+	if err != nil {
+		return nil, "", errors.New(h.opts.Namespace, err.Error(), 501)
+	}
+	return srv, endpoint, nil
+}
+
+// forward calls the endpoint of the service with the body encoded as the content type. The
+// responses are passed to send as they're received, there is one unless the endpoint is a stream.
+func (h *grpcWebHandler) forward(r *http.Request, srv *api.Service, endpoint, ct string, body interface{}, newRsp func() interface{}, send func(interface{}) error) error {
+	c := h.opts.Client
+	cx := ctx.FromRequest(r)
+	req := c.NewRequest(srv.Name, endpoint, body, client.WithContentType(ct))
+
+	if !isStream(srv, endpoint) {
+		rsp := newRsp()
+		if err := c.Call(cx, req, rsp); err != nil {
+			return err
+		}
+		return send(rsp)
+	}
+
+	stream, err := c.Stream(cx, req)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	if err := stream.Send(body); err != nil {
+		return err
+	}
+
+	for {
+		rsp := newRsp()
+		if err := stream.Recv(rsp); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := send(rsp); err != nil {
+			return err
+		}
+	}
+}
+
+// writer writes the frames of a grpc-web response, base64 encoding them if the request was text
+type writer struct {
+	w    http.ResponseWriter
+	text bool
+	// sent is true if the headers of the response have been sent
+	sent bool
+}
+
+func (w *writer) write(f []byte) error {
+	w.sent = true
+	if w.text {
+		f = []byte(base64.StdEncoding.EncodeToString(f))
+	}
+	if _, err := w.w.Write(f); err != nil {
+		return err
+	}
+	if fl, ok := w.w.(http.Flusher); ok {
+		fl.Flush()
+	}
+	return nil
+}
+
+// finish ends the response with the status of the error. The status is sent in the headers if no
+// messages were sent, as a trailers only response.
+func (w *writer) finish(err error) {
+	code, msg := status(err)
+	if !w.sent {
+		w.w.Header().Set("grpc-status", strconv.Itoa(code))
+		w.w.Header().Set("grpc-message", encodeMessage(msg))
+		w.w.WriteHeader(http.StatusOK)
+		return
+	}
+	w.write(trailer(code, msg))
+}
+
+// serveGRPCWeb serves a grpc-web request, the messages are forwarded to the service as is
+func (h *grpcWebHandler) serveGRPCWeb(w http.ResponseWriter, r *http.Request) {
+	ct := r.Header.Get("Content-Type")
+	text := isText(ct)
+	rw := &writer{w: w, text: text}
+
+	// the status is sent in the headers or trailers so it needs to be readable by the browser
+	w.Header().Set("Content-Type", ct)
+	w.Header().Set("Access-Control-Expose-Headers", "grpc-status, grpc-message")
+
+	srv, endpoint, err := h.route(r)
+	if err != nil {
+		rw.finish(err)
+		return
+	}
+
+	msg, err := readBody(r.Body, text)
+	if err != nil {
+		rw.finish(errors.BadRequest(h.opts.Namespace, err.Error()))
+		return
+	}
+
+	newRsp := func() interface{} { return &raw.Frame{} }
+	send := func(rsp interface{}) error {
+		return rw.write(frame(flagData, rsp.(*raw.Frame).Data))
+	}
+
+	rw.finish(h.forward(r, srv, endpoint, protoContentType, &raw.Frame{Data: msg}, newRsp, send))
+}
+
+func (h *grpcWebHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ct := r.Header.Get("Content-Type")
+	switch {
+	case strings.HasPrefix(ct, "application/grpc-web"):
+		h.serveGRPCWeb(w, r)
+	case strings.HasPrefix(ct, connectStreamContentType):
+		h.serveConnectStream(w, r)
+	case strings.HasPrefix(ct, "application/json"):
+		h.serveConnect(w, r)
+	default:
+		http.Error(w, "Unsupported content type "+ct, http.StatusUnsupportedMediaType)
+	}
+}
+
+func (h *grpcWebHandler) String() string {
+	return Handler
+}
@@ -0,0 +1,206 @@
+package grpcweb
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/micro/go-micro/v2/api"
+	"github.com/micro/go-micro/v2/api/handler"
+	"github.com/micro/go-micro/v2/api/router"
+	"github.com/micro/go-micro/v2/client"
+	raw "github.com/micro/go-micro/v2/codec/bytes"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/registry"
+)
+
+type testRouter struct {
+	router.Router
+}
+
+func (r *testRouter) Route(req *http.Request) (*api.Service, error) {
+	return &api.Service{
+		Name: "greeter",
+		Services: []*registry.Service{{
+			Name: "greeter",
+			Endpoints: []*registry.Endpoint{
+				{Name: "Greeter.Hello"},
+				{Name: "Greeter.Stream", Metadata: map[string]string{"stream": "true"}},
+			},
+		}},
+	}, nil
+}
+
+type testRequest struct {
+	client.Request
+	endpoint string
+	body     interface{}
+}
+
+// testClient echoes the request, streams echo it twice
+type testClient struct {
+	client.Client
+	err error
+}
+
+func (c *testClient) NewRequest(service, endpoint string, req interface{}, opts ...client.RequestOption) client.Request {
+	return &testRequest{endpoint: endpoint, body: req}
+}
+
+func (c *testClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	if c.err != nil {
+		return c.err
+	}
+	switch r := rsp.(type) {
+	case *raw.Frame:
+		r.Data = req.(*testRequest).body.(*raw.Frame).Data
+	case *json.RawMessage:
+		*r = *req.(*testRequest).body.(*json.RawMessage)
+	}
+	return nil
+}
+
+func (c *testClient) Stream(ctx context.Context, req client.Request, opts ...client.CallOption) (client.Stream, error) {
+	return &testStream{}, nil
+}
+
+type testStream struct {
+	client.Stream
+	data []byte
+	sent int
+}
+
+func (s *testStream) Send(msg interface{}) error {
+	s.data = msg.(*raw.Frame).Data
+	return nil
+}
+
+func (s *testStream) Recv(msg interface{}) error {
+	if s.sent == 2 {
+		return io.EOF
+	}
+	s.sent++
+	msg.(*raw.Frame).Data = s.data
+	return nil
+}
+
+func (s *testStream) Close() error {
+	return nil
+}
+
+// readFrames reads the frames of a response body
+func readFrames(t *testing.T, body []byte) [][]byte {
+	var frames [][]byte
+	for len(body) > 0 {
+		if len(body) < headerSize {
+			t.Fatalf("Invalid frame %v", body)
+		}
+		length := int(body[1])<<24 | int(body[2])<<16 | int(body[3])<<8 | int(body[4])
+		frames = append(frames, body[:headerSize+length])
+		body = body[headerSize+length:]
+	}
+	return frames
+}
+
+func TestGRPCWeb(t *testing.T) {
+	h := NewHandler(handler.WithRouter(&testRouter{}), handler.WithClient(&testClient{}))
+
+	req := httptest.NewRequest("POST", "/greeter.Greeter/Hello", bytes.NewReader(frame(flagData, []byte("hello"))))
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	frames := readFrames(t, w.Body.Bytes())
+	if len(frames) != 2 {
+		t.Fatalf("Expected a message and trailer, got %v frames", len(frames))
+	}
+	if string(frames[0][headerSize:]) != "hello" {
+		t.Errorf("Expected the message to be echoed, got %q", frames[0][headerSize:])
+	}
+	if frames[1][0] != flagTrailer || !bytes.Contains(frames[1], []byte("grpc-status: 0")) {
+		t.Errorf("Expected an ok trailer, got %q", frames[1])
+	}
+}
+
+func TestGRPCWebTextStream(t *testing.T) {
+	h := NewHandler(handler.WithRouter(&testRouter{}), handler.WithClient(&testClient{}))
+
+	body := base64.StdEncoding.EncodeToString(frame(flagData, []byte("hello")))
+	req := httptest.NewRequest("POST", "/greeter.Greeter/Stream", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/grpc-web-text")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	// each frame is encoded separately so the response is several padded chunks
+	rsp, err := decodeText(w.Body.Bytes())
+	if err != nil {
+		t.Fatalf("Error decoding the response: %v", err)
+	}
+
+	frames := readFrames(t, rsp)
+	if len(frames) != 3 {
+		t.Fatalf("Expected two messages and a trailer, got %v frames", len(frames))
+	}
+	for _, f := range frames[:2] {
+		if string(f[headerSize:]) != "hello" {
+			t.Errorf("Expected the message to be streamed, got %q", f[headerSize:])
+		}
+	}
+}
+
+func TestGRPCWebError(t *testing.T) {
+	c := &testClient{err: errors.NotFound("greeter", "user not found")}
+	h := NewHandler(handler.WithRouter(&testRouter{}), handler.WithClient(c))
+
+	req := httptest.NewRequest("POST", "/greeter.Greeter/Hello", bytes.NewReader(frame(flagData, []byte("hello"))))
+	req.Header.Set("Content-Type", "application/grpc-web")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if s := w.Header().Get("grpc-status"); s != "5" {
+		t.Errorf("Expected the not found status in the headers, got %q", s)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected a trailers only response, got %q", w.Body.Bytes())
+	}
+}
+
+func TestConnect(t *testing.T) {
+	h := NewHandler(handler.WithRouter(&testRouter{}), handler.WithClient(&testClient{}))
+
+	req := httptest.NewRequest("POST", "/greeter.Greeter/Hello", bytes.NewReader([]byte(`{"name":"John"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if w.Code != 200 || w.Body.String() != `{"name":"John"}` {
+		t.Errorf("Expected the json to be echoed, got %v %v", w.Code, w.Body.String())
+	}
+
+	c := &testClient{err: errors.Forbidden("greeter", "denied")}
+	h = NewHandler(handler.WithRouter(&testRouter{}), handler.WithClient(c))
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/greeter.Greeter/Hello", bytes.NewReader([]byte(`{}`)))
+	req.Header.Set("Content-Type", "application/json")
+	h.ServeHTTP(w, req)
+
+	var e connectError
+	json.Unmarshal(w.Body.Bytes(), &e)
+	if w.Code != 403 || e.Code != "permission_denied" || e.Message != "denied" {
+		t.Errorf("Expected a permission denied error, got %v %+v", w.Code, e)
+	}
+}
+
+func TestEndpointName(t *testing.T) {
+	if ep, ok := endpointName("/go.micro.srv.greeter.Greeter/Hello"); !ok || ep != "Greeter.Hello" {
+		t.Errorf("Expected Greeter.Hello, got %v", ep)
+	}
+	if _, ok := endpointName("/greeter"); ok {
+		t.Errorf("Expected an invalid method")
+	}
+}
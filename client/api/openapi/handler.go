@@ -0,0 +1,141 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// Generate returns the document of the services with the prefix in the domain of the registry
+func Generate(r registry.Registry, domain string, opts Options) (*Document, error) {
+	list, err := r.ListServices(registry.ListDomain(domain))
+	if err != nil {
+		return nil, err
+	}
+
+	// the services are listed without their endpoints by some registries so they're read, the
+	// endpoints of the versions of a service are merged
+	var services []*registry.Service
+	seen := make(map[string]bool)
+
+	for _, s := range list {
+		if seen[s.Name] || (len(opts.Prefix) > 0 && !strings.HasPrefix(s.Name, opts.Prefix+".")) {
+			continue
+		}
+		seen[s.Name] = true
+
+		versions, err := r.GetService(s.Name, registry.GetDomain(domain))
+		if err == registry.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		srv := &registry.Service{Name: s.Name}
+		endpoints := make(map[string]bool)
+		for _, v := range versions {
+			for _, ep := range v.Endpoints {
+				if endpoints[ep.Name] {
+					continue
+				}
+				endpoints[ep.Name] = true
+				srv.Endpoints = append(srv.Endpoints, ep)
+			}
+		}
+		services = append(services, srv)
+	}
+
+	return New(services, opts), nil
+}
+
+// Handler serves the document of the services in the namespace of the request
+func Handler(r registry.Registry, opts Options) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		domain := req.Header.Get(namespace.NamespaceKey)
+		if len(domain) == 0 {
+			domain = namespace.DefaultNamespace
+		}
+
+		doc, err := Generate(r, domain, opts)
+		if err != nil {
+			logger.Errorf("Error generating openapi document: %v", err)
+			http.Error(w, err.Error(), 500)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(doc)
+	}
+}
+
+var uiTemplate = template.Must(template.New("ui").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" href="{{.Assets}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="{{.Assets}}/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = function() {
+      SwaggerUIBundle({url: "{{.URL}}", dom_id: "#swagger-ui"});
+    };
+  </script>
+</body>
+</html>
+`))
+
+// uiFiles are the files of the swagger-ui-dist package used by the ui
+var uiFiles = []string{"swagger-ui-bundle.js", "swagger-ui.css"}
+
+// CheckUIDir returns an error if the files of swagger ui aren't in the dir
+func CheckUIDir(dir string) error {
+	for _, name := range uiFiles {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("%v isn't a dir of the swagger-ui-dist package: %v", dir, err)
+		}
+	}
+	return nil
+}
+
+// isUIFile returns true if the name is one of the files of swagger ui
+func isUIFile(name string) bool {
+	for _, n := range uiFiles {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// UIHandler serves swagger ui for the document served at the url, e.g. /openapi.json. The assets of
+// swagger ui are served from the dir of the swagger-ui-dist package below the path of the ui, e.g.
+// /docs/swagger-ui.css, so the ui doesn't load scripts from other sites.
+func UIHandler(url, title, dir string) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		p, name := path.Split(req.URL.Path)
+		if isUIFile(name) {
+			w.Header().Set("Cache-Control", "public, max-age=86400")
+			http.ServeFile(w, req, filepath.Join(dir, name))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		uiTemplate.Execute(w, map[string]string{
+			"Title":  title,
+			"URL":    url,
+			"Assets": strings.TrimSuffix(p+name, "/"),
+		})
+	}
+}
@@ -0,0 +1,286 @@
+// Package openapi generates an OpenAPI 3 document of the services served by the api gateway from
+// the endpoints and request and response values they register
+package openapi
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/micro/go-micro/v2/registry"
+)
+
+// Version is the version of the OpenAPI specification the documents are written in
+const Version = "3.0.3"
+
+// Document is an OpenAPI document
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       *Info               `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components *Components         `json:"components,omitempty"`
+}
+
+// Info describes the api
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem is the operations of a path keyed by the lowercase http method, e.g. post
+type PathItem map[string]*Operation
+
+// Operation is a call to an endpoint of a service
+type Operation struct {
+	Tags        []string             `json:"tags,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	OperationID string               `json:"operationId"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// RequestBody is the body of the request to an operation
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is a response of an operation
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the schema of a request or response body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components are the schemas referenced by the operations
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// Schema describes a value
+type Schema struct {
+	Ref        string             `json:"$ref,omitempty"`
+	Type       string             `json:"type,omitempty"`
+	Format     string             `json:"format,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+}
+
+// Options of the generated document
+type Options struct {
+	// Prefix is the namespace of the services served by the gateway, e.g. go.micro.api
+	Prefix string
+	// Title and Version of the api
+	Title   string
+	Version string
+}
+
+// New returns the document of the services with the prefix. The paths of the endpoints are read
+// from the api endpoint metadata if it's set, otherwise they're the paths the micro resolver
+// routes to the endpoint, e.g. /foo/bar for Foo.Bar of go.micro.api.foo.
+func New(services []*registry.Service, opts Options) *Document {
+	doc := &Document{
+		OpenAPI:    Version,
+		Info:       &Info{Title: opts.Title, Version: opts.Version},
+		Paths:      make(map[string]PathItem),
+		Components: &Components{Schemas: make(map[string]*Schema)},
+	}
+
+	// services are sorted so the document is the same for the same services
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+
+	for _, srv := range services {
+		if len(opts.Prefix) > 0 && !strings.HasPrefix(srv.Name, opts.Prefix+".") {
+			continue
+		}
+		name := strings.TrimPrefix(srv.Name, opts.Prefix+".")
+
+		s := &schemas{service: srv.Name, components: doc.Components.Schemas, types: make(map[string]bool)}
+		for _, ep := range srv.Endpoints {
+			s.collect(ep.Request)
+			s.collect(ep.Response)
+		}
+
+		for _, ep := range srv.Endpoints {
+			path := endpointPath(name, ep)
+			item, ok := doc.Paths[path]
+			if !ok {
+				item = make(PathItem)
+				doc.Paths[path] = item
+			}
+			methods := endpointMethods(ep)
+			for _, method := range methods {
+				op := s.operation(ep)
+				// operation ids are unique so they're qualified by the method if there are several
+				if len(methods) > 1 {
+					op.OperationID += "." + method
+				}
+				item[method] = op
+			}
+		}
+	}
+
+	return doc
+}
+
+// endpointPath returns the path of the endpoint, the reverse of the micro resolver
+func endpointPath(name string, ep *registry.Endpoint) string {
+	if p := ep.Metadata["path"]; len(p) > 0 {
+		// paths can be regular expressions, e.g. ^/foo/bar$
+		p = strings.Split(p, ",")[0]
+		return strings.TrimSuffix(strings.TrimPrefix(p, "^"), "$")
+	}
+
+	parts := strings.Split(name, ".")
+	method := strings.Split(ep.Name, ".")
+
+	// Foo.Bar of foo is served at /foo/bar and Bar.Zool of foo at /foo/bar/zool
+	if len(method) == 2 && strings.EqualFold(method[0], strings.Replace(parts[len(parts)-1], "-", "", -1)) {
+		method = method[1:]
+	}
+	for _, m := range method {
+		parts = append(parts, toKebab(m))
+	}
+
+	return "/" + strings.Join(parts, "/")
+}
+
+// endpointMethods returns the lowercase http methods of the endpoint, POST if they're not set
+func endpointMethods(ep *registry.Endpoint) []string {
+	m := ep.Metadata["method"]
+	if len(m) == 0 {
+		return []string{"post"}
+	}
+
+	var methods []string
+	for _, method := range strings.Split(m, ",") {
+		methods = append(methods, strings.ToLower(strings.TrimSpace(method)))
+	}
+	return methods
+}
+
+// toKebab converts the camel case method to the path the resolver converts back, e.g.
+// CreateUser => create-user
+func toKebab(s string) string {
+	var out []rune
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				out = append(out, '-')
+			}
+			r = unicode.ToLower(r)
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// schemas converts the values of the endpoints of a service into schemas
+type schemas struct {
+	service    string
+	components map[string]*Schema
+	// types are the names of the types with fields which are added to the components
+	types map[string]bool
+}
+
+// collect the types with fields, the elements of slices are referenced by their type so the types
+// are collected before the schemas are built
+func (s *schemas) collect(v *registry.Value) {
+	if v == nil || len(v.Values) == 0 {
+		return
+	}
+	s.types[v.Type] = true
+	for _, f := range v.Values {
+		s.collect(f)
+	}
+}
+
+// ref returns the name of the component of the type, types are qualified by the service since
+// most services have a Request and Response
+func (s *schemas) ref(typ string) string {
+	return s.service + "." + typ
+}
+
+func (s *schemas) operation(ep *registry.Endpoint) *Operation {
+	op := &Operation{
+		Tags:        []string{s.service},
+		Summary:     ep.Name,
+		Description: ep.Metadata["description"],
+		OperationID: s.service + "." + ep.Name,
+		Responses: map[string]*Response{
+			"200": {
+				Description: "Successful response",
+				Content:     map[string]*MediaType{"application/json": {Schema: s.schema(ep.Response)}},
+			},
+			"default": {Description: "Error response"},
+		},
+	}
+
+	if ep.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"application/json": {Schema: s.schema(ep.Request)}},
+		}
+	}
+
+	return op
+}
+
+// schema returns the schema of the value, values with fields are added to the components
+func (s *schemas) schema(v *registry.Value) *Schema {
+	if v == nil {
+		return &Schema{Type: "object"}
+	}
+	if len(v.Values) == 0 {
+		return s.typeSchema(v.Type)
+	}
+
+	ref := s.ref(v.Type)
+	if _, ok := s.components[ref]; !ok {
+		obj := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		// the component is added before the fields so recursive types reference it
+		s.components[ref] = obj
+		for _, f := range v.Values {
+			obj.Properties[f.Name] = s.schema(f)
+		}
+	}
+
+	return &Schema{Ref: "#/components/schemas/" + ref}
+}
+
+// typeSchema returns the schema of a type without fields
+func (s *schemas) typeSchema(typ string) *Schema {
+	switch typ {
+	case "string":
+		return &Schema{Type: "string"}
+	case "bool":
+		return &Schema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "uint", "uint8", "uint16", "uint32":
+		return &Schema{Type: "integer", Format: "int32"}
+	case "int64", "uint64":
+		return &Schema{Type: "integer", Format: "int64"}
+	case "float32", "float":
+		return &Schema{Type: "number", Format: "float"}
+	case "float64", "double":
+		return &Schema{Type: "number", Format: "double"}
+	case "[]uint8", "[]byte":
+		return &Schema{Type: "string", Format: "byte"}
+	}
+
+	if strings.HasPrefix(typ, "[]") {
+		return &Schema{Type: "array", Items: s.typeSchema(strings.TrimPrefix(typ, "[]"))}
+	}
+	if s.types[typ] {
+		return &Schema{Ref: "#/components/schemas/" + s.ref(typ)}
+	}
+
+	// maps, interfaces and messages without fields
+	return &Schema{Type: "object"}
+}
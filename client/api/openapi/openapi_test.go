@@ -0,0 +1,148 @@
+package openapi
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/v2/registry"
+)
+
+func TestEndpointPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint *registry.Endpoint
+		path     string
+	}{
+		{"foo", &registry.Endpoint{Name: "Foo.Bar"}, "/foo/bar"},
+		{"foo", &registry.Endpoint{Name: "Bar.Zool"}, "/foo/bar/zool"},
+		{"foo", &registry.Endpoint{Name: "Foo.CreateUser"}, "/foo/create-user"},
+		{"foo-bar", &registry.Endpoint{Name: "FooBar.Call"}, "/foo-bar/call"},
+		{"v1.foo", &registry.Endpoint{Name: "Foo.Bar"}, "/v1/foo/bar"},
+		{"foo", &registry.Endpoint{Name: "Foo.Bar", Metadata: map[string]string{"path": "^/foo/baz$"}}, "/foo/baz"},
+	}
+
+	for _, tc := range tests {
+		if p := endpointPath(tc.name, tc.endpoint); p != tc.path {
+			t.Errorf("Expected %v %v to have the path %v, got %v", tc.name, tc.endpoint.Name, tc.path, p)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	user := &registry.Value{Name: "user", Type: "User", Values: []*registry.Value{
+		{Name: "id", Type: "string"},
+		{Name: "age", Type: "int64"},
+		{Name: "tags", Type: "[]string"},
+	}}
+
+	services := []*registry.Service{
+		{
+			Name: "go.micro.api.users",
+			Endpoints: []*registry.Endpoint{
+				{
+					Name:     "Users.Create",
+					Request:  &registry.Value{Name: "CreateRequest", Type: "CreateRequest", Values: []*registry.Value{user}},
+					Response: &registry.Value{Name: "CreateResponse", Type: "CreateResponse"},
+				},
+				{
+					Name:     "Users.List",
+					Request:  &registry.Value{Name: "ListRequest", Type: "ListRequest"},
+					Response: &registry.Value{Name: "ListResponse", Type: "ListResponse", Values: []*registry.Value{{Name: "users", Type: "[]User"}}},
+					Metadata: map[string]string{"method": "GET,POST"},
+				},
+			},
+		},
+		{
+			Name:      "go.micro.srv.users",
+			Endpoints: []*registry.Endpoint{{Name: "Users.Delete"}},
+		},
+	}
+
+	doc := New(services, Options{Prefix: "go.micro.api", Title: "Micro API", Version: "latest"})
+
+	if len(doc.Paths) != 2 {
+		t.Fatalf("Expected 2 paths, got %v", len(doc.Paths))
+	}
+
+	create, ok := doc.Paths["/users/create"]["post"]
+	if !ok {
+		t.Fatalf("Expected the create operation")
+	}
+	if ref := create.RequestBody.Content["application/json"].Schema.Ref; ref != "#/components/schemas/go.micro.api.users.CreateRequest" {
+		t.Errorf("Expected the request to reference its component, got %v", ref)
+	}
+
+	list := doc.Paths["/users/list"]
+	if len(list) != 2 || list["get"] == nil || list["post"] == nil {
+		t.Fatalf("Expected the list operation to have the get and post methods, got %v", list)
+	}
+	if list["get"].OperationID == list["post"].OperationID {
+		t.Errorf("Expected the operation ids to be unique")
+	}
+
+	schemas := doc.Components.Schemas
+	u, ok := schemas["go.micro.api.users.User"]
+	if !ok {
+		t.Fatalf("Expected the user component")
+	}
+	if s := u.Properties["age"]; s.Type != "integer" || s.Format != "int64" {
+		t.Errorf("Expected age to be an int64 integer, got %v %v", s.Type, s.Format)
+	}
+	if s := u.Properties["tags"]; s.Type != "array" || s.Items.Type != "string" {
+		t.Errorf("Expected tags to be an array of strings, got %v", s.Type)
+	}
+
+	users := schemas["go.micro.api.users.ListResponse"].Properties["users"]
+	if users.Type != "array" || users.Items.Ref != "#/components/schemas/go.micro.api.users.User" {
+		t.Errorf("Expected users to be an array of user, got %v", users.Type)
+	}
+}
+
+func TestUIHandler(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swagger-ui")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := CheckUIDir(dir); err == nil {
+		t.Error("Expected an error for a dir without swagger ui")
+	}
+	for _, name := range uiFiles {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte("/* "+name+" */"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := CheckUIDir(dir); err != nil {
+		t.Errorf("Unexpected error checking the dir of swagger ui: %v", err)
+	}
+	h := UIHandler("/openapi.json", "Micro API", dir)
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest("GET", "/docs", nil))
+	body := w.Body.String()
+	if !strings.Contains(body, `src="/docs/swagger-ui-bundle.js"`) || !strings.Contains(body, `href="/docs/swagger-ui.css"`) {
+		t.Errorf("Expected the assets to be served by the gateway, got %v", body)
+	}
+	if strings.Contains(body, "https://") {
+		t.Errorf("Expected no assets to be loaded from other sites, got %v", body)
+	}
+
+	// the assets are served from the dir
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest("GET", "/docs/swagger-ui-bundle.js", nil))
+	if w.Code != 200 || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/javascript") || w.Body.String() != "/* swagger-ui-bundle.js */" {
+		t.Fatalf("Unexpected response %v %v %v", w.Code, w.Header(), w.Body.String())
+	}
+
+	// other files in the dir aren't
+	ioutil.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0644)
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest("GET", "/docs/secret.txt", nil))
+	if w.Body.String() == "secret" {
+		t.Errorf("Expected only the files of swagger ui to be served, got %v", w.Body.String())
+	}
+}
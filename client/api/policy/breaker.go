@@ -0,0 +1,123 @@
+package policy
+
+import (
+	"time"
+)
+
+// the states of a circuit breaker
+const (
+	// StateClosed lets requests through
+	StateClosed = "closed"
+	// StateOpen rejects requests until the cooldown has passed
+	StateOpen = "open"
+	// StateHalfOpen lets a request through at a time to probe whether the endpoint has recovered
+	StateHalfOpen = "half-open"
+)
+
+// BreakerStats are the stats of the circuit breaker of an endpoint
+type BreakerStats struct {
+	Service  string `json:"service"`
+	Endpoint string `json:"endpoint"`
+	State    string `json:"state"`
+	// Trips is the number of times the breaker has opened
+	Trips uint64 `json:"trips"`
+	// Requests, Failures and Rejected are the number of requests made, failed and rejected by the
+	// open breaker
+	Requests uint64 `json:"requests"`
+	Failures uint64 `json:"failures"`
+	Rejected uint64 `json:"rejected"`
+}
+
+// breaker is the circuit breaker of an endpoint, it's guarded by the lock of the enforcer
+type breaker struct {
+	stats BreakerStats
+	// failures are the consecutive failures while closed and successes the successful probes while
+	// half open
+	failures  int
+	successes int
+	// probing is true while a probe is in flight
+	probing bool
+	opened  time.Time
+}
+
+func newBreaker(service, endpoint string) *breaker {
+	return &breaker{stats: BreakerStats{Service: service, Endpoint: endpoint, State: StateClosed}}
+}
+
+// allow returns true if a request can be made and whether it's a probe of a half open breaker
+func (b *breaker) allow(p *Breaker, now time.Time) (bool, bool) {
+	switch b.stats.State {
+	case StateOpen:
+		if now.Sub(b.opened) < seconds(p.Cooldown) {
+			b.stats.Rejected++
+			return false, false
+		}
+		b.stats.State = StateHalfOpen
+		b.successes = 0
+		fallthrough
+	case StateHalfOpen:
+		if b.probing {
+			b.stats.Rejected++
+			return false, false
+		}
+		b.probing = true
+		b.stats.Requests++
+		return true, true
+	}
+
+	b.stats.Requests++
+	return true, false
+}
+
+// record the result of a request
+func (b *breaker) record(p *Breaker, ok, probe bool, now time.Time) {
+	if !ok {
+		b.stats.Failures++
+	}
+
+	if probe {
+		b.probing = false
+		if !ok {
+			b.trip(now)
+			return
+		}
+
+		b.successes++
+		probes := p.Probes
+		if probes == 0 {
+			probes = 1
+		}
+		if b.successes >= probes {
+			b.stats.State = StateClosed
+			b.failures = 0
+		}
+		return
+	}
+
+	// requests let through before the breaker opened don't affect it
+	if b.stats.State != StateClosed {
+		return
+	}
+	if ok {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= p.Failures {
+		b.trip(now)
+	}
+}
+
+// trip opens the breaker
+func (b *breaker) trip(now time.Time) {
+	b.stats.State = StateOpen
+	b.stats.Trips++
+	b.opened = now
+	b.failures = 0
+	b.successes = 0
+}
+
+// retryAfter returns the number of seconds until the open breaker lets a probe through
+func (b *breaker) retryAfter(p *Breaker, now time.Time) time.Duration {
+	return seconds(p.Cooldown) - now.Sub(b.opened)
+}
@@ -0,0 +1,89 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/logger"
+)
+
+var (
+	// ConfigNamespace and ConfigPath are where the policies are read from in the config service, e.g.
+	// micro config set api.policies '{"policies": [{"service": "go.micro.api.users", "timeout": 5}]}'
+	ConfigNamespace = "global"
+	ConfigPath      = "api.policies"
+	// ConfigInterval is how often the policies are read from config
+	ConfigInterval = time.Second * 30
+)
+
+// parseConfig parses the policies from the json in config. Values set with micro config set are
+// stored as json strings so the string is parsed if the policies are one.
+func parseConfig(data string) (*Config, error) {
+	if len(data) == 0 || data == "null" {
+		return &Config{}, nil
+	}
+
+	var s string
+	if err := json.Unmarshal([]byte(data), &s); err == nil {
+		data = s
+	}
+
+	c := &Config{}
+	if err := json.Unmarshal([]byte(data), c); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// load reads the policies from the config service
+func (e *Enforcer) load(srv pb.ConfigService) error {
+	rsp, err := srv.Read(context.TODO(), &pb.ReadRequest{Namespace: ConfigNamespace, Path: ConfigPath})
+	if err != nil {
+		// there are no policies if the config hasn't been set
+		if er := errors.FromError(err); er != nil && er.Code == 404 {
+			e.Update(&Config{})
+			return nil
+		}
+		return err
+	}
+
+	var data string
+	if rsp.Change != nil && rsp.Change.ChangeSet != nil {
+		data = rsp.Change.ChangeSet.Data
+	}
+
+	c, err := parseConfig(data)
+	if err != nil {
+		return err
+	}
+	e.Update(c)
+	return nil
+}
+
+// Watch reads the policies from the config service every ConfigInterval so they can be changed
+// without restarting the gateway. The current policies are kept if they can't be read.
+func (e *Enforcer) Watch(cl client.Client, exit chan bool) {
+	srv := pb.NewConfigService("go.micro.config", cl)
+
+	t := time.NewTicker(ConfigInterval)
+	defer t.Stop()
+
+	for {
+		if err := e.load(srv); err != nil {
+			logger.Errorf("Error loading api policies from config: %v", err)
+		}
+
+		select {
+		case <-exit:
+			return
+		case <-t.C:
+		}
+	}
+}
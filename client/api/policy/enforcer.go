@@ -0,0 +1,256 @@
+package policy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/api/resolver"
+	"github.com/micro/go-micro/v2/api/server"
+	"github.com/micro/micro/v2/internal/api"
+	"github.com/micro/micro/v2/internal/handler"
+)
+
+// Enforcer applies the policies to the requests to the gateway
+type Enforcer struct {
+	sync.RWMutex
+	config *Config
+	prefix string
+	// breakers are the circuit breakers of the endpoints keyed by service and endpoint
+	breakers map[string]*breaker
+}
+
+// New returns an enforcer of the policies of the gateway with the prefix, e.g. go.micro.api
+func New(prefix string) *Enforcer {
+	return &Enforcer{config: &Config{}, prefix: prefix, breakers: make(map[string]*breaker)}
+}
+
+// Update the policies, e.g. when they're changed in config
+func (e *Enforcer) Update(c *Config) {
+	e.Lock()
+	e.config = c
+	e.Unlock()
+}
+
+// Config returns the current policies
+func (e *Enforcer) Config() *Config {
+	e.RLock()
+	defer e.RUnlock()
+	return e.config
+}
+
+// Breakers returns the stats of the circuit breakers sorted by service and endpoint
+func (e *Enforcer) Breakers() []*BreakerStats {
+	e.RLock()
+	defer e.RUnlock()
+
+	stats := make([]*BreakerStats, 0, len(e.breakers))
+	for _, b := range e.breakers {
+		s := b.stats
+		stats = append(stats, &s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Service == stats[j].Service {
+			return stats[i].Endpoint < stats[j].Endpoint
+		}
+		return stats[i].Service < stats[j].Service
+	})
+	return stats
+}
+
+// policy returns the policy of the request along with its service and endpoint
+func (e *Enforcer) policy(req *http.Request) (*Policy, string, string) {
+	ep, ok := req.Context().Value(resolver.Endpoint{}).(*resolver.Endpoint)
+	if !ok || len(ep.Name) == 0 {
+		return nil, "", ""
+	}
+
+	service := api.ServiceName(req.Context(), e.prefix, ep.Name)
+	endpoint := ep.Path
+	if len(endpoint) == 0 {
+		endpoint = ep.Method
+	}
+
+	for _, p := range e.Config().Policies {
+		if !api.MatchService(p.Service, e.prefix, service) {
+			continue
+		}
+		if api.Match(p.Endpoint, endpoint) {
+			return p, service, endpoint
+		}
+	}
+	return nil, service, endpoint
+}
+
+// allow checks the breaker of the endpoint, the time until it lets a request through is returned if
+// it's open
+func (e *Enforcer) allow(p *Policy, service, endpoint string) (bool, bool, time.Duration) {
+	if p.Breaker == nil {
+		return true, false, 0
+	}
+
+	e.Lock()
+	defer e.Unlock()
+
+	key := service + "/" + endpoint
+	b, ok := e.breakers[key]
+	if !ok {
+		b = newBreaker(service, endpoint)
+		e.breakers[key] = b
+	}
+
+	now := time.Now()
+	if ok, probe := b.allow(p.Breaker, now); ok {
+		return true, probe, 0
+	}
+	return false, false, b.retryAfter(p.Breaker, now)
+}
+
+// record the result of a request to the endpoint
+func (e *Enforcer) record(p *Policy, service, endpoint string, ok, probe bool) {
+	if p.Breaker == nil {
+		return
+	}
+
+	e.Lock()
+	defer e.Unlock()
+
+	if b, exists := e.breakers[service+"/"+endpoint]; exists {
+		b.record(p.Breaker, ok, probe, time.Now())
+	}
+}
+
+// statusWriter records the status of a response written through to the client
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack the connection of a stream which is upgraded to a websocket
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("connection can't be hijacked")
+	}
+	if w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return h.Hijack()
+}
+
+// failed returns true if the response is a failure of the backend, i.e. a server error including
+// gateway timeouts, a request timeout or an attempt which ran past its deadline whatever its status
+func failed(status int, timedOut bool) bool {
+	return timedOut || status >= 500 || status == http.StatusRequestTimeout
+}
+
+// attempt serves the request with the timeout of the policy, it returns true if the deadline of the
+// attempt was exceeded
+func attempt(h http.Handler, w http.ResponseWriter, req *http.Request, p *Policy) bool {
+	ctx := req.Context()
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, seconds(p.Timeout))
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+	h.ServeHTTP(w, req)
+	return ctx.Err() == context.DeadlineExceeded
+}
+
+// Wrapper applies the policies to the requests to the handler. It's expected to be wrapped by the
+// auth wrapper which resolves the endpoint of the request.
+func (e *Enforcer) Wrapper() server.Wrapper {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			p, service, endpoint := e.policy(req)
+			if p == nil {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			// streams and requests which can't be retried are written through
+			retries := p.Retries
+			if !idempotent(req.Method) || handler.IsStreamRequest(req) {
+				retries = 0
+			}
+
+			var body []byte
+			if retries > 0 && req.Body != nil {
+				b, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				body = b
+			}
+
+			for i := 0; ; i++ {
+				ok, probe, wait := e.allow(p, service, endpoint)
+				if !ok {
+					retryAfter := int(math.Max(1, math.Ceil(wait.Seconds())))
+					w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+					http.Error(w, "Service unavailable", http.StatusServiceUnavailable)
+					return
+				}
+
+				if retries == 0 {
+					sw := &statusWriter{ResponseWriter: w}
+					timedOut := attempt(h, sw, req, p)
+					e.record(p, service, endpoint, !failed(sw.status, timedOut), probe)
+					return
+				}
+
+				// the response of the attempt is only written if it isn't retried
+				rec := api.NewRecorder()
+				r := req.Clone(req.Context())
+				r.Body = ioutil.NopCloser(bytes.NewReader(body))
+				timedOut := attempt(h, rec, r, p)
+				if rec.Status == 0 {
+					rec.Status = http.StatusOK
+				}
+				e.record(p, service, endpoint, !failed(rec.Status, timedOut), probe)
+
+				// the last attempt is written whether it failed or not
+				if !failed(rec.Status, timedOut) || i == retries || req.Context().Err() != nil {
+					rec.WriteResponse(w)
+					return
+				}
+
+				select {
+				case <-req.Context().Done():
+				case <-time.After(p.backoff(i + 1)):
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Package policy applies timeouts, retries and circuit breakers to the requests the api gateway
+// makes to each service and endpoint
+package policy
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Policy is applied to the requests to the endpoints it matches
+type Policy struct {
+	// Service and Endpoint match the resolved service and endpoint, e.g. go.micro.api.users and
+	// Users.Read. Blank or * matches any and a trailing * matches a prefix.
+	Service  string `json:"service"`
+	Endpoint string `json:"endpoint"`
+	// Timeout is the number of seconds each attempt of a request can take, the client default is
+	// used if it's not set
+	Timeout float64 `json:"timeout"`
+	// Retries is the number of times requests with idempotent methods are retried if they fail, the
+	// attempts are spaced by Backoff seconds which doubles after each attempt
+	Retries int     `json:"retries"`
+	Backoff float64 `json:"backoff"`
+	// Breaker stops requests to the endpoints while they're failing
+	Breaker *Breaker `json:"breaker"`
+}
+
+// Breaker is the circuit breaker of a policy
+type Breaker struct {
+	// Failures is the number of consecutive failures which trip the breaker
+	Failures int `json:"failures"`
+	// Cooldown is the number of seconds the breaker is open before a request is let through to probe
+	// the endpoint
+	Cooldown float64 `json:"cooldown"`
+	// Probes is the number of successful probes which close the breaker, one if it's not set
+	Probes int `json:"probes"`
+}
+
+// Config is the policies of the gateway, the first policy which matches a request is applied
+type Config struct {
+	Policies []*Policy `json:"policies"`
+}
+
+// Validate the policies
+func (c *Config) Validate() error {
+	for i, p := range c.Policies {
+		if p.Timeout < 0 {
+			return fmt.Errorf("Policy %d has an invalid timeout %v", i, p.Timeout)
+		}
+		if p.Retries < 0 {
+			return fmt.Errorf("Policy %d has invalid retries %v", i, p.Retries)
+		}
+		if p.Backoff < 0 {
+			return fmt.Errorf("Policy %d has an invalid backoff %v", i, p.Backoff)
+		}
+		if b := p.Breaker; b != nil {
+			if b.Failures <= 0 {
+				return fmt.Errorf("Policy %d has an invalid breaker failures %v", i, b.Failures)
+			}
+			if b.Cooldown <= 0 {
+				return fmt.Errorf("Policy %d has an invalid breaker cooldown %v", i, b.Cooldown)
+			}
+			if b.Probes < 0 {
+				return fmt.Errorf("Policy %d has invalid breaker probes %v", i, b.Probes)
+			}
+		}
+	}
+	return nil
+}
+
+// seconds converts the seconds of a policy to a duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// backoff returns how long to wait before the attempt, attempts start at one for the first retry
+func (p *Policy) backoff(attempt int) time.Duration {
+	return seconds(p.Backoff * math.Pow(2, float64(attempt-1)))
+}
+
+// idempotent returns true if requests with the method can be retried
+func idempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package policy
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/api/resolver"
+)
+
+// testHandler fails the first requests it serves
+type testHandler struct {
+	calls int
+	fail  int
+	body  string
+}
+
+func (h *testHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.calls++
+	b, _ := ioutil.ReadAll(r.Body)
+	h.body = string(b)
+	if h.calls <= h.fail {
+		http.Error(w, "unavailable", http.StatusInternalServerError)
+		return
+	}
+	w.Write([]byte(`{"calls":` + string(rune('0'+h.calls)) + `}`))
+}
+
+func testRequest(method, endpoint string) *http.Request {
+	req := httptest.NewRequest(method, "/users/read", strings.NewReader(`{"id":"1"}`))
+	ep := &resolver.Endpoint{Name: "go.micro.api.users", Method: endpoint}
+	return req.WithContext(context.WithValue(req.Context(), resolver.Endpoint{}, ep))
+}
+
+func TestRetries(t *testing.T) {
+	e := New("go.micro.api")
+	e.Update(&Config{Policies: []*Policy{{Service: "users", Retries: 2, Backoff: 0.001}}})
+
+	h := &testHandler{fail: 2}
+	w := httptest.NewRecorder()
+	e.Wrapper()(h).ServeHTTP(w, testRequest("GET", "Users.Read"))
+	if w.Code != http.StatusOK || w.Body.String() != `{"calls":3}` {
+		t.Fatalf("Expected the third attempt, got %v %v", w.Code, w.Body.String())
+	}
+	if h.body != `{"id":"1"}` {
+		t.Errorf("Expected the body to be sent with each attempt, got %v", h.body)
+	}
+
+	// requests with methods which aren't idempotent aren't retried
+	h = &testHandler{fail: 1}
+	w = httptest.NewRecorder()
+	e.Wrapper()(h).ServeHTTP(w, testRequest("POST", "Users.Read"))
+	if w.Code != http.StatusInternalServerError || h.calls != 1 {
+		t.Errorf("Expected a single failed attempt, got %v after %v calls", w.Code, h.calls)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	e := New("go.micro.api")
+	e.Update(&Config{Policies: []*Policy{{Endpoint: "Users.*", Timeout: 0.01}}})
+
+	var deadline time.Time
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, _ = r.Context().Deadline()
+	})
+	e.Wrapper()(h).ServeHTTP(httptest.NewRecorder(), testRequest("POST", "Users.Read"))
+	if deadline.IsZero() {
+		t.Error("Expected the request to have a deadline")
+	}
+}
+
+func TestBreaker(t *testing.T) {
+	e := New("go.micro.api")
+	e.Update(&Config{Policies: []*Policy{
+		{Service: "go.micro.api.users", Breaker: &Breaker{Failures: 2, Cooldown: 0.05}},
+	}})
+
+	h := &testHandler{fail: 2}
+	wrapped := e.Wrapper()(h)
+	for i := 0; i < 2; i++ {
+		wrapped.ServeHTTP(httptest.NewRecorder(), testRequest("POST", "Users.Read"))
+	}
+
+	// the breaker is open so the request is rejected
+	w := httptest.NewRecorder()
+	wrapped.ServeHTTP(w, testRequest("POST", "Users.Read"))
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "1" || h.calls != 2 {
+		t.Fatalf("Expected the request to be rejected, got %v after %v calls", w.Code, h.calls)
+	}
+	if b := e.Breakers(); len(b) != 1 || b[0].State != StateOpen || b[0].Trips != 1 || b[0].Rejected != 1 {
+		t.Fatalf("Expected an open breaker, got %+v", b[0])
+	}
+
+	// the probe after the cooldown closes the breaker
+	time.Sleep(time.Millisecond * 60)
+	w = httptest.NewRecorder()
+	wrapped.ServeHTTP(w, testRequest("POST", "Users.Read"))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected the probe to succeed, got %v", w.Code)
+	}
+	if b := e.Breakers(); b[0].State != StateClosed {
+		t.Errorf("Expected the breaker to close, got %v", b[0].State)
+	}
+}
+
+func TestSlowCalls(t *testing.T) {
+	e := New("go.micro.api")
+	e.Update(&Config{Policies: []*Policy{
+		{Service: "users", Timeout: 0.01, Breaker: &Breaker{Failures: 2, Cooldown: 1}},
+	}})
+
+	// the handler responds after the deadline of the request has passed
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		w.Write([]byte(`{}`))
+	})
+	wrapped := e.Wrapper()(h)
+	for i := 0; i < 2; i++ {
+		wrapped.ServeHTTP(httptest.NewRecorder(), testRequest("POST", "Users.Read"))
+	}
+	if b := e.Breakers(); len(b) != 1 || b[0].State != StateOpen || b[0].Failures != 2 {
+		t.Fatalf("Expected the slow calls to open the breaker, got %+v", b)
+	}
+
+	// request timeouts are failures too
+	e = New("go.micro.api")
+	e.Update(&Config{Policies: []*Policy{{Service: "users", Breaker: &Breaker{Failures: 1, Cooldown: 1}}}})
+	h = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "timeout", http.StatusRequestTimeout)
+	})
+	e.Wrapper()(h).ServeHTTP(httptest.NewRecorder(), testRequest("POST", "Users.Read"))
+	if b := e.Breakers(); len(b) != 1 || b[0].State != StateOpen {
+		t.Errorf("Expected the request timeout to open the breaker, got %+v", b)
+	}
+}
+
+func TestHalfOpen(t *testing.T) {
+	p := &Breaker{Failures: 1, Cooldown: 1}
+	b := newBreaker("go.micro.api.users", "Users.Read")
+	now := time.Now()
+
+	b.record(p, false, false, now)
+	if ok, _ := b.allow(p, now); ok {
+		t.Fatal("Expected the open breaker to reject the request")
+	}
+
+	// a single probe is let through at a time
+	now = now.Add(time.Second)
+	if ok, probe := b.allow(p, now); !ok || !probe {
+		t.Fatal("Expected a probe")
+	}
+	if ok, _ := b.allow(p, now); ok {
+		t.Fatal("Expected the request to be rejected while probing")
+	}
+
+	// a failed probe opens the breaker again
+	b.record(p, false, true, now)
+	if b.stats.State != StateOpen || b.stats.Trips != 2 {
+		t.Errorf("Expected the breaker to open again, got %v after %v trips", b.stats.State, b.stats.Trips)
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	c, err := parseConfig(`"{\"policies\": [{\"service\": \"users\", \"timeout\": 5, \"breaker\": {\"failures\": 5, \"cooldown\": 30}}]}"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Policies) != 1 || c.Policies[0].Timeout != 5 || c.Policies[0].Breaker.Failures != 5 {
+		t.Errorf("Unexpected policies %+v", c.Policies)
+	}
+
+	if _, err := parseConfig(`{"policies": [{"breaker": {"failures": 0, "cooldown": 30}}]}`); err == nil {
+		t.Error("Expected an error for a breaker without failures")
+	}
+}
@@ -0,0 +1,63 @@
+package policy
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/logger"
+	pb "github.com/micro/micro/v2/service/debug/stats/proto"
+)
+
+// ReportInterval is how often the breakers are written to the debug stats service
+var ReportInterval = time.Second * 10
+
+// snapshot returns the breakers as a debug stats snapshot
+func (e *Enforcer) snapshot(service *pb.Service) *pb.Snapshot {
+	breakers := e.Breakers()
+	snap := &pb.Snapshot{
+		Service:   service,
+		Timestamp: uint64(time.Now().Unix()),
+		Breakers:  make([]*pb.Breaker, 0, len(breakers)),
+	}
+	for _, b := range breakers {
+		snap.Breakers = append(snap.Breakers, &pb.Breaker{
+			Service:  b.Service,
+			Endpoint: b.Endpoint,
+			State:    b.State,
+			Trips:    b.Trips,
+			Requests: b.Requests,
+			Failures: b.Failures,
+			Rejected: b.Rejected,
+		})
+	}
+	return snap
+}
+
+// Report writes the breakers to the debug stats service every ReportInterval so they're shown with
+// the stats of the node of the gateway
+func (e *Enforcer) Report(cl client.Client, service *pb.Service, exit chan bool) {
+	srv := pb.NewStatsService("go.micro.debug", cl)
+
+	t := time.NewTicker(ReportInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-exit:
+			return
+		case <-t.C:
+		}
+
+		// there's nothing to report until a policy with a breaker has been applied
+		snap := e.snapshot(service)
+		if len(snap.Breakers) == 0 {
+			continue
+		}
+
+		req := &pb.WriteRequest{Service: service, Stats: snap}
+		if _, err := srv.Write(context.TODO(), req); err != nil {
+			logger.Debugf("Error reporting api breakers to debug stats: %v", err)
+		}
+	}
+}
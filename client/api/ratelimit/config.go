@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/logger"
+)
+
+var (
+	// ConfigNamespace and ConfigPath are where the limits are read from in the config service, e.g.
+	// micro config set api.ratelimit '{"rules": [{"key": "ip", "rate": 10, "burst": 20}]}'
+	ConfigNamespace = "global"
+	ConfigPath      = "api.ratelimit"
+	// ConfigInterval is how often the limits are read from config
+	ConfigInterval = time.Second * 30
+)
+
+// parseLimits parses the limits from the json in config. Values set with micro config set are stored
+// as json strings so the string is parsed if the limits are one.
+func parseLimits(data string) (*Limits, error) {
+	if len(data) == 0 || data == "null" {
+		return &Limits{}, nil
+	}
+
+	var s string
+	if err := json.Unmarshal([]byte(data), &s); err == nil {
+		data = s
+	}
+
+	limits := &Limits{}
+	if err := json.Unmarshal([]byte(data), limits); err != nil {
+		return nil, err
+	}
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+// load reads the limits from the config service
+func (l *Limiter) load(c pb.ConfigService) error {
+	rsp, err := c.Read(context.TODO(), &pb.ReadRequest{Namespace: ConfigNamespace, Path: ConfigPath})
+	if err != nil {
+		// there are no limits if the config hasn't been set
+		if e := errors.FromError(err); e != nil && e.Code == 404 {
+			l.Update(&Limits{})
+			return nil
+		}
+		return err
+	}
+
+	var data string
+	if rsp.Change != nil && rsp.Change.ChangeSet != nil {
+		data = rsp.Change.ChangeSet.Data
+	}
+
+	limits, err := parseLimits(data)
+	if err != nil {
+		return err
+	}
+	l.Update(limits)
+	return nil
+}
+
+// Watch reads the limits from the config service every ConfigInterval so they can be changed without
+// restarting the gateway. The current limits are kept if they can't be read.
+func (l *Limiter) Watch(c client.Client, exit chan bool) {
+	srv := pb.NewConfigService("go.micro.config", c)
+
+	t := time.NewTicker(ConfigInterval)
+	defer t.Stop()
+
+	for {
+		if err := l.load(srv); err != nil {
+			logger.Errorf("Error loading rate limits from config: %v", err)
+		}
+
+		select {
+		case <-exit:
+			return
+		case <-t.C:
+		}
+	}
+}
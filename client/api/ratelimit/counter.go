@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+// counterPrefix is prefixed to the keys of the buckets written to the store
+const counterPrefix = "ratelimit/"
+
+// Counter holds the token buckets of the rate limits
+type Counter interface {
+	// Take a token from the bucket with the key, refilled at rate tokens a second up to burst. The
+	// tokens remaining are returned along with whether a token was taken.
+	Take(key string, rate float64, burst int) (float64, bool, error)
+}
+
+// bucket is a token bucket
+type bucket struct {
+	Tokens  float64 `json:"tokens"`
+	Updated int64   `json:"updated"`
+}
+
+// take refills the bucket for the time since it was last updated and takes a token if there is one
+func (b *bucket) take(now time.Time, rate float64, burst int) bool {
+	if b.Updated == 0 {
+		b.Tokens = float64(burst)
+	} else if elapsed := now.Sub(time.Unix(0, b.Updated)).Seconds(); elapsed > 0 {
+		b.Tokens = math.Min(float64(burst), b.Tokens+elapsed*rate)
+	}
+	b.Updated = now.UnixNano()
+
+	if b.Tokens < 1 {
+		return false
+	}
+	b.Tokens--
+	return true
+}
+
+type memoryCounter struct {
+	sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewCounter returns a counter which holds the buckets in memory, the counts are local to the gateway
+func NewCounter() Counter {
+	c := &memoryCounter{buckets: make(map[string]*bucket)}
+	go c.prune()
+	return c
+}
+
+func (c *memoryCounter) Take(key string, rate float64, burst int) (float64, bool, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	b, ok := c.buckets[key]
+	if !ok {
+		b = &bucket{}
+		c.buckets[key] = b
+	}
+	taken := b.take(time.Now(), rate, burst)
+	return b.Tokens, taken, nil
+}
+
+// prune removes the buckets which haven't been used recently, e.g. of clients which have gone away
+func (c *memoryCounter) prune() {
+	t := time.NewTicker(time.Minute)
+	defer t.Stop()
+
+	for range t.C {
+		expired := time.Now().Add(-time.Hour).UnixNano()
+
+		c.Lock()
+		for key, b := range c.buckets {
+			if b.Updated < expired {
+				delete(c.buckets, key)
+			}
+		}
+		c.Unlock()
+	}
+}
+
+type storeCounter struct {
+	sync.Mutex
+	store store.Store
+}
+
+// NewStoreCounter returns a counter which holds the buckets in the store so they're shared by the
+// replicas of the gateway. Writes aren't transactional so concurrent requests to different replicas
+// may exceed the limit slightly.
+func NewStoreCounter(s store.Store) Counter {
+	return &storeCounter{store: s}
+}
+
+func (c *storeCounter) Take(key string, rate float64, burst int) (float64, bool, error) {
+	c.Lock()
+	defer c.Unlock()
+
+	b := &bucket{}
+	recs, err := c.store.Read(counterPrefix + key)
+	if err != nil && err != store.ErrNotFound {
+		return 0, false, err
+	} else if err == nil {
+		if err := json.Unmarshal(recs[0].Value, b); err != nil {
+			return 0, false, err
+		}
+	}
+
+	taken := b.take(time.Now(), rate, burst)
+
+	bytes, err := json.Marshal(b)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// the bucket expires once it would have been refilled
+	ttl := time.Duration(float64(burst)/rate*float64(time.Second)) + time.Minute
+	if err := c.store.Write(&store.Record{Key: counterPrefix + key, Value: bytes, Expiry: ttl}); err != nil {
+		return 0, false, err
+	}
+
+	return b.Tokens, taken, nil
+}
@@ -0,0 +1,202 @@
+// Package ratelimit limits the rate of the requests made to the api gateway using token buckets per
+// service and endpoint, auth account or client ip
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/micro/go-micro/v2/api/resolver"
+	"github.com/micro/go-micro/v2/api/server"
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/micro/v2/internal/api"
+)
+
+// the keys requests are counted by
+const (
+	// KeyEndpoint counts the requests to each endpoint the rule matches
+	KeyEndpoint = "endpoint"
+	// KeyAccount counts the requests of each auth account, requests without an account are counted
+	// by their ip
+	KeyAccount = "account"
+	// KeyIP counts the requests from each client ip
+	KeyIP = "ip"
+)
+
+// Rule limits the requests to the endpoints it matches
+type Rule struct {
+	// Service and Endpoint match the resolved service and endpoint, e.g. go.micro.api.users and
+	// Users.Create. Blank or * matches any and a trailing * matches a prefix.
+	Service  string `json:"service"`
+	Endpoint string `json:"endpoint"`
+	// Key is what the requests are counted by; endpoint, account or ip
+	Key string `json:"key"`
+	// Rate is the number of requests a second and Burst the most requests which can be made at once
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+// Limits are the rules requests to the gateway are limited by, a request is only served if every
+// rule which matches it allows it
+type Limits struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// Validate the rules of the limits
+func (l *Limits) Validate() error {
+	for i, r := range l.Rules {
+		if r.Rate <= 0 {
+			return fmt.Errorf("Rule %d has an invalid rate %v", i, r.Rate)
+		}
+		if r.Burst <= 0 {
+			return fmt.Errorf("Rule %d has an invalid burst %v", i, r.Burst)
+		}
+		switch r.Key {
+		case KeyEndpoint, KeyAccount, KeyIP:
+		default:
+			return fmt.Errorf("Rule %d has an invalid key %v, expected endpoint, account or ip", i, r.Key)
+		}
+	}
+	return nil
+}
+
+// Limiter limits the rate of requests to the gateway
+type Limiter struct {
+	sync.RWMutex
+	limits  *Limits
+	counter Counter
+	prefix  string
+}
+
+// New returns a limiter which counts requests with the counter and the prefix of the gateway, e.g.
+// go.micro.api
+func New(c Counter, prefix string) *Limiter {
+	return &Limiter{counter: c, prefix: prefix, limits: &Limits{}}
+}
+
+// Update the limits, e.g. when they're changed in config
+func (l *Limiter) Update(limits *Limits) {
+	l.Lock()
+	l.limits = limits
+	l.Unlock()
+}
+
+// Limits returns the current limits
+func (l *Limiter) Limits() *Limits {
+	l.RLock()
+	defer l.RUnlock()
+	return l.limits
+}
+
+// clientIP returns the ip the request was made from
+func clientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+// result is the state of the bucket of a rule after a request
+type result struct {
+	rule      *Rule
+	remaining float64
+	allowed   bool
+}
+
+// reset is the number of seconds until the bucket is full
+func (r *result) reset() int {
+	return int(math.Ceil((float64(r.rule.Burst) - r.remaining) / r.rule.Rate))
+}
+
+// retryAfter is the number of seconds until the bucket has a token
+func (r *result) retryAfter() int {
+	return int(math.Max(1, math.Ceil((1-r.remaining)/r.rule.Rate)))
+}
+
+// allow takes a token for the request from the bucket of every rule which matches it. The result of
+// the most restrictive rule is returned, nil if no rules match.
+func (l *Limiter) allow(req *http.Request) (*result, error) {
+	var service, endpoint string
+	if ep, ok := req.Context().Value(resolver.Endpoint{}).(*resolver.Endpoint); ok && len(ep.Name) > 0 {
+		service = api.ServiceName(req.Context(), l.prefix, ep.Name)
+		endpoint = ep.Path
+		if len(endpoint) == 0 {
+			endpoint = ep.Method
+		}
+	}
+
+	var worst *result
+	for i, r := range l.Limits().Rules {
+		if !api.MatchService(r.Service, l.prefix, service) {
+			continue
+		}
+		if !api.Match(r.Endpoint, endpoint) {
+			continue
+		}
+
+		// each rule has its own buckets, accounts and ips share a bucket across the endpoints
+		key := strconv.Itoa(i)
+		switch r.Key {
+		case KeyEndpoint:
+			key += "/endpoint/" + service + "/" + endpoint
+		case KeyAccount:
+			if acc, ok := auth.AccountFromContext(req.Context()); ok && len(acc.ID) > 0 {
+				key += "/account/" + acc.ID
+			} else {
+				key += "/ip/" + clientIP(req)
+			}
+		case KeyIP:
+			key += "/ip/" + clientIP(req)
+		}
+
+		remaining, allowed, err := l.counter.Take(key, r.Rate, r.Burst)
+		if err != nil {
+			return nil, err
+		}
+
+		res := &result{rule: r, remaining: remaining, allowed: allowed}
+		if worst == nil || (worst.allowed && !allowed) || (worst.allowed == allowed && remaining < worst.remaining) {
+			worst = res
+		}
+	}
+
+	return worst, nil
+}
+
+// Wrapper limits the requests to the handler. It's expected to be wrapped by the auth wrapper which
+// resolves the endpoint and account of the request.
+func (l *Limiter) Wrapper() server.Wrapper {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			res, err := l.allow(req)
+			if err != nil {
+				// requests aren't rejected because the counters are unavailable
+				logger.Errorf("Error rate limiting request: %v", err)
+				h.ServeHTTP(w, req)
+				return
+			}
+			if res == nil {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(res.rule.Burst))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(int(math.Max(0, math.Floor(res.remaining)))))
+			w.Header().Set("X-RateLimit-Reset", strconv.Itoa(res.reset()))
+
+			if !res.allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(res.retryAfter()))
+				http.Error(w, "Too many requests", http.StatusTooManyRequests)
+				return
+			}
+
+			h.ServeHTTP(w, req)
+		})
+	}
+}
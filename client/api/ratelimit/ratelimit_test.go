@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/micro/go-micro/v2/api/resolver"
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/store/memory"
+)
+
+func TestLimiter(t *testing.T) {
+	for _, c := range []Counter{NewCounter(), NewStoreCounter(memory.NewStore())} {
+		l := New(c, "go.micro.api")
+		l.Update(&Limits{Rules: []*Rule{
+			{Service: "users", Endpoint: "Users.Create", Key: KeyAccount, Rate: 0.001, Burst: 2},
+			{Service: "go.micro.api.*", Key: KeyIP, Rate: 0.001, Burst: 3},
+		}})
+
+		h := l.Wrapper()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+		request := func(name, endpoint, account, ip string) *httptest.ResponseRecorder {
+			req := httptest.NewRequest("POST", "/", nil)
+			req.RemoteAddr = ip + ":1234"
+			ctx := context.WithValue(req.Context(), resolver.Endpoint{}, &resolver.Endpoint{Name: name, Method: endpoint})
+			if len(account) > 0 {
+				ctx = auth.ContextWithAccount(ctx, &auth.Account{ID: account})
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req.WithContext(ctx))
+			return w
+		}
+
+		// each account has its own bucket for the endpoint
+		for i, expected := range []int{200, 200, 429} {
+			w := request("users", "Users.Create", "john", "10.0.0.1")
+			if w.Code != expected {
+				t.Fatalf("Expected request %d to be %d but got %d", i, expected, w.Code)
+			}
+		}
+		w := request("users", "Users.Create", "john", "10.0.0.1")
+		if w.Header().Get("Retry-After") == "" || w.Header().Get("X-RateLimit-Remaining") != "0" {
+			t.Errorf("Expected the rate limit headers to be set but got %v", w.Header())
+		}
+		if w := request("users", "Users.Create", "jane", "10.0.0.2"); w.Code != 200 {
+			t.Errorf("Expected another account to be allowed but got %d", w.Code)
+		}
+
+		// the ip rule has a bucket per client ip and the tightest rule is reported
+		w = request("users", "Users.Read", "", "10.0.0.2")
+		if w.Code != 200 || w.Header().Get("X-RateLimit-Limit") != "3" || w.Header().Get("X-RateLimit-Remaining") != "1" {
+			t.Errorf("Expected the ip rule to be applied but got %d %v", w.Code, w.Header())
+		}
+
+		// requests to endpoints no rules match aren't limited
+		if w := request("", "", "", "10.0.0.3"); w.Code != 200 || w.Header().Get("X-RateLimit-Limit") != "" {
+			t.Errorf("Expected the request not to be limited but got %d %v", w.Code, w.Header())
+		}
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	limits, err := parseLimits(`"{\"rules\":[{\"key\":\"ip\",\"rate\":10,\"burst\":20}]}"`)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing limits: %v", err)
+	}
+	if len(limits.Rules) != 1 || limits.Rules[0].Burst != 20 {
+		t.Errorf("Unexpected limits %+v", limits.Rules)
+	}
+
+	if _, err := parseLimits(`{"rules":[{"key":"host","rate":10,"burst":20}]}`); err == nil {
+		t.Errorf("Expected an error parsing a rule with an invalid key")
+	}
+}
@@ -0,0 +1,132 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/micro/go-micro/v2/api/resolver"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/util/ctx"
+	"github.com/micro/micro/v2/internal/api"
+)
+
+// routeResolver resolves the endpoints of the routes and falls back to another resolver for the
+// requests which don't match one
+type routeResolver struct {
+	table *Table
+	resolver.Resolver
+}
+
+func (r *routeResolver) Resolve(req *http.Request, opts ...resolver.ResolveOption) (*resolver.Endpoint, error) {
+	route, _ := r.table.Match(req)
+	if route == nil {
+		return r.Resolver.Resolve(req, opts...)
+	}
+
+	// the routes resolve the full names of services, the request is marked so the wrappers of the
+	// gateway don't prefix them like the names resolved from paths
+	*req = *req.WithContext(api.WithRouted(req.Context()))
+
+	options := resolver.NewResolveOptions(opts...)
+	return &resolver.Endpoint{
+		Name:   route.Service,
+		Domain: options.Domain,
+		Method: route.Endpoint,
+		Path:   route.Path,
+	}, nil
+}
+
+// Resolver returns a resolver which resolves the endpoints of the routes so the requests to them
+// are authorized, limited and cached by the service and endpoint they're routed to
+func (t *Table) Resolver(r resolver.Resolver) resolver.Resolver {
+	return &routeResolver{table: t, Resolver: r}
+}
+
+// handler calls the endpoints of the routes
+type handler struct {
+	table  *Table
+	client client.Client
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	route, vars := h.table.Match(r)
+	if route == nil {
+		api.WriteError(w, "go.micro.api", errors.NotFound("go.micro.api", "no route for %v %v", r.Method, r.URL.Path))
+		return
+	}
+
+	msg := &message{
+		path:   make(map[string]string),
+		query:  r.URL.Query(),
+		header: r.Header.Clone(),
+	}
+	for k, v := range vars {
+		msg.path[k] = v
+	}
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		api.WriteError(w, "go.micro.api", errors.BadRequest("go.micro.api", "error reading request: %v", err))
+		return
+	}
+	if len(bytes.TrimSpace(b)) > 0 {
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.UseNumber()
+		if err := d.Decode(&msg.body); err != nil {
+			api.WriteError(w, "go.micro.api", errors.BadRequest("go.micro.api", "error decoding request: %v", err))
+			return
+		}
+	}
+
+	transformRequest(route, msg)
+	if msg.body == nil {
+		msg.body = make(map[string]interface{})
+	}
+
+	// the headers are sent as metadata so the moved fields are set on a copy of the request
+	req := r.Clone(r.Context())
+	req.Header = msg.header
+
+	var opts []client.CallOption
+	if len(route.Version) > 0 {
+		opts = append(opts, client.WithSelectOption(selector.WithFilter(selector.FilterVersion(route.Version))))
+	}
+
+	var rsp json.RawMessage
+	creq := h.client.NewRequest(route.Service, route.Endpoint, msg.body, client.WithContentType("application/json"))
+	if err := h.client.Call(ctx.FromRequest(req), creq, &rsp, opts...); err != nil {
+		api.WriteError(w, "go.micro.api", err)
+		return
+	}
+
+	out := &message{header: w.Header()}
+	if len(rsp) > 0 {
+		d := json.NewDecoder(bytes.NewReader(rsp))
+		d.UseNumber()
+		if err := d.Decode(&out.body); err != nil {
+			api.WriteError(w, "go.micro.api", errors.InternalServerError("go.micro.api", "error decoding response: %v", err))
+			return
+		}
+	}
+
+	transformResponse(route, out)
+
+	b, err = json.Marshal(out.body)
+	if err != nil {
+		api.WriteError(w, "go.micro.api", errors.InternalServerError("go.micro.api", "error encoding response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	w.Write(b)
+}
+
+// Handler returns a handler which calls the endpoints of the routes
+func (t *Table) Handler(c client.Client) http.Handler {
+	return &handler{table: t, client: c}
+}
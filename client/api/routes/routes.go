@@ -0,0 +1,151 @@
+// Package routes maps http methods and paths declared in a routing file to service endpoints so
+// stable public apis can be published without renaming the services which serve them
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// Route maps requests with the method and path to the endpoint of a service, e.g.
+// {"method": "GET", "path": "/v1/users/{id}", "service": "go.micro.service.users", "endpoint": "Users.Read"}
+type Route struct {
+	// Method is the http method of the requests, any method matches if it's blank
+	Method string `json:"method"`
+	// Path is the template of the path of the requests, params are declared as {name} or with a
+	// pattern as {name:[0-9]+}
+	Path string `json:"path"`
+	// Service and Endpoint are called with the request
+	Service  string `json:"service"`
+	Endpoint string `json:"endpoint"`
+	// Version is the version of the service which is called, any version if it's blank. It lets
+	// versions of the api be routed to versions of the service, e.g. /v1/users to 1.0.0.
+	Version string `json:"version"`
+	// Request and Response move fields of the request and response in order, see Field
+	Request  []*Field `json:"request"`
+	Response []*Field `json:"response"`
+}
+
+// Field moves the value from one location to another, e.g. {"from": "path.id", "to": "body.user.id"}.
+// The locations are path.<param>, query.<param>, header.<name>, body.<field> or the whole body.
+// Fields are only moved to the body or headers since that's all which is sent to and from services.
+type Field struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Table is the routes of the gateway, the first route which matches a request is used
+type Table struct {
+	Routes []*Route `json:"routes"`
+
+	router *mux.Router
+}
+
+// the locations of fields
+const (
+	locationPath   = "path"
+	locationQuery  = "query"
+	locationHeader = "header"
+	locationBody   = "body"
+)
+
+// parseLocation splits a location into where it is and its name, the name of the whole body is blank
+func parseLocation(l string) (string, string, error) {
+	if l == locationBody {
+		return locationBody, "", nil
+	}
+	parts := strings.SplitN(l, ".", 2)
+	if len(parts) != 2 || len(parts[1]) == 0 {
+		return "", "", fmt.Errorf("invalid location %v", l)
+	}
+	switch parts[0] {
+	case locationPath, locationQuery, locationHeader, locationBody:
+		return parts[0], parts[1], nil
+	}
+	return "", "", fmt.Errorf("invalid location %v", l)
+}
+
+// Validate the routes
+func (t *Table) Validate() error {
+	for i, r := range t.Routes {
+		if !strings.HasPrefix(r.Path, "/") {
+			return fmt.Errorf("Route %d has an invalid path %v", i, r.Path)
+		}
+		if len(r.Service) == 0 || len(r.Endpoint) == 0 {
+			return fmt.Errorf("Route %d is missing a service or endpoint", i)
+		}
+		for _, f := range r.Request {
+			if _, _, err := parseLocation(f.From); err != nil {
+				return fmt.Errorf("Route %d has a request field with an %v", i, err)
+			}
+			if to, _, err := parseLocation(f.To); err != nil || (to != locationBody && to != locationHeader) {
+				return fmt.Errorf("Route %d has a request field with an invalid location %v", i, f.To)
+			}
+		}
+		for _, f := range r.Response {
+			if from, _, err := parseLocation(f.From); err != nil || from != locationBody {
+				return fmt.Errorf("Route %d has a response field with an invalid location %v", i, f.From)
+			}
+			if to, _, err := parseLocation(f.To); err != nil || (to != locationBody && to != locationHeader) {
+				return fmt.Errorf("Route %d has a response field with an invalid location %v", i, f.To)
+			}
+		}
+	}
+	return nil
+}
+
+// Parse the routes from json and compile their paths
+func Parse(data []byte) (*Table, error) {
+	t := &Table{}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, err
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	// the routes are matched by a mux router which is named by the index of the route
+	t.router = mux.NewRouter()
+	for i, r := range t.Routes {
+		mr := t.router.Path(r.Path).Name(strconv.Itoa(i))
+		if len(r.Method) > 0 {
+			mr.Methods(strings.ToUpper(r.Method))
+		}
+		if err := mr.GetError(); err != nil {
+			return nil, fmt.Errorf("Route %d has an invalid path %v: %v", i, r.Path, err)
+		}
+	}
+	return t, nil
+}
+
+// Load the routes from the file
+func Load(path string) (*Table, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(b)
+}
+
+// Match returns the route of the request and its path params
+func (t *Table) Match(req *http.Request) (*Route, map[string]string) {
+	if t == nil || t.router == nil {
+		return nil, nil
+	}
+
+	var m mux.RouteMatch
+	if !t.router.Match(req, &m) || m.MatchErr != nil || m.Route == nil {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(m.Route.GetName())
+	if err != nil || i >= len(t.Routes) {
+		return nil, nil
+	}
+	return t.Routes[i], m.Vars
+}
@@ -0,0 +1,132 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/metadata"
+)
+
+var testRoutes = `{"routes": [
+	{
+		"method": "GET",
+		"path": "/v1/users/{id}",
+		"service": "go.micro.service.users",
+		"endpoint": "Users.Read",
+		"version": "1.0.0",
+		"request": [
+			{"from": "path.id", "to": "body.user.id"},
+			{"from": "query.locale", "to": "header.X-Locale"}
+		],
+		"response": [
+			{"from": "body.etag", "to": "header.ETag"},
+			{"from": "body.user", "to": "body"}
+		]
+	},
+	{"method": "POST", "path": "/v1/users", "service": "go.micro.service.users", "endpoint": "Users.Create"}
+]}`
+
+type testRequest struct {
+	client.Request
+	service, endpoint string
+	body              interface{}
+}
+
+func (r *testRequest) Service() string   { return r.service }
+func (r *testRequest) Endpoint() string  { return r.endpoint }
+func (r *testRequest) Body() interface{} { return r.body }
+
+// testClient records the requests and returns the response
+type testClient struct {
+	client.Client
+	req  *testRequest
+	md   metadata.Metadata
+	opts int
+	rsp  string
+}
+
+func (c *testClient) NewRequest(service, endpoint string, req interface{}, opts ...client.RequestOption) client.Request {
+	return &testRequest{service: service, endpoint: endpoint, body: req}
+}
+
+func (c *testClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	c.req = req.(*testRequest)
+	c.md, _ = metadata.FromContext(ctx)
+	c.opts = len(opts)
+	return json.Unmarshal([]byte(c.rsp), rsp)
+}
+
+func TestMatch(t *testing.T) {
+	table, err := Parse([]byte(testRoutes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	route, vars := table.Match(httptest.NewRequest("GET", "/v1/users/123", nil))
+	if route == nil || route.Endpoint != "Users.Read" || vars["id"] != "123" {
+		t.Fatalf("Expected Users.Read with the id, got %+v %v", route, vars)
+	}
+	if route, _ := table.Match(httptest.NewRequest("DELETE", "/v1/users/123", nil)); route != nil {
+		t.Errorf("Expected the method not to match, got %+v", route)
+	}
+	if route, _ := table.Match(httptest.NewRequest("POST", "/v2/users", nil)); route != nil {
+		t.Errorf("Expected the path not to match, got %+v", route)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	table, err := Parse([]byte(testRoutes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := &testClient{rsp: `{"user": {"id": "123", "name": "john"}, "etag": "abc"}`}
+	w := httptest.NewRecorder()
+	table.Handler(c).ServeHTTP(w, httptest.NewRequest("GET", "/v1/users/123?locale=en-GB&fields=name", nil))
+
+	if c.req.service != "go.micro.service.users" || c.req.endpoint != "Users.Read" || c.opts != 1 {
+		t.Fatalf("Expected Users.Read of version 1.0.0, got %v %v", c.req.service, c.req.endpoint)
+	}
+	b, _ := json.Marshal(c.req.body)
+	if string(b) != `{"fields":"name","user":{"id":"123"}}` {
+		t.Errorf("Unexpected request %v", string(b))
+	}
+	if locale, _ := c.md.Get("X-Locale"); locale != "en-GB" {
+		t.Errorf("Expected the locale in the metadata, got %v", locale)
+	}
+
+	if w.Code != http.StatusOK || w.Body.String() != `{"id":"123","name":"john"}` {
+		t.Errorf("Unexpected response %v %v", w.Code, w.Body.String())
+	}
+	if w.Header().Get("ETag") != "abc" {
+		t.Errorf("Expected the etag header, got %v", w.Header().Get("ETag"))
+	}
+
+	// the body is sent as is
+	c.rsp = `{}`
+	w = httptest.NewRecorder()
+	table.Handler(c).ServeHTTP(w, httptest.NewRequest("POST", "/v1/users", strings.NewReader(`{"name": "john"}`)))
+	b, _ = json.Marshal(c.req.body)
+	if c.req.endpoint != "Users.Create" || string(b) != `{"name":"john"}` || c.opts != 0 {
+		t.Errorf("Unexpected request %v %v", c.req.endpoint, string(b))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	invalid := []string{
+		`{"routes": [{"path": "users", "service": "users", "endpoint": "Users.Read"}]}`,
+		`{"routes": [{"path": "/users"}]}`,
+		`{"routes": [{"path": "/users", "service": "users", "endpoint": "Users.Read", "request": [{"from": "path.id", "to": "query.id"}]}]}`,
+		`{"routes": [{"path": "/users", "service": "users", "endpoint": "Users.Read", "response": [{"from": "header.id", "to": "body"}]}]}`,
+	}
+	for _, data := range invalid {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Expected an error for %v", data)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// message is the parts of a request or response which fields are moved between
+type message struct {
+	path   map[string]string
+	query  url.Values
+	header http.Header
+	body   interface{}
+}
+
+// get returns the value at the location
+func (m *message) get(l string) (interface{}, bool) {
+	where, name, _ := parseLocation(l)
+
+	switch where {
+	case locationPath:
+		v, ok := m.path[name]
+		return v, ok
+	case locationQuery:
+		v, ok := m.query[name]
+		if !ok || len(v) == 0 {
+			return nil, false
+		}
+		return queryValue(v), true
+	case locationHeader:
+		if v := m.header.Get(name); len(v) > 0 {
+			return v, true
+		}
+		return nil, false
+	}
+
+	if len(name) == 0 {
+		return m.body, m.body != nil
+	}
+	parent, key := lookup(m.body, name, false)
+	if parent == nil {
+		return nil, false
+	}
+	v, ok := parent[key]
+	return v, ok
+}
+
+// remove the value at the location
+func (m *message) remove(l string) {
+	where, name, _ := parseLocation(l)
+
+	switch where {
+	case locationPath:
+		delete(m.path, name)
+	case locationQuery:
+		m.query.Del(name)
+	case locationHeader:
+		m.header.Del(name)
+	case locationBody:
+		if len(name) == 0 {
+			m.body = nil
+			return
+		}
+		if parent, key := lookup(m.body, name, false); parent != nil {
+			delete(parent, key)
+		}
+	}
+}
+
+// set the value at the location, only the body and headers can be set
+func (m *message) set(l string, v interface{}) {
+	where, name, _ := parseLocation(l)
+
+	switch where {
+	case locationHeader:
+		if s, ok := v.(string); ok {
+			m.header.Set(name, s)
+			return
+		}
+		b, _ := json.Marshal(v)
+		m.header.Set(name, string(b))
+	case locationBody:
+		if len(name) == 0 {
+			m.body = v
+			return
+		}
+		if _, ok := m.body.(map[string]interface{}); !ok {
+			m.body = make(map[string]interface{})
+		}
+		parent, key := lookup(m.body, name, true)
+		parent[key] = v
+	}
+}
+
+// move the field, it's skipped if there's no value to move
+func (m *message) move(f *Field) {
+	v, ok := m.get(f.From)
+	if !ok {
+		return
+	}
+	m.remove(f.From)
+	m.set(f.To, v)
+}
+
+// lookup returns the object which holds the dotted field of the body and the key of the field in
+// it. The objects on the way to the field are created if create is true.
+func lookup(body interface{}, field string, create bool) (map[string]interface{}, string) {
+	obj, ok := body.(map[string]interface{})
+	if !ok {
+		return nil, ""
+	}
+
+	parts := strings.Split(field, ".")
+	for _, p := range parts[:len(parts)-1] {
+		next, ok := obj[p].(map[string]interface{})
+		if !ok {
+			if !create {
+				return nil, ""
+			}
+			next = make(map[string]interface{})
+			obj[p] = next
+		}
+		obj = next
+	}
+	return obj, parts[len(parts)-1]
+}
+
+// queryValue returns the value of a query param, params which are repeated are lists
+func queryValue(v []string) interface{} {
+	if len(v) == 1 {
+		return v[0]
+	}
+	l := make([]interface{}, len(v))
+	for i, s := range v {
+		l[i] = s
+	}
+	return l
+}
+
+// transformRequest moves the fields of the request to the body and headers sent to the service.
+// The path and query params which aren't moved are set in the body by their names, unless the body
+// already has the field.
+func transformRequest(r *Route, msg *message) {
+	for _, f := range r.Request {
+		msg.move(f)
+	}
+
+	if len(msg.path) == 0 && len(msg.query) == 0 {
+		return
+	}
+	if msg.body == nil {
+		msg.body = make(map[string]interface{})
+	}
+	body, ok := msg.body.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for k, v := range msg.path {
+		if _, exists := body[k]; !exists {
+			body[k] = v
+		}
+	}
+	for k, v := range msg.query {
+		if _, exists := body[k]; !exists && len(v) > 0 {
+			body[k] = queryValue(v)
+		}
+	}
+}
+
+// transformResponse moves the fields of the response of the service to the body and headers
+// returned to the client
+func transformResponse(r *Route, msg *message) {
+	for _, f := range r.Response {
+		msg.move(f)
+	}
+}
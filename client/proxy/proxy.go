@@ -2,6 +2,7 @@
 package proxy
 
 import (
+	"crypto/tls"
 	"os"
 	"strings"
 
@@ -113,6 +114,9 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 		server.Broker(bmem.NewBroker()),
 	}
 
+	// the tls config is also used by the http server of the transcoder
+	var tlsConfig *tls.Config
+
 	// enable acme will create a net.Listener which
 	if ctx.Bool("enable_acme") {
 		var ap acme.Provider
@@ -163,6 +167,7 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 
 		// set the tls config
 		serverOpts = append(serverOpts, server.TLSConfig(config))
+		tlsConfig = config
 		// enable tls will leverage tls certs and generate a tls.Config
 	} else if ctx.Bool("enable_tls") {
 		// get certificates from the context
@@ -172,6 +177,7 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 			return
 		}
 		serverOpts = append(serverOpts, server.TLSConfig(config))
+		tlsConfig = config
 	}
 
 	// add auth wrapper to server
@@ -189,15 +195,24 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 	authOpt := server.WrapHandler(wrapper.AuthHandler(authFn))
 	serverOpts = append(serverOpts, authOpt)
 
+	// the client of the proxy which also calls the shadow services the traffic is mirrored to
+	proxyClient := service.Client()
+
 	// set proxy
 	switch Protocol {
 	case "http":
+		// without an endpoint to proxy to http/json is served and transcoded to the protocol of the
+		// services
+		if len(Endpoint) == 0 {
+			runTranscoder(service, tlsConfig)
+			return
+		}
 		p = http.NewProxy(popts...)
 		serverOpts = append(serverOpts, server.WithRouter(p))
-		// TODO: http server
 		srv = server.NewServer(serverOpts...)
 	case "mucp":
-		popts = append(popts, proxy.WithClient(mucli.NewClient()))
+		proxyClient = mucli.NewClient()
+		popts = append(popts, proxy.WithClient(proxyClient))
 		p = mucp.NewProxy(popts...)
 
 		serverOpts = append(serverOpts, server.WithRouter(p))
@@ -209,6 +224,14 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 		srv = sgrpc.NewServer(serverOpts...)
 	}
 
+	// mirror and record the traffic if it's enabled
+	p, closeTraffic, err := trafficProxy(ctx, p, proxyClient)
+	if err != nil {
+		log.Fatalf("Error setting up the traffic of the proxy: %v", err)
+	}
+	defer closeTraffic()
+	srv.Init(server.WithRouter(p))
+
 	if len(Endpoint) > 0 {
 		log.Infof("Proxy [%s] serving endpoint: %s", p.String(), Endpoint)
 	} else {
@@ -251,7 +274,7 @@ func Commands(options ...micro.Option) []*cli.Command {
 			},
 			&cli.StringFlag{
 				Name:    "protocol",
-				Usage:   "Set the protocol used for proxying e.g mucp, grpc, http",
+				Usage:   "Set the protocol used for proxying e.g mucp, grpc, http. Without an endpoint http serves json which is transcoded to the services",
 				EnvVars: []string{"MICRO_PROXY_PROTOCOL"},
 			},
 			&cli.StringFlag{
@@ -259,6 +282,59 @@ func Commands(options ...micro.Option) []*cli.Command {
 				Usage:   "Set the endpoint to route to e.g greeter or localhost:9090",
 				EnvVars: []string{"MICRO_PROXY_ENDPOINT"},
 			},
+			&cli.StringFlag{
+				Name:    "mirror_version",
+				Usage:   "Mirror requests to this version of the services, their responses are discarded and compared. Not applied when transcoding json, i.e. http without an endpoint",
+				EnvVars: []string{"MICRO_PROXY_MIRROR_VERSION"},
+			},
+			&cli.Float64Flag{
+				Name:    "mirror_percent",
+				Usage:   "Set the percentage of requests which are mirrored",
+				EnvVars: []string{"MICRO_PROXY_MIRROR_PERCENT"},
+				Value:   100,
+			},
+			&cli.StringFlag{
+				Name:    "mirror_diffs",
+				Usage:   "Write the responses of the mirror which differ to this file rather than the log",
+				EnvVars: []string{"MICRO_PROXY_MIRROR_DIFFS"},
+			},
+			&cli.StringFlag{
+				Name:    "record",
+				Usage:   "Record the requests and responses to this file so they can be replayed. Not applied when transcoding json, i.e. http without an endpoint",
+				EnvVars: []string{"MICRO_PROXY_RECORD"},
+			},
+			&cli.BoolFlag{
+				Name:    "record_credentials",
+				Usage:   "Record the authorization and cookie headers of the requests, they're left out by default",
+				EnvVars: []string{"MICRO_PROXY_RECORD_CREDENTIALS"},
+			},
+		},
+		Subcommands: []*cli.Command{
+			{
+				Name:   "replay",
+				Usage:  "Replay recorded requests against the services and compare the responses",
+				Action: replay,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "file",
+						Aliases: []string{"f"},
+						Usage:   "Set the file of the recorded requests",
+					},
+					&cli.StringFlag{
+						Name:  "service",
+						Usage: "Replay the requests against this service rather than the recorded one",
+					},
+					&cli.StringFlag{
+						Name:  "version",
+						Usage: "Replay the requests against this version of the services",
+					},
+					&cli.IntFlag{
+						Name:  "timeout",
+						Usage: "Set the timeout of each request in seconds",
+						Value: 10,
+					},
+				},
+			},
 		},
 		Action: func(ctx *cli.Context) error {
 			Run(ctx, options...)
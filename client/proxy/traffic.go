@@ -0,0 +1,113 @@
+package proxy
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/micro/cli/v2"
+	mucli "github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/config/cmd"
+	log "github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/proxy"
+	"github.com/micro/micro/v2/client/proxy/traffic"
+)
+
+// trafficProxy wraps the proxy to mirror the requests to a shadow version of the services and record
+// them if it's enabled by the flags. The returned func waits for the mirrored requests in flight,
+// logs their stats and closes the files of the diffs and records.
+func trafficProxy(ctx *cli.Context, p proxy.Proxy, c mucli.Client) (proxy.Proxy, func(), error) {
+	version := ctx.String("mirror_version")
+	if len(version) == 0 && len(ctx.String("record")) == 0 {
+		return p, func() {}, nil
+	}
+
+	var files []io.Closer
+	closeFiles := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+
+	opts := traffic.Options{
+		Client:            c,
+		Version:           version,
+		Percent:           ctx.Float64("mirror_percent"),
+		RecordCredentials: ctx.Bool("record_credentials"),
+	}
+
+	// the diffs and records contain the bodies of the requests so they're only readable by the owner
+	if path := ctx.String("mirror_diffs"); len(path) > 0 {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, f)
+		opts.Diffs = f
+	}
+
+	if path := ctx.String("record"); len(path) > 0 {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			closeFiles()
+			return nil, nil, err
+		}
+		files = append(files, f)
+		opts.Recorder = traffic.NewRecorder(f)
+		log.Infof("Proxy recording requests to %s", path)
+	}
+
+	if len(version) > 0 {
+		log.Infof("Proxy mirroring %v%% of requests to version %s", opts.Percent, version)
+	}
+
+	tp := traffic.NewProxy(p, opts)
+	closeTraffic := func() {
+		tp.Close()
+		if len(version) > 0 {
+			s := tp.Stats()
+			log.Infof("Proxy mirrored %d requests to version %s, %d matched, %d differed and %d were skipped", s.Mirrored, version, s.Matched, s.Differed, s.Skipped)
+		}
+		closeFiles()
+	}
+	return tp, closeTraffic, nil
+}
+
+// replay the recorded requests against the services and print the responses which differ
+func replay(ctx *cli.Context) error {
+	path := ctx.String("file")
+	if len(path) == 0 {
+		return fmt.Errorf("missing the file of the recorded requests")
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	records, err := traffic.ReadRecords(f)
+	if err != nil {
+		return fmt.Errorf("error reading %v: %v", path, err)
+	}
+
+	res := traffic.Replay(*cmd.DefaultOptions().Client, records, traffic.ReplayOptions{
+		Service: ctx.String("service"),
+		Version: ctx.String("version"),
+		Timeout: time.Duration(ctx.Int("timeout")) * time.Second,
+	})
+
+	for _, d := range res.Diffs {
+		fmt.Printf("%s %s\n", d.Service, d.Endpoint)
+		fmt.Printf("  request:  %q\n", d.Request)
+		fmt.Printf("  expected: %q %s\n", d.Expected, d.ExpectedError)
+		fmt.Printf("  actual:   %q %s\n", d.Actual, d.ActualError)
+	}
+	fmt.Printf("%d requests replayed, %d matched, %d differed\n", res.Total, res.Matched, len(res.Diffs))
+
+	if len(res.Diffs) > 0 {
+		return fmt.Errorf("%d of %d responses differed", len(res.Diffs), res.Total)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package traffic
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/micro/go-micro/v2/errors"
+)
+
+// Diff is a response which differs from the expected one
+type Diff struct {
+	Time     string `json:"time"`
+	Service  string `json:"service"`
+	Endpoint string `json:"endpoint"`
+	// Version is the version of the service which returned the actual response
+	Version  string `json:"version,omitempty"`
+	Request  []byte `json:"request"`
+	Expected []byte `json:"expected,omitempty"`
+	Actual   []byte `json:"actual,omitempty"`
+	// ExpectedError and ActualError are the errors returned instead of the responses
+	ExpectedError string `json:"expected_error,omitempty"`
+	ActualError   string `json:"actual_error,omitempty"`
+}
+
+// errorString returns the error as it's recorded
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// equalErrors returns true if the errors are the same, micro errors are compared by their code and
+// detail since their ids can differ between versions
+func equalErrors(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+	ea, eb := errors.Parse(a), errors.Parse(b)
+	return ea.Code != 0 && ea.Code == eb.Code && ea.Detail == eb.Detail
+}
+
+// equalBodies returns true if the responses are equal, json is compared by value so the order of
+// the fields doesn't matter
+func equalBodies(contentType string, a, b []byte) bool {
+	if bytes.Equal(a, b) {
+		return true
+	}
+	if !strings.Contains(contentType, "json") {
+		return false
+	}
+
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// compare the actual response of the record with the recorded one, nil is returned if they're equal
+func compare(rec *Record, actual []byte, actualErr error) *Diff {
+	if equalErrors(rec.Error, errorString(actualErr)) && (len(rec.Error) > 0 || equalBodies(rec.ContentType, rec.Response, actual)) {
+		return nil
+	}
+
+	d := &Diff{
+		Time:          rec.Time,
+		Service:       rec.Service,
+		Endpoint:      rec.Endpoint,
+		Request:       rec.Request,
+		Expected:      rec.Response,
+		ExpectedError: rec.Error,
+		ActualError:   errorString(actualErr),
+	}
+	if actualErr == nil {
+		d.Actual = actual
+	}
+	return d
+}
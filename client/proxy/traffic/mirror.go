@@ -0,0 +1,258 @@
+package traffic
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/micro/go-micro/v2/codec/bytes"
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/metadata"
+	"github.com/micro/go-micro/v2/proxy"
+	"github.com/micro/go-micro/v2/server"
+)
+
+var (
+	// MirrorTimeout is how long the shadow service has to respond to a mirrored request
+	MirrorTimeout = time.Second * 10
+	// MaxMirrored is the max number of mirrored requests in flight, requests are skipped rather than
+	// mirrored while the shadow version is this far behind
+	MaxMirrored = 100
+	// CredentialHeaders are the headers left out of the records unless RecordCredentials is set
+	CredentialHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+)
+
+// Options of the proxy
+type Options struct {
+	// Client calls the shadow version of the services
+	Client client.Client
+	// Version is the version of the services the requests are mirrored to, nothing is mirrored if
+	// it's blank. The responses of the shadow version are discarded and compared with those served.
+	Version string
+	// Percent is the percentage of the requests which are mirrored
+	Percent float64
+	// Diffs are written to as lines of json, they're logged if it's nil
+	Diffs io.Writer
+	// Recorder records the requests and responses, nothing is recorded if it's nil
+	Recorder *Recorder
+	// RecordCredentials records the CredentialHeaders of the requests, they're left out by default
+	RecordCredentials bool
+}
+
+// Stats are the number of requests mirrored, how many of the responses differed and the number of
+// requests which weren't mirrored since too many were in flight
+type Stats struct {
+	Mirrored uint64 `json:"mirrored"`
+	Matched  uint64 `json:"matched"`
+	Differed uint64 `json:"differed"`
+	Skipped  uint64 `json:"skipped"`
+}
+
+// Proxy wraps a proxy to mirror and record the requests it serves, streams are served as is
+type Proxy struct {
+	proxy.Proxy
+	opts Options
+	// inflight limits the mirrored requests in flight to MaxMirrored
+	inflight chan struct{}
+	shadows  sync.WaitGroup
+
+	sync.Mutex
+	stats  Stats
+	closed bool
+}
+
+// NewProxy wraps the proxy
+func NewProxy(p proxy.Proxy, opts Options) *Proxy {
+	return &Proxy{Proxy: p, opts: opts, inflight: make(chan struct{}, MaxMirrored)}
+}
+
+// Close stops mirroring requests and waits for those in flight so their diffs are written before
+// the writer is closed
+func (p *Proxy) Close() {
+	p.Lock()
+	p.closed = true
+	p.Unlock()
+	p.shadows.Wait()
+}
+
+// request keeps the body of the request when the proxy reads it
+type request struct {
+	server.Request
+	body []byte
+}
+
+func (r *request) Read() ([]byte, error) {
+	b, err := r.Request.Read()
+	if err == nil && r.body == nil {
+		r.body = b
+	}
+	return b, err
+}
+
+// response keeps the body of the response when the proxy writes it
+type response struct {
+	server.Response
+	body []byte
+}
+
+func (r *response) Write(b []byte) error {
+	r.body = append(r.body, b...)
+	return r.Response.Write(b)
+}
+
+// mirror returns true if the request should be mirrored
+func (p *Proxy) mirror() bool {
+	return len(p.opts.Version) > 0 && rand.Float64()*100 < p.opts.Percent
+}
+
+// Stats returns the stats of the mirrored requests
+func (p *Proxy) Stats() Stats {
+	return Stats{
+		Mirrored: atomic.LoadUint64(&p.stats.Mirrored),
+		Matched:  atomic.LoadUint64(&p.stats.Matched),
+		Differed: atomic.LoadUint64(&p.stats.Differed),
+		Skipped:  atomic.LoadUint64(&p.stats.Skipped),
+	}
+}
+
+// startShadow returns true if a mirrored request can be started, i.e. the proxy isn't closed and
+// fewer than MaxMirrored are in flight. endShadow must be called once it's done.
+func (p *Proxy) startShadow() bool {
+	p.Lock()
+	defer p.Unlock()
+	if p.closed {
+		return false
+	}
+
+	select {
+	case p.inflight <- struct{}{}:
+	default:
+		atomic.AddUint64(&p.stats.Skipped, 1)
+		return false
+	}
+	p.shadows.Add(1)
+	return true
+}
+
+// endShadow releases a mirrored request started by startShadow
+func (p *Proxy) endShadow() {
+	<-p.inflight
+	p.shadows.Done()
+}
+
+// recordHeader returns the header of the request as it's recorded
+func (p *Proxy) recordHeader(header map[string]string) map[string]string {
+	if p.opts.RecordCredentials {
+		return header
+	}
+
+	md := make(map[string]string, len(header))
+	for k, v := range header {
+		if !isCredential(k) {
+			md[k] = v
+		}
+	}
+	return md
+}
+
+// isCredential returns true if the header is one of the CredentialHeaders
+func isCredential(header string) bool {
+	for _, h := range CredentialHeaders {
+		if strings.EqualFold(h, header) {
+			return true
+		}
+	}
+	return false
+}
+
+// ServeRequest serves the request with the wrapped proxy then records it and mirrors it to the
+// shadow version of the service
+func (p *Proxy) ServeRequest(ctx context.Context, req server.Request, rsp server.Response) error {
+	mirror := p.mirror()
+	if req.Stream() || (!mirror && p.opts.Recorder == nil) {
+		return p.Proxy.ServeRequest(ctx, req, rsp)
+	}
+
+	r := &request{Request: req}
+	w := &response{Response: rsp}
+	err := p.Proxy.ServeRequest(ctx, r, w)
+
+	rec := &Record{
+		Time:        time.Now().UTC().Format(time.RFC3339Nano),
+		Service:     req.Service(),
+		Endpoint:    req.Endpoint(),
+		ContentType: req.ContentType(),
+		Header:      p.recordHeader(req.Header()),
+		Request:     r.body,
+		Response:    w.body,
+		Error:       errorString(err),
+	}
+
+	if p.opts.Recorder != nil {
+		if rerr := p.opts.Recorder.Record(rec); rerr != nil {
+			logger.Errorf("Error recording request to %v %v: %v", rec.Service, rec.Endpoint, rerr)
+		}
+	}
+
+	// the shadow is called once the response is served so it doesn't add latency. It's called with
+	// the metadata of the request rather than the recorded header so it has the credentials.
+	if mirror && p.startShadow() {
+		md, _ := metadata.FromContext(ctx)
+		go func() {
+			defer p.endShadow()
+			p.shadow(md, rec)
+		}()
+	}
+
+	return err
+}
+
+// call the endpoint of the record and return the encoded response
+func call(ctx context.Context, c client.Client, rec *Record, opts ...client.CallOption) ([]byte, error) {
+	req := c.NewRequest(rec.Service, rec.Endpoint, &bytes.Frame{Data: rec.Request}, client.WithContentType(rec.ContentType))
+	rsp := &bytes.Frame{}
+	if err := c.Call(ctx, req, rsp, opts...); err != nil {
+		return nil, err
+	}
+	return rsp.Data, nil
+}
+
+// shadow calls the shadow version of the service with the request and reports the differences of
+// its response
+func (p *Proxy) shadow(md metadata.Metadata, rec *Record) {
+	ctx, cancel := context.WithTimeout(metadata.NewContext(context.Background(), md), MirrorTimeout)
+	defer cancel()
+
+	filter := selector.WithFilter(selector.FilterVersion(p.opts.Version))
+	actual, err := call(ctx, p.opts.Client, rec, client.WithSelectOption(filter))
+
+	atomic.AddUint64(&p.stats.Mirrored, 1)
+	d := compare(rec, actual, err)
+	if d == nil {
+		atomic.AddUint64(&p.stats.Matched, 1)
+		return
+	}
+	atomic.AddUint64(&p.stats.Differed, 1)
+	d.Version = p.opts.Version
+
+	if p.opts.Diffs == nil {
+		logger.Warnf("Response of %v %v version %v differs: expected %q error %q, got %q error %q",
+			d.Service, d.Endpoint, d.Version, d.Expected, d.ExpectedError, d.Actual, d.ActualError)
+		return
+	}
+
+	b, err := json.Marshal(d)
+	if err != nil {
+		return
+	}
+	p.Lock()
+	p.opts.Diffs.Write(append(b, '\n'))
+	p.Unlock()
+}
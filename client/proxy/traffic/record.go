@@ -0,0 +1,62 @@
+// Package traffic mirrors the requests served by the proxy to a shadow version of the services and
+// records them so they can be replayed against the services later
+package traffic
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// Record is a request to an endpoint and its response
+type Record struct {
+	Time        string            `json:"time"`
+	Service     string            `json:"service"`
+	Endpoint    string            `json:"endpoint"`
+	ContentType string            `json:"content_type"`
+	Header      map[string]string `json:"header,omitempty"`
+	// Request and Response are the encoded bodies, e.g. protobuf or json
+	Request  []byte `json:"request"`
+	Response []byte `json:"response,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Recorder writes the records as lines of json
+type Recorder struct {
+	sync.Mutex
+	w io.Writer
+}
+
+// NewRecorder returns a recorder which writes to w, e.g. a file
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w}
+}
+
+// Record writes the record
+func (r *Recorder) Record(rec *Record) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	r.Lock()
+	defer r.Unlock()
+	_, err = r.w.Write(append(b, '\n'))
+	return err
+}
+
+// ReadRecords reads the records written by a recorder
+func ReadRecords(r io.Reader) ([]*Record, error) {
+	var records []*Record
+
+	d := json.NewDecoder(r)
+	for {
+		rec := &Record{}
+		if err := d.Decode(rec); err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+}
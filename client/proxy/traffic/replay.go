@@ -0,0 +1,73 @@
+package traffic
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/micro/go-micro/v2/metadata"
+)
+
+// ReplayOptions are the options of a replay
+type ReplayOptions struct {
+	// Service replaces the service of the records, e.g. to replay against a copy of the service
+	Service string
+	// Version is the version of the services the records are replayed against, any if it's blank
+	Version string
+	// Timeout of each request
+	Timeout time.Duration
+}
+
+// Result is the result of a replay
+type Result struct {
+	Total   int     `json:"total"`
+	Matched int     `json:"matched"`
+	Diffs   []*Diff `json:"diffs"`
+}
+
+// replayHeader returns the recorded header which is sent as metadata, the micro headers of the
+// transport are skipped since they're set for each request
+func replayHeader(header map[string]string) metadata.Metadata {
+	md := make(metadata.Metadata)
+	for k, v := range header {
+		if strings.HasPrefix(k, "Micro-") || k == "Content-Type" {
+			continue
+		}
+		md[k] = v
+	}
+	return md
+}
+
+// Replay calls the services with the recorded requests in order and compares their responses with
+// the recorded ones
+func Replay(c client.Client, records []*Record, opts ReplayOptions) *Result {
+	var callOpts []client.CallOption
+	if len(opts.Version) > 0 {
+		callOpts = append(callOpts, client.WithSelectOption(selector.WithFilter(selector.FilterVersion(opts.Version))))
+	}
+	if opts.Timeout > 0 {
+		callOpts = append(callOpts, client.WithRequestTimeout(opts.Timeout))
+	}
+
+	res := &Result{}
+	for _, rec := range records {
+		r := *rec
+		if len(opts.Service) > 0 {
+			r.Service = opts.Service
+		}
+
+		ctx := metadata.NewContext(context.Background(), replayHeader(r.Header))
+		actual, err := call(ctx, c, &r, callOpts...)
+
+		res.Total++
+		if d := compare(&r, actual, err); d != nil {
+			d.Version = opts.Version
+			res.Diffs = append(res.Diffs, d)
+			continue
+		}
+		res.Matched++
+	}
+	return res
+}
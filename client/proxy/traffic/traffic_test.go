@@ -0,0 +1,194 @@
+package traffic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	bytesc "github.com/micro/go-micro/v2/codec/bytes"
+	"github.com/micro/go-micro/v2/proxy"
+	"github.com/micro/go-micro/v2/server"
+)
+
+type testRequest struct {
+	server.Request
+	body []byte
+}
+
+func (r *testRequest) Service() string           { return "go.micro.service.users" }
+func (r *testRequest) Endpoint() string          { return "Users.Read" }
+func (r *testRequest) ContentType() string       { return "application/json" }
+func (r *testRequest) Header() map[string]string {
+	return map[string]string{"Micro-Service": "users", "Authorization": "Bearer abc"}
+}
+func (r *testRequest) Stream() bool              { return false }
+func (r *testRequest) Read() ([]byte, error)     { return r.body, nil }
+
+type testResponse struct {
+	server.Response
+	body []byte
+}
+
+func (r *testResponse) Write(b []byte) error {
+	r.body = append(r.body, b...)
+	return nil
+}
+
+// testProxy echoes the request
+type testProxy struct {
+	proxy.Proxy
+}
+
+func (p *testProxy) ServeRequest(ctx context.Context, req server.Request, rsp server.Response) error {
+	b, _ := req.Read()
+	return rsp.Write(b)
+}
+
+// testClient returns the response for calls
+type testClient struct {
+	client.Client
+	rsp   string
+	calls chan string
+}
+
+type testClientRequest struct {
+	client.Request
+	service string
+}
+
+func (c *testClient) NewRequest(service, endpoint string, req interface{}, opts ...client.RequestOption) client.Request {
+	return &testClientRequest{service: service}
+}
+
+func (c *testClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	rsp.(*bytesc.Frame).Data = []byte(c.rsp)
+	c.calls <- req.(*testClientRequest).service
+	return nil
+}
+
+func TestMirrorAndRecord(t *testing.T) {
+	var records, diffs bytes.Buffer
+	c := &testClient{rsp: `{"name": "john", "id": "1"}`, calls: make(chan string, 1)}
+	p := NewProxy(&testProxy{}, Options{
+		Client:   c,
+		Version:  "2.0.0",
+		Percent:  100,
+		Diffs:    &diffs,
+		Recorder: NewRecorder(&records),
+	})
+
+	rsp := &testResponse{}
+	if err := p.ServeRequest(context.TODO(), &testRequest{body: []byte(`{"id":"1","name":"john"}`)}, rsp); err != nil {
+		t.Fatal(err)
+	}
+	if string(rsp.body) != `{"id":"1","name":"john"}` {
+		t.Fatalf("Expected the response of the proxy, got %s", rsp.body)
+	}
+
+	select {
+	case <-c.calls:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the request to be mirrored")
+	}
+	// the json responses are equal despite the order of the fields
+	p.Close()
+	if s := p.Stats(); s.Mirrored != 1 || s.Matched != 1 || diffs.Len() > 0 {
+		t.Fatalf("Expected the mirrored response to match, got %+v %s", s, diffs.String())
+	}
+
+	recs, err := ReadRecords(&records)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 1 || recs[0].Endpoint != "Users.Read" || string(recs[0].Response) != `{"id":"1","name":"john"}` {
+		t.Fatalf("Unexpected records %+v", recs)
+	}
+	if _, ok := recs[0].Header["Authorization"]; ok || recs[0].Header["Micro-Service"] != "users" {
+		t.Errorf("Expected the credentials to be left out of the record, got %v", recs[0].Header)
+	}
+
+	// the recorded request is replayed against another service
+	c.rsp = `{"id": "1", "name": "jane"}`
+	res := Replay(c, recs, ReplayOptions{Service: "go.micro.service.users-v2"})
+	if service := <-c.calls; service != "go.micro.service.users-v2" {
+		t.Errorf("Expected the request to be replayed against the service, got %v", service)
+	}
+	if res.Total != 1 || res.Matched != 0 || len(res.Diffs) != 1 {
+		t.Fatalf("Expected a diff, got %+v", res)
+	}
+	b, _ := json.Marshal(res.Diffs[0])
+	if !bytes.Contains(b, []byte(`"actual":"eyJpZCI6ICIxIiwgIm5hbWUiOiAiamFuZSJ9"`)) {
+		t.Errorf("Expected the actual response in the diff, got %s", b)
+	}
+}
+
+func TestReplayHeader(t *testing.T) {
+	md := replayHeader(map[string]string{"Micro-Service": "users", "Authorization": "Bearer abc"})
+	if len(md) != 1 || md["Authorization"] != "Bearer abc" {
+		t.Errorf("Expected only the authorization header, got %v", md)
+	}
+}
+
+// blockingClient blocks the calls until it's released
+type blockingClient struct {
+	client.Client
+	release chan bool
+	calls   int32
+}
+
+func (c *blockingClient) NewRequest(service, endpoint string, req interface{}, opts ...client.RequestOption) client.Request {
+	return &testClientRequest{service: service}
+}
+
+func (c *blockingClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	atomic.AddInt32(&c.calls, 1)
+	<-c.release
+	rsp.(*bytesc.Frame).Data = []byte(`{"id":"1"}`)
+	return nil
+}
+
+func TestMirrorLimit(t *testing.T) {
+	max := MaxMirrored
+	MaxMirrored = 1
+	defer func() { MaxMirrored = max }()
+
+	c := &blockingClient{release: make(chan bool)}
+	var diffs bytes.Buffer
+	p := NewProxy(&testProxy{}, Options{Client: c, Version: "2.0.0", Percent: 100, Diffs: &diffs})
+
+	// the second request isn't mirrored while the first is in flight
+	for i := 0; i < 2; i++ {
+		if err := p.ServeRequest(context.TODO(), &testRequest{body: []byte(`{"id":"1"}`)}, &testResponse{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s := p.Stats(); s.Skipped != 1 {
+		t.Fatalf("Expected a request to be skipped, got %+v", s)
+	}
+
+	// close waits for the request in flight and nothing is mirrored once it's closed
+	closed := make(chan bool)
+	go func() {
+		p.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+		t.Fatal("Expected close to wait for the mirrored request")
+	case <-time.After(time.Millisecond * 10):
+	}
+	close(c.release)
+	<-closed
+	if s := p.Stats(); s.Mirrored != 1 || s.Matched != 1 {
+		t.Errorf("Expected the mirrored request to complete before close returned, got %+v", s)
+	}
+
+	p.ServeRequest(context.TODO(), &testRequest{body: []byte(`{"id":"1"}`)}, &testResponse{})
+	if calls := atomic.LoadInt32(&c.calls); calls != 1 {
+		t.Errorf("Expected no requests to be mirrored once closed, got %v calls", calls)
+	}
+}
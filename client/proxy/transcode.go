@@ -0,0 +1,50 @@
+package proxy
+
+import (
+	"crypto/tls"
+
+	"github.com/micro/go-micro/v2"
+	aserver "github.com/micro/go-micro/v2/api/server"
+	httpapi "github.com/micro/go-micro/v2/api/server/http"
+	mucli "github.com/micro/go-micro/v2/client"
+	cgrpc "github.com/micro/go-micro/v2/client/grpc"
+	log "github.com/micro/go-micro/v2/logger"
+	apiAuth "github.com/micro/micro/v2/client/api/auth"
+	"github.com/micro/micro/v2/client/proxy/transcode"
+)
+
+// runTranscoder serves http/json at the address of the proxy, the requests are transcoded to the
+// protocol of the nodes of the services. The requests are authorized by the services and endpoints
+// they call, like those of the api.
+func runTranscoder(service micro.Service, config *tls.Config) {
+	clients := map[string]mucli.Client{
+		"grpc": cgrpc.NewClient(),
+		"mucp": mucli.NewClient(),
+	}
+	t := transcode.New(service.Options().Registry, clients)
+
+	aw := apiAuth.Wrapper(&transcode.Resolver{}, Name)
+	opts := []aserver.Option{aserver.WrapHandler(aw)}
+	if config != nil {
+		opts = append(opts, aserver.EnableTLS(true), aserver.TLSConfig(config))
+	}
+
+	srv := httpapi.NewServer(Address)
+	srv.Init(opts...)
+	srv.Handle("/", t)
+
+	log.Infof("Proxy [http] transcoding json to the services at %s", Address)
+
+	if err := srv.Start(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Run internal service
+	if err := service.Run(); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := srv.Stop(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,33 @@
+package transcode
+
+import (
+	"net/http"
+
+	"github.com/micro/go-micro/v2/api/resolver"
+	"github.com/micro/micro/v2/internal/api"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// Resolver resolves the paths of the transcoder to the services and endpoints they call so the
+// requests can be authorized, e.g. /go.micro.service.users/Users/Read. The paths have the full
+// names of the services so the requests are marked as routed and the names aren't prefixed.
+type Resolver struct{}
+
+// Resolve returns the service and endpoint of the path, the list of services at / isn't resolved
+func (r *Resolver) Resolve(req *http.Request, opts ...resolver.ResolveOption) (*resolver.Endpoint, error) {
+	service, endpoint := parsePath(req.URL.Path)
+	if len(service) == 0 {
+		return nil, resolver.ErrNotFound
+	}
+	*req = *req.WithContext(api.WithRouted(req.Context()))
+
+	domain := resolver.NewResolveOptions(opts...).Domain
+	if len(domain) == 0 {
+		domain = namespace.DefaultNamespace
+	}
+	return &resolver.Endpoint{Name: service, Method: endpoint, Domain: domain}, nil
+}
+
+func (r *Resolver) String() string {
+	return "transcode"
+}
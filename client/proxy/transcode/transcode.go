@@ -0,0 +1,302 @@
+// Package transcode serves an http/json front door for the proxy, the requests are transcoded to the
+// protocol of the services, e.g. grpc or mucp, using the descriptions of their endpoints in the
+// registry
+package transcode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/client/selector"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/util/ctx"
+	"github.com/micro/micro/v2/internal/api"
+	"github.com/micro/micro/v2/internal/handler"
+)
+
+// DefaultProtocol is the protocol of the nodes which don't set one in their metadata
+var DefaultProtocol = "mucp"
+
+// Transcoder calls the services with the json requests to /[service]/[Service.Method] or
+// /[service]/[Service]/[Method]. The services are listed at / and their endpoints at /[service].
+type Transcoder struct {
+	registry registry.Registry
+	// clients call the services by the protocol of their nodes
+	clients map[string]client.Client
+}
+
+// New returns a transcoder which calls the services with the client of the protocol of their nodes
+func New(r registry.Registry, clients map[string]client.Client) *Transcoder {
+	return &Transcoder{registry: r, clients: clients}
+}
+
+// parsePath returns the service and endpoint of the path, the endpoint is blank if only the service
+// is in the path
+func parsePath(p string) (string, string) {
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	switch len(parts) {
+	case 1:
+		return parts[0], ""
+	case 2:
+		return parts[0], parts[1]
+	case 3:
+		return parts[0], parts[1] + "." + parts[2]
+	}
+	return "", ""
+}
+
+func (t *Transcoder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/" {
+		t.list(w)
+		return
+	}
+
+	service, endpoint := parsePath(r.URL.Path)
+	if len(service) == 0 {
+		api.WriteError(w, "go.micro.proxy", errors.NotFound("go.micro.proxy", "invalid path %v", r.URL.Path))
+		return
+	}
+
+	services, err := t.registry.GetService(service)
+	if err == registry.ErrNotFound || (err == nil && len(services) == 0) {
+		api.WriteError(w, "go.micro.proxy", errors.NotFound("go.micro.proxy", "service %v not found", service))
+		return
+	} else if err != nil {
+		api.WriteError(w, "go.micro.proxy", errors.InternalServerError("go.micro.proxy", "error getting service %v: %v", service, err))
+		return
+	}
+
+	if len(endpoint) == 0 {
+		api.WriteJSON(w, "go.micro.proxy", services[0])
+		return
+	}
+
+	var ep *registry.Endpoint
+	for _, e := range services[0].Endpoints {
+		if e.Name == endpoint {
+			ep = e
+			break
+		}
+	}
+	if ep == nil {
+		api.WriteError(w, "go.micro.proxy", errors.NotFound("go.micro.proxy", "endpoint %v of service %v not found", endpoint, service))
+		return
+	}
+
+	protocol := nodeProtocol(services)
+	c, ok := t.clients[protocol]
+	if !ok {
+		api.WriteError(w, "go.micro.proxy", errors.InternalServerError("go.micro.proxy", "service %v has unsupported protocol %v", service, protocol))
+		return
+	}
+
+	// streams are served as server-sent events or over websockets
+	if ep.Metadata["stream"] == "true" {
+		if !handler.IsStreamRequest(r) {
+			api.WriteError(w, "go.micro.proxy", errors.BadRequest("go.micro.proxy", "endpoint %v is a stream, expected an event stream or websocket request", endpoint))
+			return
+		}
+		handler.ServeStream(w, r, c, service, endpoint)
+		return
+	}
+
+	body, err := request(r, ep.Request)
+	if err != nil {
+		api.WriteError(w, "go.micro.proxy", errors.BadRequest("go.micro.proxy", "error decoding request: %v", err))
+		return
+	}
+
+	// the nodes of the protocol of the client are selected
+	opts := []client.CallOption{
+		client.WithSelectOption(selector.WithFilter(selector.FilterLabel("protocol", protocol))),
+	}
+
+	var rsp json.RawMessage
+	req := c.NewRequest(service, endpoint, body, client.WithContentType("application/json"))
+	if err := c.Call(ctx.FromRequest(r), req, &rsp, opts...); err != nil {
+		api.WriteError(w, "go.micro.proxy", err)
+		return
+	}
+	if len(rsp) == 0 {
+		rsp = json.RawMessage("{}")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(rsp)))
+	w.Write(rsp)
+}
+
+// list writes the names of the services
+func (t *Transcoder) list(w http.ResponseWriter) {
+	services, err := t.registry.ListServices()
+	if err != nil {
+		api.WriteError(w, "go.micro.proxy", errors.InternalServerError("go.micro.proxy", "error listing services: %v", err))
+		return
+	}
+
+	names := make([]string, 0, len(services))
+	seen := make(map[string]bool)
+	for _, s := range services {
+		if !seen[s.Name] {
+			seen[s.Name] = true
+			names = append(names, s.Name)
+		}
+	}
+	sort.Strings(names)
+	api.WriteJSON(w, "go.micro.proxy", map[string][]string{"services": names})
+}
+
+// nodeProtocol returns the protocol of the nodes of the service, the protocol of the most nodes is
+// used if they differ
+func nodeProtocol(services []*registry.Service) string {
+	counts := make(map[string]int)
+	protocol := DefaultProtocol
+	for _, s := range services {
+		for _, n := range s.Nodes {
+			p := n.Metadata["protocol"]
+			if len(p) == 0 {
+				p = DefaultProtocol
+			}
+			counts[p]++
+			if counts[p] > counts[protocol] {
+				protocol = p
+			}
+		}
+	}
+	return protocol
+}
+
+// request returns the json body of the request merged with its query params, the params are typed
+// by the description of the request in the registry, e.g. ?limit=10 is a number if limit is an
+// int32. Nested fields are set with dots, e.g. ?page.limit=10.
+func request(r *http.Request, desc *registry.Value) (json.RawMessage, error) {
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	b = bytes.TrimSpace(b)
+
+	query := r.URL.Query()
+	if len(query) == 0 {
+		if len(b) == 0 {
+			return json.RawMessage("{}"), nil
+		}
+		if !json.Valid(b) {
+			return nil, fmt.Errorf("invalid json")
+		}
+		return json.RawMessage(b), nil
+	}
+
+	body := make(map[string]interface{})
+	if len(b) > 0 {
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.UseNumber()
+		if err := d.Decode(&body); err != nil {
+			return nil, err
+		}
+	}
+	if err := setQuery(body, query, desc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(body)
+}
+
+// setQuery sets the query params in the body unless it already has the fields
+func setQuery(body map[string]interface{}, query url.Values, desc *registry.Value) error {
+	for k, v := range query {
+		if len(v) == 0 {
+			continue
+		}
+
+		parts := strings.Split(k, ".")
+		obj, field := body, desc
+		for _, p := range parts[:len(parts)-1] {
+			next, ok := obj[p].(map[string]interface{})
+			if !ok {
+				next = make(map[string]interface{})
+				obj[p] = next
+			}
+			obj, field = next, child(field, p)
+		}
+
+		key := parts[len(parts)-1]
+		if _, exists := obj[key]; exists {
+			continue
+		}
+		val, err := convert(v, child(field, key))
+		if err != nil {
+			return fmt.Errorf("invalid value of %v: %v", k, err)
+		}
+		obj[key] = val
+	}
+	return nil
+}
+
+// child returns the description of the field of the value, nil if it isn't described
+func child(v *registry.Value, name string) *registry.Value {
+	if v == nil {
+		return nil
+	}
+	for _, c := range v.Values {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// convert the values of a query param to the type of the field, fields which aren't described are
+// strings or lists of strings if the param is repeated
+func convert(v []string, field *registry.Value) (interface{}, error) {
+	typ := "string"
+	if field != nil {
+		typ = field.Type
+	}
+
+	if strings.HasPrefix(typ, "[]") {
+		l := make([]interface{}, 0, len(v))
+		for _, s := range v {
+			val, err := convertValue(s, strings.TrimPrefix(typ, "[]"))
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, val)
+		}
+		return l, nil
+	}
+
+	if field == nil && len(v) > 1 {
+		return convert(v, &registry.Value{Type: "[]string"})
+	}
+	return convertValue(v[0], typ)
+}
+
+func convertValue(s, typ string) (interface{}, error) {
+	switch typ {
+	case "int", "int32", "int64", "uint", "uint32", "uint64":
+		// 64 bit integers are strings in the json of protobuf but both are accepted
+		if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+			if _, err := strconv.ParseUint(s, 10, 64); err != nil {
+				return nil, err
+			}
+		}
+		return json.Number(s), nil
+	case "float32", "float64":
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return nil, err
+		}
+		return json.Number(s), nil
+	case "bool":
+		return strconv.ParseBool(s)
+	}
+	return s, nil
+}
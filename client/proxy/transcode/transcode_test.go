@@ -0,0 +1,128 @@
+package transcode
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/v2/api/resolver"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/micro/v2/internal/api"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+type testRegistry struct {
+	registry.Registry
+}
+
+func (r *testRegistry) GetService(name string, opts ...registry.GetOption) ([]*registry.Service, error) {
+	if name != "go.micro.service.users" {
+		return nil, registry.ErrNotFound
+	}
+	return []*registry.Service{{
+		Name: name,
+		Endpoints: []*registry.Endpoint{{
+			Name: "Users.List",
+			Request: &registry.Value{Values: []*registry.Value{
+				{Name: "limit", Type: "int32"},
+				{Name: "active", Type: "bool"},
+				{Name: "ids", Type: "[]string"},
+				{Name: "page", Values: []*registry.Value{{Name: "offset", Type: "int64"}}},
+			}},
+		}},
+		Nodes: []*registry.Node{
+			{Id: "1", Metadata: map[string]string{"protocol": "grpc"}},
+			{Id: "2", Metadata: map[string]string{"protocol": "grpc"}},
+			{Id: "3", Metadata: map[string]string{"protocol": "mucp"}},
+		},
+	}}, nil
+}
+
+type testRequest struct {
+	client.Request
+	service, endpoint string
+	body              interface{}
+}
+
+// testClient records the requests it calls
+type testClient struct {
+	client.Client
+	req *testRequest
+}
+
+func (c *testClient) NewRequest(service, endpoint string, req interface{}, opts ...client.RequestOption) client.Request {
+	return &testRequest{service: service, endpoint: endpoint, body: req}
+}
+
+func (c *testClient) Call(ctx context.Context, req client.Request, rsp interface{}, opts ...client.CallOption) error {
+	c.req = req.(*testRequest)
+	return json.Unmarshal([]byte(`{"users":[]}`), rsp)
+}
+
+func TestTranscoder(t *testing.T) {
+	grpc, mucp := &testClient{}, &testClient{}
+	tc := New(&testRegistry{}, map[string]client.Client{"grpc": grpc, "mucp": mucp})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/go.micro.service.users/Users/List?limit=10&active=true&ids=1&ids=2&page.offset=20&sort=name", strings.NewReader(`{"limit": 5}`))
+	tc.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK || w.Body.String() != `{"users":[]}` {
+		t.Fatalf("Unexpected response %v %v", w.Code, w.Body.String())
+	}
+	if grpc.req == nil || mucp.req != nil {
+		t.Fatal("Expected the grpc client to be called since most nodes are grpc")
+	}
+	if grpc.req.service != "go.micro.service.users" || grpc.req.endpoint != "Users.List" {
+		t.Errorf("Unexpected endpoint %v %v", grpc.req.service, grpc.req.endpoint)
+	}
+
+	// the body takes precedence over the query and the params are typed by the description
+	expected := `{"active":true,"ids":["1","2"],"limit":5,"page":{"offset":20},"sort":"name"}`
+	if b := string(grpc.req.body.(json.RawMessage)); b != expected {
+		t.Errorf("Expected request %v, got %v", expected, b)
+	}
+
+	// the params which can't be converted are rejected
+	w = httptest.NewRecorder()
+	tc.ServeHTTP(w, httptest.NewRequest("GET", "/go.micro.service.users/Users.List?limit=ten", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected a bad request, got %v", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	tc.ServeHTTP(w, httptest.NewRequest("POST", "/go.micro.service.users/Users.Delete", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected the endpoint not to be found, got %v", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	tc.ServeHTTP(w, httptest.NewRequest("POST", "/go.micro.service.posts/Posts.List", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected the service not to be found, got %v", w.Code)
+	}
+}
+
+func TestResolver(t *testing.T) {
+	r := &Resolver{}
+	req := httptest.NewRequest("POST", "/go.micro.service.users/Users/Read", nil)
+	ep, err := r.Resolve(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ep.Name != "go.micro.service.users" || ep.Method != "Users.Read" || ep.Domain != namespace.DefaultNamespace {
+		t.Errorf("Unexpected endpoint %+v", ep)
+	}
+	// the names are full service names so they aren't prefixed when authorized
+	if !api.IsRouted(req.Context()) {
+		t.Error("Expected the request to be marked as routed")
+	}
+
+	if _, err := r.Resolve(httptest.NewRequest("GET", "/", nil)); err != resolver.ErrNotFound {
+		t.Errorf("Expected the list of services not to be resolved, got %v", err)
+	}
+}
@@ -0,0 +1,279 @@
+// Package console serves the runtime services, their logs and stats to the operations pages of the
+// web dashboard. The runtime and debug services are called with the context of the request so the
+// account and namespace of the user apply.
+package console
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/errors"
+	pb "github.com/micro/go-micro/v2/runtime/service/proto"
+	"github.com/micro/go-micro/v2/util/ctx"
+	"github.com/micro/micro/v2/internal/api"
+	stats "github.com/micro/micro/v2/service/debug/stats/proto"
+)
+
+var (
+	// LogLines is the number of past lines of the logs sent before they're followed
+	LogLines = 100
+	// MaxLogLines limits the lines which can be requested
+	MaxLogLines = 1000
+)
+
+// Console calls the runtime service to manage the services and the debug service for their stats
+type Console struct {
+	runtime pb.RuntimeService
+	stats   stats.StatsService
+}
+
+// New returns a console which calls the go.micro.runtime and go.micro.debug services
+func New(c client.Client) *Console {
+	return &Console{
+		runtime: pb.NewRuntimeService("go.micro.runtime", c),
+		stats:   stats.NewStatsService("go.micro.debug", c),
+	}
+}
+
+// Service is a service of the runtime
+type Service struct {
+	Name     string            `json:"name"`
+	Version  string            `json:"version"`
+	Source   string            `json:"source"`
+	Status   string            `json:"status"`
+	Error    string            `json:"error,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+func toService(s *pb.Service) *Service {
+	status := s.Metadata["status"]
+	if len(status) == 0 {
+		status = "unknown"
+	}
+	return &Service{
+		Name:     s.Name,
+		Version:  s.Version,
+		Source:   s.Source,
+		Status:   status,
+		Error:    s.Metadata["error"],
+		Metadata: s.Metadata,
+	}
+}
+
+// Services lists the services of the runtime, optionally of a ?type= e.g. service
+func (c *Console) Services(w http.ResponseWriter, r *http.Request) {
+	rsp, err := c.runtime.Read(ctx.FromRequest(r), &pb.ReadRequest{
+		Options: &pb.ReadOptions{Type: r.URL.Query().Get("type")},
+	})
+	if err != nil {
+		api.WriteError(w, "go.micro.web", err)
+		return
+	}
+
+	services := make([]*Service, 0, len(rsp.Services))
+	for _, s := range rsp.Services {
+		services = append(services, toService(s))
+	}
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Name == services[j].Name {
+			return services[i].Version < services[j].Version
+		}
+		return services[i].Name < services[j].Name
+	})
+
+	api.WriteJSON(w, "go.micro.web", map[string]interface{}{"services": services})
+}
+
+// action reads the service of a json post. The json content type is required so the actions can't
+// be posted by forms on other sites with the cookie of the user.
+func action(r *http.Request) (*pb.Service, error) {
+	if r.Method != "POST" {
+		return nil, errors.New("go.micro.web", "method not allowed", http.StatusMethodNotAllowed)
+	}
+	if ct := r.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/json") {
+		return nil, errors.BadRequest("go.micro.web", "expected a json request, got %v", ct)
+	}
+
+	b, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.BadRequest("go.micro.web", "error reading request: %v", err)
+	}
+	var req struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal(b, &req); err != nil {
+		return nil, errors.BadRequest("go.micro.web", "invalid request: %v", err)
+	}
+	if len(req.Name) == 0 {
+		return nil, errors.BadRequest("go.micro.web", "service name required")
+	}
+	return &pb.Service{Name: req.Name, Version: req.Version}, nil
+}
+
+// Restart updates the service of the posted name and version with its current source, which
+// restarts it
+func (c *Console) Restart(w http.ResponseWriter, r *http.Request) {
+	srv, err := action(r)
+	if err != nil {
+		api.WriteError(w, "go.micro.web", err)
+		return
+	}
+	cx := ctx.FromRequest(r)
+
+	rsp, err := c.runtime.Read(cx, &pb.ReadRequest{
+		Options: &pb.ReadOptions{Service: srv.Name, Version: srv.Version},
+	})
+	if err != nil {
+		api.WriteError(w, "go.micro.web", err)
+		return
+	}
+	if len(rsp.Services) == 0 {
+		api.WriteError(w, "go.micro.web", errors.NotFound("go.micro.web", "service %v not found", srv.Name))
+		return
+	}
+
+	if _, err := c.runtime.Update(cx, &pb.UpdateRequest{Service: rsp.Services[0]}); err != nil {
+		api.WriteError(w, "go.micro.web", err)
+		return
+	}
+	api.WriteJSON(w, "go.micro.web", map[string]interface{}{"service": toService(rsp.Services[0])})
+}
+
+// Kill deletes the service of the posted name and version from the runtime
+func (c *Console) Kill(w http.ResponseWriter, r *http.Request) {
+	srv, err := action(r)
+	if err != nil {
+		api.WriteError(w, "go.micro.web", err)
+		return
+	}
+	if _, err := c.runtime.Delete(ctx.FromRequest(r), &pb.DeleteRequest{Service: srv}); err != nil {
+		api.WriteError(w, "go.micro.web", err)
+		return
+	}
+	api.WriteJSON(w, "go.micro.web", map[string]interface{}{})
+}
+
+// Logs sends the last ?lines= of the logs of the ?service= and follows them as server-sent events.
+// The records are sent as message events, errors as error events and the end of the logs as an end
+// event. Requests which don't accept an event stream get the last lines as json.
+func (c *Console) Logs(w http.ResponseWriter, r *http.Request) {
+	service := r.URL.Query().Get("service")
+	if len(service) == 0 {
+		api.WriteError(w, "go.micro.web", errors.BadRequest("go.micro.web", "service required"))
+		return
+	}
+	lines := LogLines
+	if l := r.URL.Query().Get("lines"); len(l) > 0 {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 0 {
+			api.WriteError(w, "go.micro.web", errors.BadRequest("go.micro.web", "invalid lines %v", l))
+			return
+		}
+		lines = n
+	}
+	if lines > MaxLogLines {
+		lines = MaxLogLines
+	}
+
+	follow := strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+	fl, ok := w.(http.Flusher)
+	if follow && !ok {
+		api.WriteError(w, "go.micro.web", errors.InternalServerError("go.micro.web", "streaming unsupported"))
+		return
+	}
+
+	stream, err := c.runtime.Logs(ctx.FromRequest(r), &pb.LogsRequest{
+		Service: service,
+		Count:   int64(lines),
+		Stream:  follow,
+	})
+	if err != nil {
+		api.WriteError(w, "go.micro.web", err)
+		return
+	}
+	defer stream.Close()
+
+	if !follow {
+		records := []*pb.LogRecord{}
+		for {
+			rec, err := stream.Recv()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				api.WriteError(w, "go.micro.web", err)
+				return
+			}
+			records = append(records, rec)
+		}
+		api.WriteJSON(w, "go.micro.web", map[string]interface{}{"records": records})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(event string, data []byte) {
+		if len(event) > 0 {
+			fmt.Fprintf(w, "event: %s\n", event)
+		}
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		fl.Flush()
+	}
+
+	// the followed logs are closed when the client goes away
+	done := make(chan bool)
+	defer close(done)
+	go func() {
+		select {
+		case <-r.Context().Done():
+			stream.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		rec, err := stream.Recv()
+		if err != nil {
+			if r.Context().Err() != nil {
+				return
+			}
+			if err == io.EOF {
+				send("end", []byte("{}"))
+				return
+			}
+			b, _ := json.Marshal(errors.Parse(err.Error()))
+			send("error", b)
+			return
+		}
+		b, err := json.Marshal(rec)
+		if err != nil {
+			continue
+		}
+		send("", b)
+	}
+}
+
+// Stats sends the series of the stats of the nodes of the ?service=, or of every service
+func (c *Console) Stats(w http.ResponseWriter, r *http.Request) {
+	req := &stats.ReadRequest{Past: true}
+	if s := r.URL.Query().Get("service"); len(s) > 0 {
+		req.Service = &stats.Service{Name: s}
+	}
+
+	rsp, err := c.stats.Read(ctx.FromRequest(r), req)
+	if err != nil {
+		api.WriteError(w, "go.micro.web", err)
+		return
+	}
+	api.WriteJSON(w, "go.micro.web", map[string]interface{}{"series": Series(rsp.Stats)})
+}
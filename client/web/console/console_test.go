@@ -0,0 +1,177 @@
+package console
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/metadata"
+	pb "github.com/micro/go-micro/v2/runtime/service/proto"
+	stats "github.com/micro/micro/v2/service/debug/stats/proto"
+)
+
+// testRuntime records the requests made to the runtime
+type testRuntime struct {
+	pb.RuntimeService
+	services []*pb.Service
+	updated  *pb.Service
+	deleted  *pb.Service
+	logs     *pb.LogsRequest
+	ns       string
+}
+
+func (r *testRuntime) Read(ctx context.Context, req *pb.ReadRequest, opts ...client.CallOption) (*pb.ReadResponse, error) {
+	r.ns, _ = metadata.Get(ctx, "Micro-Namespace")
+	var services []*pb.Service
+	for _, s := range r.services {
+		if len(req.Options.Service) == 0 || req.Options.Service == s.Name {
+			services = append(services, s)
+		}
+	}
+	return &pb.ReadResponse{Services: services}, nil
+}
+
+func (r *testRuntime) Update(ctx context.Context, req *pb.UpdateRequest, opts ...client.CallOption) (*pb.UpdateResponse, error) {
+	r.updated = req.Service
+	return &pb.UpdateResponse{}, nil
+}
+
+func (r *testRuntime) Delete(ctx context.Context, req *pb.DeleteRequest, opts ...client.CallOption) (*pb.DeleteResponse, error) {
+	r.deleted = req.Service
+	return &pb.DeleteResponse{}, nil
+}
+
+func (r *testRuntime) Logs(ctx context.Context, req *pb.LogsRequest, opts ...client.CallOption) (pb.Runtime_LogsService, error) {
+	r.logs = req
+	return &testLogs{records: []string{"starting", "listening on :8080"}}, nil
+}
+
+type testLogs struct {
+	pb.Runtime_LogsService
+	records []string
+}
+
+func (l *testLogs) Recv() (*pb.LogRecord, error) {
+	if len(l.records) == 0 {
+		return nil, io.EOF
+	}
+	rec := &pb.LogRecord{Message: l.records[0]}
+	l.records = l.records[1:]
+	return rec, nil
+}
+
+func (l *testLogs) Close() error {
+	return nil
+}
+
+func TestRuntime(t *testing.T) {
+	rt := &testRuntime{services: []*pb.Service{
+		{Name: "users", Version: "latest", Source: "github.com/micro/services/users", Metadata: map[string]string{"status": "running"}},
+		{Name: "posts", Version: "latest", Source: "github.com/micro/services/posts"},
+	}}
+	c := &Console{runtime: rt}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/runtime/services", nil)
+	req.Header.Set("Micro-Namespace", "foo")
+	c.Services(w, req)
+
+	var rsp struct {
+		Services []*Service `json:"services"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &rsp); err != nil {
+		t.Fatalf("Error decoding %v: %v", w.Body.String(), err)
+	}
+	if len(rsp.Services) != 2 || rsp.Services[0].Name != "posts" || rsp.Services[0].Status != "unknown" || rsp.Services[1].Status != "running" {
+		t.Errorf("Unexpected services %+v", rsp.Services)
+	}
+	if rt.ns != "foo" {
+		t.Errorf("Expected the runtime to be called with the namespace of the request, got %v", rt.ns)
+	}
+
+	// the service is restarted with its current source
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/runtime/restart", strings.NewReader(`{"name":"users"}`))
+	req.Header.Set("Content-Type", "application/json")
+	c.Restart(w, req)
+	if w.Code != http.StatusOK || rt.updated == nil || rt.updated.Source != "github.com/micro/services/users" {
+		t.Errorf("Expected the service to be updated, got %v %v", w.Code, rt.updated)
+	}
+
+	// actions which aren't json are rejected
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/runtime/kill", strings.NewReader(`name=users`))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c.Kill(w, req)
+	if w.Code != http.StatusBadRequest || rt.deleted != nil {
+		t.Errorf("Expected a form post to be rejected, got %v", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/runtime/kill", strings.NewReader(`{"name":"posts","version":"latest"}`))
+	req.Header.Set("Content-Type", "application/json")
+	c.Kill(w, req)
+	if w.Code != http.StatusOK || rt.deleted == nil || rt.deleted.Name != "posts" {
+		t.Errorf("Expected the service to be deleted, got %v %v", w.Code, rt.deleted)
+	}
+}
+
+func TestLogs(t *testing.T) {
+	rt := &testRuntime{}
+	c := &Console{runtime: rt}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/runtime/logs?service=users&lines=5000", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	c.Logs(w, req)
+
+	if rt.logs.Count != int64(MaxLogLines) || !rt.logs.Stream {
+		t.Errorf("Expected the logs to be followed up to the max lines, got %+v", rt.logs)
+	}
+	if body := w.Body.String(); !strings.HasPrefix(body, "data: {") || !strings.Contains(body, "starting") || !strings.HasSuffix(body, "event: end\ndata: {}\n\n") {
+		t.Errorf("Unexpected events %q", body)
+	}
+
+	// the last lines are sent as json without following them
+	w = httptest.NewRecorder()
+	c.Logs(w, httptest.NewRequest("GET", "/runtime/logs?service=users", nil))
+	if rt.logs.Stream || rt.logs.Count != int64(LogLines) || !strings.Contains(w.Body.String(), "listening on :8080") {
+		t.Errorf("Unexpected logs %+v %v", rt.logs, w.Body.String())
+	}
+}
+
+func TestSeries(t *testing.T) {
+	snap := func(node string, ts, requests, errors uint64) *stats.Snapshot {
+		return &stats.Snapshot{
+			Service:   &stats.Service{Name: "go.micro.service.users", Node: &stats.Node{Id: node}},
+			Timestamp: ts,
+			Requests:  requests,
+			Errors:    errors,
+		}
+	}
+
+	series := Series([]*stats.Snapshot{
+		snap("2", 100, 10, 0),
+		snap("1", 110, 50, 5),
+		snap("1", 100, 30, 0),
+		snap("1", 110, 50, 5),
+		// the node restarted
+		snap("1", 120, 4, 0),
+	})
+	if len(series) != 2 || series[0].Node != "1" || series[1].Node != "2" {
+		t.Fatalf("Expected a serie per node, got %+v", series)
+	}
+
+	points := series[0].Points
+	if len(points) != 3 {
+		t.Fatalf("Expected the duplicate snapshot to be skipped, got %v points", len(points))
+	}
+	if points[0].Requests != 0 || points[1].Requests != 2 || points[1].Errors != 0.5 || points[2].Requests != 0 {
+		t.Errorf("Unexpected rates %+v %+v %+v", points[0], points[1], points[2])
+	}
+}
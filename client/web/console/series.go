@@ -0,0 +1,84 @@
+package console
+
+import (
+	"sort"
+
+	stats "github.com/micro/micro/v2/service/debug/stats/proto"
+)
+
+// Point is the stats of a node at the time of a snapshot. The requests and errors are the rates per
+// second since the previous snapshot.
+type Point struct {
+	Timestamp uint64  `json:"timestamp"`
+	Memory    uint64  `json:"memory"`
+	Threads   uint64  `json:"threads"`
+	GC        uint64  `json:"gc"`
+	Requests  float64 `json:"requests"`
+	Errors    float64 `json:"errors"`
+}
+
+// Serie is the points of a node of a service in time order
+type Serie struct {
+	Service string   `json:"service"`
+	Version string   `json:"version"`
+	Node    string   `json:"node"`
+	Started int64    `json:"started"`
+	Points  []*Point `json:"points"`
+}
+
+// Series returns the snapshots as a serie per node. The counters of the snapshots are per node so
+// the rates are taken between the snapshots of each node, a node which restarted starts over at 0.
+func Series(snapshots []*stats.Snapshot) []*Serie {
+	nodes := make(map[string][]*stats.Snapshot)
+	for _, s := range snapshots {
+		if s.Service == nil || s.Service.Node == nil {
+			continue
+		}
+		key := s.Service.Name + "/" + s.Service.Node.Id
+		nodes[key] = append(nodes[key], s)
+	}
+
+	series := make([]*Serie, 0, len(nodes))
+	for _, snaps := range nodes {
+		sort.SliceStable(snaps, func(i, j int) bool { return snaps[i].Timestamp < snaps[j].Timestamp })
+
+		last := snaps[len(snaps)-1]
+		serie := &Serie{
+			Service: last.Service.Name,
+			Version: last.Service.Version,
+			Node:    last.Service.Node.Id,
+			Started: last.Started,
+		}
+
+		var prev *stats.Snapshot
+		for _, s := range snaps {
+			// the snapshots services write themselves may be scraped more than once
+			if prev != nil && s.Timestamp == prev.Timestamp {
+				continue
+			}
+
+			p := &Point{
+				Timestamp: s.Timestamp,
+				Memory:    s.Memory,
+				Threads:   s.Threads,
+				GC:        s.Gc,
+			}
+			if prev != nil && s.Requests >= prev.Requests && s.Errors >= prev.Errors {
+				secs := float64(s.Timestamp - prev.Timestamp)
+				p.Requests = float64(s.Requests-prev.Requests) / secs
+				p.Errors = float64(s.Errors-prev.Errors) / secs
+			}
+			serie.Points = append(serie.Points, p)
+			prev = s
+		}
+		series = append(series, serie)
+	}
+
+	sort.Slice(series, func(i, j int) bool {
+		if series[i].Service == series[j].Service {
+			return series[i].Node < series[j].Node
+		}
+		return series[i].Service < series[j].Service
+	})
+	return series
+}
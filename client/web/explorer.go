@@ -0,0 +1,200 @@
+package web
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/store"
+	inauth "github.com/micro/micro/v2/internal/auth"
+)
+
+var (
+	// HistorySize is the number of calls kept in the history of each user
+	HistorySize = 50
+	// FavoritesSize is the max number of favorite requests of each user
+	FavoritesSize = 100
+
+	historyPrefix   = "web/explorer/history/"
+	favoritesPrefix = "web/explorer/favorites/"
+)
+
+// call is a request made from the service explorer, it's kept in the history or favorites of the
+// user who made it
+type call struct {
+	Id       string            `json:"id"`
+	Name     string            `json:"name,omitempty"`
+	Service  string            `json:"service"`
+	Endpoint string            `json:"endpoint"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Request  json.RawMessage   `json:"request,omitempty"`
+	// Latency of the call in milliseconds
+	Latency int64 `json:"latency,omitempty"`
+	Status  int   `json:"status,omitempty"`
+	Created int64 `json:"created"`
+}
+
+// explorer saves the call history and favorite requests of the users of the service explorer in
+// the store, keyed by their account
+type explorer struct {
+	store store.Store
+	auth  auth.Auth
+
+	// serialises the read-modify-write of the lists
+	sync.Mutex
+}
+
+// account returns the account of the token in the cookie of the request
+func account(a auth.Auth, r *http.Request) (*auth.Account, bool) {
+	c, err := r.Cookie(inauth.TokenCookieName)
+	if err != nil || c == nil {
+		return nil, false
+	}
+	token := strings.TrimPrefix(c.Value, inauth.TokenCookieName+"=")
+	acc, err := a.Inspect(token)
+	if err != nil {
+		return nil, false
+	}
+	return acc, true
+}
+
+func (e *explorer) read(key string) ([]*call, error) {
+	recs, err := e.store.Read(key)
+	if err == store.ErrNotFound || (err == nil && len(recs) == 0) {
+		return []*call{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var calls []*call
+	if err := json.Unmarshal(recs[0].Value, &calls); err != nil {
+		return nil, err
+	}
+	return calls, nil
+}
+
+func (e *explorer) write(key string, calls []*call) error {
+	b, err := json.Marshal(calls)
+	if err != nil {
+		return err
+	}
+	return e.store.Write(&store.Record{Key: key, Value: b})
+}
+
+// add saves the call at the top of the list, a call with the same id is replaced and the oldest
+// calls are dropped beyond the size of the list
+func (e *explorer) add(key string, c *call, size int) ([]*call, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	calls, err := e.read(key)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*call{c}
+	for _, old := range calls {
+		if old.Id != c.Id {
+			list = append(list, old)
+		}
+	}
+	if len(list) > size {
+		list = list[:size]
+	}
+	return list, e.write(key, list)
+}
+
+// remove deletes the call of the id from the list, or the whole list if the id is blank
+func (e *explorer) remove(key, id string) ([]*call, error) {
+	e.Lock()
+	defer e.Unlock()
+
+	if len(id) == 0 {
+		if err := e.store.Delete(key); err != nil && err != store.ErrNotFound {
+			return nil, err
+		}
+		return []*call{}, nil
+	}
+
+	calls, err := e.read(key)
+	if err != nil {
+		return nil, err
+	}
+	list := []*call{}
+	for _, c := range calls {
+		if c.Id != id {
+			list = append(list, c)
+		}
+	}
+	return list, e.write(key, list)
+}
+
+// Handler serves the calls of the user at the prefix, e.g. GET /client/history lists them, POST
+// adds the call in the body and DELETE /client/history?id=[id] removes one or all of them. The
+// users who aren't logged in get a 401 and keep their calls in the browser instead.
+func (e *explorer) Handler(prefix string, size int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		acc, ok := account(e.auth, r)
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		key := prefix + acc.ID
+
+		var calls []*call
+		var err error
+
+		switch r.Method {
+		case "GET":
+			calls, err = e.read(key)
+		case "POST":
+			b, rerr := ioutil.ReadAll(r.Body)
+			if rerr != nil {
+				http.Error(w, "Error reading request: "+rerr.Error(), http.StatusBadRequest)
+				return
+			}
+			c := new(call)
+			if err := json.Unmarshal(b, c); err != nil {
+				http.Error(w, "Invalid call: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			if len(c.Service) == 0 || len(c.Endpoint) == 0 {
+				http.Error(w, "Invalid call: service and endpoint are required", http.StatusBadRequest)
+				return
+			}
+			if len(c.Request) > 0 && !json.Valid(c.Request) {
+				http.Error(w, "Invalid call: request is not json", http.StatusBadRequest)
+				return
+			}
+			if len(c.Id) == 0 {
+				c.Id = uuid.New().String()
+			}
+			if c.Created == 0 {
+				c.Created = time.Now().Unix()
+			}
+			calls, err = e.add(key, c, size)
+		case "DELETE":
+			calls, err = e.remove(key, r.URL.Query().Get("id"))
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, "Error occurred:"+err.Error(), 500)
+			return
+		}
+
+		b, err := json.Marshal(map[string]interface{}{"calls": calls})
+		if err != nil {
+			http.Error(w, "Error occurred:"+err.Error(), 500)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	}
+}
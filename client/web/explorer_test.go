@@ -0,0 +1,86 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/store/memory"
+	inauth "github.com/micro/micro/v2/internal/auth"
+)
+
+// testAuth returns the account named by the token
+type testAuth struct {
+	auth.Auth
+}
+
+func (a *testAuth) Inspect(token string) (*auth.Account, error) {
+	if len(token) == 0 {
+		return nil, auth.ErrInvalidToken
+	}
+	return &auth.Account{ID: token}, nil
+}
+
+func serveCalls(t *testing.T, h http.Handler, method, url, user, body string) (int, []*call) {
+	req := httptest.NewRequest(method, url, strings.NewReader(body))
+	if len(user) > 0 {
+		req.AddCookie(&http.Cookie{Name: inauth.TokenCookieName, Value: user})
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		return w.Code, nil
+	}
+
+	var rsp struct {
+		Calls []*call `json:"calls"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &rsp); err != nil {
+		t.Fatalf("Error decoding %v: %v", w.Body.String(), err)
+	}
+	return w.Code, rsp.Calls
+}
+
+func TestExplorerHistory(t *testing.T) {
+	ex := &explorer{store: memory.NewStore(), auth: &testAuth{}}
+	h := ex.Handler(historyPrefix, 2)
+
+	if code, _ := serveCalls(t, h, "GET", "/client/history", "", ""); code != http.StatusUnauthorized {
+		t.Fatalf("Expected the calls of anonymous users to be unauthorized, got %v", code)
+	}
+
+	for _, ep := range []string{"Users.Create", "Users.Read", "Users.List"} {
+		body := `{"service":"go.micro.service.users","endpoint":"` + ep + `","request":{"id":"1"},"latency":12}`
+		if code, _ := serveCalls(t, h, "POST", "/client/history", "john", body); code != http.StatusOK {
+			t.Fatalf("Unexpected status %v", code)
+		}
+	}
+
+	// the latest calls are kept up to the size of the history
+	_, calls := serveCalls(t, h, "GET", "/client/history", "john", "")
+	if len(calls) != 2 || calls[0].Endpoint != "Users.List" || calls[1].Endpoint != "Users.Read" {
+		t.Fatalf("Unexpected history %+v", calls)
+	}
+	if len(calls[0].Id) == 0 || calls[0].Created == 0 || calls[0].Latency != 12 || string(calls[0].Request) != `{"id":"1"}` {
+		t.Errorf("Unexpected call %+v", calls[0])
+	}
+
+	// the history is per user
+	if _, calls := serveCalls(t, h, "GET", "/client/history", "jane", ""); len(calls) != 0 {
+		t.Errorf("Expected no history for another user, got %+v", calls)
+	}
+
+	if _, left := serveCalls(t, h, "DELETE", "/client/history?id="+calls[0].Id, "john", ""); len(left) != 1 || left[0].Endpoint != "Users.Read" {
+		t.Errorf("Expected the call to be removed, got %+v", left)
+	}
+	if _, left := serveCalls(t, h, "DELETE", "/client/history", "john", ""); len(left) != 0 {
+		t.Errorf("Expected the history to be cleared, got %+v", left)
+	}
+
+	if code, _ := serveCalls(t, h, "POST", "/client/history", "john", `{"service":"go.micro.service.users"}`); code != http.StatusBadRequest {
+		t.Errorf("Expected a call without an endpoint to be rejected, got %v", code)
+	}
+}
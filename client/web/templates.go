@@ -68,6 +68,8 @@ var (
 		  {{if gt (len .User) 0 }}<span class="user small">Logged in as: {{.User}}</span>{{end}}
 	          <li><a href="/client">Client</a></li>
 	          <li><a href="/services">Services</a></li>
+	          <li><a href="/runtime">Runtime</a></li>
+	          <li><a href="/debug/stats">Debug</a></li>
 	          {{if .StatsURL}}<li><a href="{{.StatsURL}}" class="navbar-link">Stats</a></li>{{end}}
 	          {{if .LoginURL}}<li><a href="{{.LoginURL}}" class="navbar-link">{{.LoginTitle}}</a></li>{{end}}
 	        </ul>
@@ -221,6 +223,30 @@ jQuery(function($, undefined) {
 	.form-control {
 		border: 1px solid whitesmoke;
 	}
+	fieldset {
+		border-left: 2px solid whitesmoke;
+		padding-left: 10px;
+		margin-bottom: 10px;
+	}
+	legend {
+		font-size: 1em;
+		border: 0;
+		margin: 0;
+	}
+	.nav-tabs > li > a {
+		padding: 5px 10px;
+	}
+	.calls {
+		max-height: 300px;
+		overflow: scroll;
+	}
+	.calls .list-group-item {
+		cursor: pointer;
+		border-color: whitesmoke;
+	}
+	.calls .remove {
+		margin-left: 10px;
+	}
 {{end}}
 {{define "content"}}
 <div class="row">
@@ -233,7 +259,7 @@ jQuery(function($, undefined) {
 				<ul class="list-group">
 					<select class="form-control" type=text name=service id=service> 
 					<option disabled selected> -- select a service -- </option>
-					{{range $key, $value := .Results}}
+					{{range $key, $value := .Results.Services}}
 					<option class = "list-group-item" value="{{$key}}">{{$key}}</option>
 					{{end}}
 					</select>
@@ -253,24 +279,41 @@ jQuery(function($, undefined) {
 					<input class="form-control" type=text name=otherendpoint id=otherendpoint placeholder="Endpoint"/>
 				</ul>
 			</div>
-			<div class="form-group">
-			</div>
 			<div class="form-group">
 				<label for="metadata">Metadata</label>
 				<ul class="list-group">
 					<input class="form-control" type=text name=metadata id=metadata placeholder="Metadata" value="{}"/>
 				</ul>
-				<label for="request">Request</label>
-				<textarea class="form-control" name=request id=request rows=8>{}</textarea>
+				<label>Request</label>
+				<ul class="nav nav-tabs" style="margin-bottom: 10px;">
+					<li class="active"><a href="#" data-tab="fields">Form</a></li>
+					<li><a href="#" data-tab="json">JSON</a></li>
+				</ul>
+				<div id="fields" class="tab"><p class="small">Select an endpoint to fill in its request</p></div>
+				<div id="json" class="tab" style="display: none;">
+					<textarea class="form-control" name=request id=request rows=8>{}</textarea>
+				</div>
 			</div>
 			<div class="form-group">
-				<button class="btn btn-default" style="border-color: whitesmoke;">Call</button>
+				<button class="btn btn-default" style="border-color: whitesmoke;" id="call">Call</button>
+				<button type="button" class="btn btn-default" style="border-color: whitesmoke; display: none;" id="stop" onclick="stop()">Stop</button>
+				<button type="button" class="btn btn-default" style="border-color: whitesmoke;" onclick="favorite()">Favorite</button>
+				<span class="pull-right small" style="padding-top: 8px;">
+					Copy as <a href="#" onclick="return copyText(microCall())">micro call</a>
+					| <a href="#" onclick="return copyText(curl())">curl</a>
+				</span>
 			</div>
 		</form>
 	</div>
 	<div class="col-sm-7">
-		<p><b>Response</b><span class="pull-right"><a href="#" onclick="copyResponse()">Copy</a></p>
+		<p><b>Response</b> <span id="status" class="small"></span><span class="pull-right"><a href="#" onclick="return copyResponse()">Copy</a></span></p>
 		<pre id="response" style="min-height: 405px; max-height: 405px; overflow: scroll;">{}</pre>
+		<ul class="nav nav-tabs">
+			<li class="active"><a href="#" data-calls="history">History</a></li>
+			<li><a href="#" data-calls="favorites">Favorites</a></li>
+			<li class="pull-right"><a href="#" onclick="return clearCalls()" class="small">Clear</a></li>
+		</ul>
+		<ul class="list-group calls" id="calls"></ul>
 	</div>
     </div>
   </div>
@@ -278,10 +321,16 @@ jQuery(function($, undefined) {
 {{end}}
 {{define "script"}}
 	<script>
-		function copyResponse() {
-			var copyText = document.getElementById("response");
+		// the endpoints of the services and the descriptions of their requests
+		var services = {{.Results.Services}} || {};
+		var selected = {service: {{.Results.Service}}, endpoint: {{.Results.Endpoint}}};
+		var sizes = {history: {{.Results.HistorySize}}, favorites: {{.Results.FavoritesSize}}};
+		var events;
+		var calls = "history";
+
+		function copyText(text) {
 			const textArea = document.createElement('textarea');
-			textArea.textContent = copyText.innerText;
+			textArea.textContent = text;
 			textArea.style = "position: absolute; left: -1000px; top: -1000px";	
 			document.body.append(textArea);
 			textArea.select();
@@ -290,105 +339,512 @@ jQuery(function($, undefined) {
 			document.body.removeChild(textArea);
 			return false;
 		}
-	</script>
-	<script>
-		$(document).ready(function(){
-			//Function executes on change of first select option field 
-			$("#service").change(function(){
-				var select = $("#service option:selected").val();
-				$("#endpoint").empty();
-				$("#endpoint").append("<option disabled selected> -- select an endpoint -- </option>");
-				var s_map = {};
-				{{ range $service, $endpoints := .Results }}
-				var m_list = [];
-				{{range $index, $element := $endpoints}}
-				m_list[{{$index}}] = {{$element.Name}}
-				{{end}}
-				s_map[{{$service}}] = m_list
-				{{ end }}
-				if (select in s_map) {
-					var serviceEndpoints = s_map[select]
-					var len = serviceEndpoints.length;
-					for(var i = 0; i < len; i++) {
-						$("#endpoint").append("<option value=\""+serviceEndpoints[i]+"\">"+serviceEndpoints[i]+"</option>");	
+
+		function copyResponse() {
+			return copyText(document.getElementById("response").innerText);
+		}
+
+		function snake(name) {
+			return name.replace(/([a-z0-9])([A-Z])/g, '$1_$2').toLowerCase();
+		}
+
+		function findEndpoint(service, name) {
+			var eps = services[service] || [];
+			for (var i = 0; i < eps.length; i++) {
+				if (eps[i].name == name) {
+					return eps[i];
+				}
+			}
+			return null;
+		}
+
+		function isStream(ep) {
+			return ep != null && ep.metadata != null && ep.metadata["stream"] == "true";
+		}
+
+		// fields returns the inputs of the values of a request, messages are nested in fieldsets
+		function fields(values, prefix) {
+			var el = $("<div>");
+			(values || []).forEach(function(v) {
+				var name = snake(v.name);
+				var path = prefix.length > 0 ? prefix + "." + name : name;
+				var repeated = v.type.indexOf("[]") == 0;
+				var complex = v.type.indexOf("map[") == 0 || (v.values && v.values.length > 0);
+
+				if (complex && !repeated && v.type.indexOf("map[") != 0) {
+					var fs = $("<fieldset>").append($("<legend>").text(name + " (" + v.type + ")"));
+					fs.append(fields(v.values, path));
+					el.append(fs);
+					return;
+				}
+
+				var group = $("<div class='form-group'>").append($("<label class='small'>").text(name + " (" + v.type + ")"));
+				var input;
+				var typ = v.type.replace(/^\[\]/, "");
+				if (complex) {
+					input = $("<textarea class='form-control' rows=3>").attr("placeholder", "JSON");
+					typ = "json";
+				} else if (typ == "bool" && !repeated) {
+					input = $("<select class='form-control'><option value=''></option><option>true</option><option>false</option></select>");
+				} else {
+					input = $("<input class='form-control'>").attr("type", !repeated && /^(u?int|float)/.test(typ) ? "number" : "text");
+					if (repeated) {
+						input.attr("placeholder", "Comma separated");
 					}
 				}
-				$("#endpoint").append("<option value=\"other\"> - Other</option>");
+				input.attr("data-path", path).attr("data-type", typ).attr("data-repeated", repeated);
+				el.append(group.append(input));
 			});
+			return el;
+		}
 
-			//Function executes on change of second select option field 
-			$("#endpoint").change(function(){
-				var select = $("#endpoint option:selected").val();
-				if (select == "other") {
-					$(".other").css('display', 'block');
-					$("#otherendpoint").attr("disabled", false);
-				} else {
-					$(".other").css('display', 'none');
-					$("#otherendpoint").attr("disabled", true);
-					$('#otherendpoint').val('');
+		function convert(val, typ) {
+			if (typ == "json") {
+				return JSON.parse(val);
+			}
+			if (/^(u?int|float)/.test(typ)) {
+				var n = Number(val);
+				if (isNaN(n)) {
+					throw new Error(val + " is not a number");
 				}
+				return n;
+			}
+			if (typ == "bool") {
+				return val == "true";
+			}
+			return val;
+		}
 
-			});
-		});
-	</script>
-	<script>
-		function call() {
-			var req = new XMLHttpRequest()
-			req.onreadystatechange = function() {
-				if(req.readyState != 4) {
-					return
+		function setPath(obj, path, val) {
+			var parts = path.split(".");
+			for (var i = 0; i < parts.length - 1; i++) {
+				if (typeof obj[parts[i]] != "object" || obj[parts[i]] == null) {
+					obj[parts[i]] = {};
+				}
+				obj = obj[parts[i]];
+			}
+			obj[parts[parts.length - 1]] = val;
+		}
+
+		function getPath(obj, path) {
+			var parts = path.split(".");
+			for (var i = 0; i < parts.length; i++) {
+				if (obj == null || typeof obj != "object") {
+					return undefined;
 				}
-				if (req.readyState == 4 && req.status == 200) {
-					document.getElementById("response").innerText = JSON.stringify(JSON.parse(req.responseText), null, 2);
-				} else if (req.responseText.slice(0, 1) == "{") {
-					document.getElementById("response").innerText = JSON.stringify(JSON.parse(req.responseText), null, 2);
-				} else if (req.responseText.length > 0) {
-					document.getElementById("response").innerText = req.responseText;
+				obj = obj[parts[i]];
+			}
+			return obj;
+		}
+
+		// formToRequest writes the request of the inputs which are set
+		function formToRequest() {
+			var req = {};
+			try {
+				$("#fields [data-path]").each(function() {
+					var val = $.trim($(this).val());
+					if (val.length == 0) {
+						return;
+					}
+					var typ = $(this).attr("data-type");
+					if ($(this).attr("data-repeated") == "true" && typ != "json") {
+						val = val.split(",").map(function(s) { return convert($.trim(s), typ); });
+					} else {
+						val = convert(val, typ);
+					}
+					setPath(req, $(this).attr("data-path"), val);
+				});
+			} catch(e) {
+				$("#status").text("Invalid input: " + e.message);
+				return;
+			}
+			$("#status").text("");
+			$("#request").val(JSON.stringify(req, null, 2));
+		}
+
+		// requestToForm sets the inputs from the request
+		function requestToForm(req) {
+			$("#fields [data-path]").each(function() {
+				var val = getPath(req, $(this).attr("data-path"));
+				if (val === undefined || val === null) {
+					$(this).val("");
+				} else if ($(this).attr("data-type") == "json") {
+					$(this).val(JSON.stringify(val));
+				} else if (Array.isArray(val)) {
+					$(this).val(val.join(","));
 				} else {
-					document.getElementById("response").innerText = "Request error " + req.status;
+					$(this).val(String(val));
 				}
-				console.log(req.responseText);
+			});
+		}
+
+		function setEndpoints(service) {
+			$("#endpoint").empty();
+			$("#endpoint").append("<option disabled selected> -- select an endpoint -- </option>");
+			(services[service] || []).forEach(function(ep) {
+				$("#endpoint").append($("<option>").attr("value", ep.name).text(ep.name + (isStream(ep) ? " (stream)" : "")));
+			});
+			$("#endpoint").append("<option value=\"other\"> - Other</option>");
+		}
+
+		function setEndpoint(name) {
+			if (name == "other") {
+				$(".other").css('display', 'block');
+				$("#otherendpoint").attr("disabled", false);
+			} else {
+				$(".other").css('display', 'none');
+				$("#otherendpoint").attr("disabled", true);
+				$('#otherendpoint').val('');
+			}
+
+			var ep = findEndpoint($("#service").val(), name);
+			$("#fields").empty();
+			if (ep == null || ep.request == null || !ep.request.values || ep.request.values.length == 0) {
+				$("#fields").append("<p class='small'>The request of the endpoint isn't described, use JSON instead</p>");
+			} else {
+				$("#fields").append(fields(ep.request.values, ""));
+			}
+			$("#request").val("{}");
+		}
+
+		// load fills in the form with a call from the history or favorites
+		function load(c) {
+			$("#service").val(c.service);
+			setEndpoints(c.service);
+			if (findEndpoint(c.service, c.endpoint) != null) {
+				$("#endpoint").val(c.endpoint);
+				setEndpoint(c.endpoint);
+			} else {
+				$("#endpoint").val("other");
+				setEndpoint("other");
+				$("#otherendpoint").val(c.endpoint);
 			}
-			var endpoint = document.forms[0].elements["endpoint"].value
+			$("#metadata").val(JSON.stringify(c.metadata || {}));
+			var req = c.request || {};
+			$("#request").val(JSON.stringify(req, null, 2));
+			requestToForm(req);
+		}
+
+		// current returns the call in the form
+		function current() {
+			var endpoint = $("#endpoint").val();
 			if (!($('#otherendpoint').prop('disabled'))) {
-				endpoint = document.forms[0].elements["otherendpoint"].value
+				endpoint = $("#otherendpoint").val();
+			}
+			var md = $.trim($("#metadata").val());
+			var rq = $.trim($("#request").val());
+			return {
+				service: $("#service").val() || "",
+				endpoint: endpoint || "",
+				metadata: md.length > 0 ? JSON.parse(md) : {},
+				request: rq.length > 0 ? JSON.parse(rq) : {}
+			};
+		}
+
+		function quote(s) {
+			return "'" + s.replace(/'/g, "'\\''") + "'";
+		}
+
+		function microCall() {
+			try {
+				var c = current();
+			} catch(e) {
+				return "";
+			}
+			var cmd = ["micro", "call"];
+			for (var key in c.metadata) {
+				cmd.push("--metadata", quote(key + "=" + c.metadata[key]));
+			}
+			cmd.push(c.service, c.endpoint, quote(JSON.stringify(c.request)));
+			return cmd.join(" ");
+		}
+
+		function curl() {
+			try {
+				var c = current();
+			} catch(e) {
+				return "";
+			}
+			if (isStream(findEndpoint(c.service, c.endpoint))) {
+				var url = window.location.origin + "/rpc/stream?service=" + encodeURIComponent(c.service) +
+					"&endpoint=" + encodeURIComponent(c.endpoint) + "&request=" + encodeURIComponent(JSON.stringify(c.request));
+				return "curl -N -H 'Accept: text/event-stream' " + quote(url);
+			}
+			var cmd = ["curl", "-X", "POST", "-H", quote("Content-Type: application/json")];
+			for (var key in c.metadata) {
+				cmd.push("-H", quote(key + ": " + c.metadata[key]));
+			}
+			cmd.push("-d", quote(JSON.stringify({service: c.service, endpoint: c.endpoint, request: c.request})));
+			cmd.push(window.location.origin + "/rpc");
+			return cmd.join(" ");
+		}
+
+		// the calls are kept by the dashboard for users who are logged in, otherwise in the browser
+		function localCalls(kind) {
+			try {
+				return JSON.parse(window.localStorage.getItem("micro.explorer." + kind)) || [];
+			} catch(e) {
+				return [];
+			}
+		}
+
+		function saveLocal(kind, list, cb) {
+			window.localStorage.setItem("micro.explorer." + kind, JSON.stringify(list));
+			cb(list);
+		}
+
+		function callsRequest(method, kind, query, body, cb, local) {
+			$.ajax({
+				url: "/client/" + kind + query,
+				method: method,
+				contentType: "application/json",
+				data: body ? JSON.stringify(body) : undefined,
+				dataType: "json"
+			}).done(function(rsp) {
+				cb(rsp.calls || []);
+			}).fail(function(xhr) {
+				if (xhr.status == 401) {
+					local();
+				}
+			});
+		}
+
+		function listCalls(kind, cb) {
+			callsRequest("GET", kind, "", null, cb, function() { cb(localCalls(kind)); });
+		}
+
+		function addCall(kind, c, cb) {
+			callsRequest("POST", kind, "", c, cb, function() {
+				c.id = c.id || String(Date.now());
+				c.created = c.created || Math.floor(Date.now() / 1000);
+				var list = [c].concat(localCalls(kind).filter(function(o) { return o.id != c.id; }));
+				saveLocal(kind, list.slice(0, sizes[kind]), cb);
+			});
+		}
+
+		function removeCall(kind, id, cb) {
+			callsRequest("DELETE", kind, id ? "?id=" + encodeURIComponent(id) : "", null, cb, function() {
+				saveLocal(kind, id ? localCalls(kind).filter(function(o) { return o.id != id; }) : [], cb);
+			});
+		}
+
+		function showCalls(list) {
+			$("#calls").empty();
+			list.forEach(function(c) {
+				var item = $("<li class='list-group-item small'>");
+				item.append($("<b>").text(c.name || c.endpoint)).append(" ").append($("<span>").text(c.service));
+				var info = [];
+				if (c.status) {
+					info.push(c.status);
+				}
+				if (c.latency) {
+					info.push(c.latency + "ms");
+				}
+				if (c.created) {
+					info.push(new Date(c.created * 1000).toLocaleString());
+				}
+				var right = $("<span class='pull-right text-muted'>").text(info.join(" · "));
+				var remove = $("<a href='#' class='remove'>&times;</a>").on("click", function(e) {
+					e.stopPropagation();
+					removeCall(calls, c.id, showCalls);
+					return false;
+				});
+				item.append(right.append(remove));
+				item.on("click", function() { load(c); });
+				$("#calls").append(item);
+			});
+			if (list.length == 0) {
+				$("#calls").append($("<li class='list-group-item small text-muted'>").text("No " + calls + " yet"));
 			}
+		}
 
-			var reqBody;
-			var headers;
+		function clearCalls() {
+			removeCall(calls, "", showCalls);
+			return false;
+		}
 
+		function favorite() {
 			try {
-				var md = document.forms[0].elements["metadata"].value;
-				var rq = document.forms[0].elements["request"].value
-				if (md.length > 0) {
-					headers = JSON.parse(md);
+				var c = current();
+			} catch(e) {
+				$("#status").text("Invalid request: " + e.message);
+				return;
+			}
+			if (c.service.length == 0 || c.endpoint.length == 0) {
+				$("#status").text("Select a service and endpoint to favorite");
+				return;
+			}
+			var name = window.prompt("Name of the request", c.endpoint);
+			if (name == null) {
+				return;
+			}
+			c.name = name;
+			addCall("favorites", c, function(list) {
+				if (calls == "favorites") {
+					showCalls(list);
+				}
+			});
+		}
+
+		function saveHistory(c, status, latency) {
+			c.status = status;
+			c.latency = Math.round(latency);
+			addCall("history", c, function(list) {
+				if (calls == "history") {
+					showCalls(list);
 				}
-				if (rq.length > 0) {
-					reqBody = JSON.parse(rq);
-				};
+			});
+		}
+
+		function showResponse(text) {
+			if (text.slice(0, 1) == "{" || text.slice(0, 1) == "[") {
+				try {
+					text = JSON.stringify(JSON.parse(text), null, 2);
+				} catch(e) {}
+			}
+			document.getElementById("response").innerText = text;
+		}
+
+		function stop() {
+			if (events) {
+				events.close();
+				events = null;
+			}
+			$("#stop").hide();
+			$("#call").attr("disabled", false);
+		}
+
+		// stream shows the responses of a streaming endpoint as they're received, the latency is
+		// the time to the first response
+		function stream(c) {
+			var url = "/rpc/stream?service=" + encodeURIComponent(c.service) + "&endpoint=" +
+				encodeURIComponent(c.endpoint) + "&request=" + encodeURIComponent(JSON.stringify(c.request));
+			var start = performance.now();
+			var latency = 0;
+			var count = 0;
+			var out = [];
+
+			stop();
+			events = new EventSource(url);
+			$("#stop").show();
+			$("#call").attr("disabled", true);
+			$("#status").text("streaming");
+			document.getElementById("response").innerText = "";
+
+			events.onmessage = function(e) {
+				if (count == 0) {
+					latency = performance.now() - start;
+				}
+				count++;
+				try {
+					out.push(JSON.stringify(JSON.parse(e.data), null, 2));
+				} catch(err) {
+					out.push(e.data);
+				}
+				document.getElementById("response").innerText = out.join("\n");
+				$("#status").text("streaming · " + count + " messages · first after " + Math.round(latency) + "ms");
+			};
+			events.addEventListener("end", function() {
+				stop();
+				$("#status").text("200 · " + count + " messages · first after " + Math.round(latency) + "ms");
+				saveHistory(c, 200, latency);
+			});
+			events.addEventListener("error", function(e) {
+				// errors of the stream have data, otherwise the connection failed
+				var status = 500;
+				if (e.data) {
+					out.push(e.data);
+					showResponse(out.length == 1 ? e.data : out.join("\n"));
+					try {
+						status = JSON.parse(e.data).code || 500;
+					} catch(err) {}
+				}
+				stop();
+				$("#status").text(status + " · " + Math.round(performance.now() - start) + "ms");
+				saveHistory(c, status, performance.now() - start);
+			});
+		}
+
+		function call() {
+			try {
+				var c = current();
 			} catch(e) {
 				document.getElementById("response").innerText = "Invalid request: " + e.message;
 				return false;
 			}
 
-			var request = {
-				"service": document.forms[0].elements["service"].value,
-				"endpoint": endpoint,
-				"request": reqBody
+			if (isStream(findEndpoint(c.service, c.endpoint))) {
+				stream(c);
+				return false;
+			}
+
+			var req = new XMLHttpRequest()
+			var start;
+			req.onreadystatechange = function() {
+				if(req.readyState != 4) {
+					return
+				}
+				var latency = performance.now() - start;
+				$("#status").text(req.status + " · " + Math.round(latency) + "ms");
+				if (req.responseText.length > 0) {
+					showResponse(req.responseText);
+				} else {
+					document.getElementById("response").innerText = "Request error " + req.status;
+				}
+				saveHistory(c, req.status, latency);
 			}
+
 			req.open("POST", "/rpc", true);
 			req.setRequestHeader("Content-type","application/json");
-
-			if (headers != undefined) {
-				for (let [key, value] of Object.entries(headers)) {
-					req.setRequestHeader(key, value);
-				}
+			for (let [key, value] of Object.entries(c.metadata)) {
+				req.setRequestHeader(key, value);
 			}
 
-			req.send(JSON.stringify(request));
+			$("#status").text("calling");
+			start = performance.now();
+			req.send(JSON.stringify({service: c.service, endpoint: c.endpoint, request: c.request}));
 
 			return false;
-		};	
+		};
+
+		$(document).ready(function(){
+			$("#service").change(function(){
+				setEndpoints($("#service option:selected").val());
+			});
+			$("#endpoint").change(function(){
+				setEndpoint($("#endpoint option:selected").val());
+			});
+
+			// the form and the json of the request are kept in sync
+			$("#fields").on("change keyup", "[data-path]", formToRequest);
+			$("#request").on("change keyup", function() {
+				try {
+					requestToForm(JSON.parse($("#request").val()));
+				} catch(e) {}
+			});
+			$("a[data-tab]").on("click", function() {
+				$(this).parent().addClass("active").siblings().removeClass("active");
+				$(".tab").hide();
+				$("#" + $(this).data("tab")).show();
+				return false;
+			});
+			$("a[data-calls]").on("click", function() {
+				$(this).parent().addClass("active").siblings().removeClass("active");
+				calls = $(this).data("calls");
+				listCalls(calls, showCalls);
+				return false;
+			});
+
+			if (selected.service.length > 0 && selected.service in services) {
+				$("#service").val(selected.service);
+				setEndpoints(selected.service);
+				if (selected.endpoint.length > 0 && findEndpoint(selected.service, selected.endpoint) != null) {
+					$("#endpoint").val(selected.endpoint);
+					setEndpoint(selected.endpoint);
+				}
+			}
+			listCalls(calls, showCalls);
+		});
 	</script>
 {{end}}
 `
@@ -439,7 +895,7 @@ pre {padding: 20px;}
 {{define "script"}}
 <script type="text/javascript">
   $('.endpoint').on('click', function() {
-	var val = $(this).parent().find("table");
+	var val = $(this).closest("div").find("table");
 	var state = $(this).find(".state");
 	if (val.css('display') == 'none') {
 	  state.text("[-]");
@@ -480,7 +936,7 @@ pre {padding: 20px;}
 	{{end}}
 	{{range $svc.Endpoints}}
 	<div>
-		<h4 class="endpoint"><span class="state">[+]</span> {{.Name}}</h4>
+		<h4><span class="endpoint"><span class="state">[+]</span> {{.Name}}</span> <a href="/client?service={{$svc.Name}}&endpoint={{.Name}}" class="small">Call</a></h4>
 		<table class="table" style="display: none;">
 			<tbody>
 				{{if .Metadata}}
@@ -504,6 +960,437 @@ pre {padding: 20px;}
 	{{end}}
 {{end}}
 
+`
+
+	runtimeTemplate = `
+{{define "heading"}}<h4><input class="form-control input-lg search" type=text placeholder="Search" autofocus></h4>{{end}}
+{{define "title"}}Runtime{{end}}
+{{define "style"}}
+.table>tbody>tr>td { vertical-align: middle; }
+.status-running { color: #3c763d; }
+.status-error { color: #a94442; }
+.actions a { margin-left: 10px; }
+{{end}}
+{{define "content"}}
+	<p class="small text-muted" id="status"></p>
+	<table class="table">
+		<thead>
+			<th>Name</th>
+			<th>Version</th>
+			<th>Source</th>
+			<th>Status</th>
+			<th>Updated</th>
+			<th></th>
+		</thead>
+		<tbody id="services"></tbody>
+	</table>
+{{end}}
+{{define "script"}}
+<script type="text/javascript">
+	// the services are refreshed every few seconds to keep their status live
+	var interval = 5000;
+
+	function since(v) {
+		var t = Date.parse(v);
+		if (isNaN(t)) {
+			return v || "n/a";
+		}
+		var secs = Math.round((Date.now() - t) / 1000);
+		if (secs < 60) {
+			return secs + "s ago";
+		} else if (secs < 3600) {
+			return Math.round(secs / 60) + "m ago";
+		} else if (secs < 86400) {
+			return Math.round(secs / 3600) + "h ago";
+		}
+		return Math.round(secs / 86400) + "d ago";
+	}
+
+	function action(name, service) {
+		if (name == "kill" && !window.confirm("Kill " + service.name + " " + service.version + "?")) {
+			return false;
+		}
+		$("#status").text(name + " " + service.name + "...");
+		$.ajax({
+			url: "/runtime/" + name,
+			method: "POST",
+			contentType: "application/json",
+			data: JSON.stringify({name: service.name, version: service.version}),
+			dataType: "json"
+		}).done(function() {
+			$("#status").text(name + " " + service.name + " done");
+			refresh();
+		}).fail(function(xhr) {
+			var err = xhr.responseText;
+			try {
+				err = JSON.parse(xhr.responseText).detail;
+			} catch(e) {}
+			$("#status").text(name + " " + service.name + " failed: " + err);
+		});
+		return false;
+	}
+
+	function filter() {
+		var val = $.trim($(".search").val());
+		$("#services tr").each(function() {
+			$(this).toggle($(this).data("filter").search(val) >= 0);
+		});
+	}
+
+	function refresh() {
+		$.ajax({url: "/runtime/services", dataType: "json"}).done(function(rsp) {
+			$("#services").empty();
+			(rsp.services || []).forEach(function(s) {
+				var status = $("<td>").addClass("status-" + s.status).text(s.status);
+				if (s.error) {
+					status.attr("title", s.error).text(s.status + ": " + s.error);
+				}
+				var q = "?service=" + encodeURIComponent(s.name);
+				var actions = $("<td class='actions text-right'>")
+					.append($("<a>").attr("href", "/runtime/logs" + q).text("Logs"))
+					.append($("<a>").attr("href", "/debug/stats" + q).text("Stats"))
+					.append($("<a href='#'>").text("Restart").on("click", function() { return action("restart", s); }))
+					.append($("<a href='#' class='text-danger'>").text("Kill").on("click", function() { return action("kill", s); }));
+				var row = $("<tr>").attr("data-filter", s.name)
+					.append($("<td>").text(s.name))
+					.append($("<td>").text(s.version))
+					.append($("<td class='small'>").text(s.source))
+					.append(status)
+					.append($("<td>").text(since((s.metadata || {})["updated"])))
+					.append(actions);
+				$("#services").append(row);
+			});
+			if ((rsp.services || []).length == 0) {
+				$("#services").append("<tr data-filter=''><td colspan=6 class='text-muted'>No services are running</td></tr>");
+			}
+			filter();
+		}).fail(function(xhr) {
+			$("#status").text("Error reading the runtime: " + xhr.responseText);
+		});
+	}
+
+	jQuery(function($, undefined) {
+		$('.search').on('keyup', filter);
+		refresh();
+		setInterval(refresh, interval);
+	});
+</script>
+{{end}}
+`
+
+	logsTemplate = `
+{{define "title"}}Logs{{end}}
+{{define "heading"}}<h3>Logs</h3>{{end}}
+{{define "style"}}
+#logs {
+	height: calc(100vh - 260px);
+	overflow: scroll;
+	white-space: pre-wrap;
+	font-size: 0.85em;
+}
+{{end}}
+{{define "content"}}
+	<form class="form-inline" id="logs-form" onsubmit="return tail();">
+		<div class="form-group">
+			<input class="form-control" type=text id=service placeholder="Service" value="{{.Results.Service}}" list="services"/>
+			<datalist id="services"></datalist>
+		</div>
+		<div class="form-group">
+			<input class="form-control" type=number id=lines min=0 value="{{.Results.Lines}}" style="width: 100px;" title="Lines"/>
+		</div>
+		<div class="checkbox">
+			<label><input type=checkbox id=follow checked> Follow</label>
+		</div>
+		<button class="btn btn-default" style="border-color: whitesmoke;">Tail</button>
+		<button type="button" class="btn btn-default" style="border-color: whitesmoke;" onclick="stop()">Stop</button>
+		<button type="button" class="btn btn-default" style="border-color: whitesmoke;" onclick="$('#logs').empty()">Clear</button>
+		<span class="small text-muted" id="status"></span>
+	</form>
+	<p></p>
+	<pre id="logs"></pre>
+{{end}}
+{{define "script"}}
+<script type="text/javascript">
+	var events;
+
+	function stop() {
+		if (events) {
+			events.close();
+			events = null;
+		}
+		$("#status").text("");
+	}
+
+	function append(text) {
+		var el = document.getElementById("logs");
+		// keep following the end of the logs unless the user scrolled up
+		var bottom = el.scrollHeight - el.scrollTop - el.clientHeight < 20;
+		el.appendChild(document.createTextNode(text + "\n"));
+		if (bottom) {
+			el.scrollTop = el.scrollHeight;
+		}
+	}
+
+	function tail() {
+		var service = $.trim($("#service").val());
+		if (service.length == 0) {
+			return false;
+		}
+		stop();
+		$("#logs").empty();
+		window.history.replaceState(null, "", "/runtime/logs?service=" + encodeURIComponent(service));
+
+		var url = "/runtime/logs/stream?service=" + encodeURIComponent(service) + "&lines=" + encodeURIComponent($("#lines").val());
+		if (!$("#follow").is(":checked")) {
+			$.ajax({url: url, dataType: "json"}).done(function(rsp) {
+				(rsp.records || []).forEach(function(r) { append(r.message); });
+			}).fail(function(xhr) {
+				$("#status").text(xhr.responseText);
+			});
+			return false;
+		}
+
+		events = new EventSource(url);
+		$("#status").text("following");
+		events.onmessage = function(e) {
+			try {
+				append(JSON.parse(e.data).message);
+			} catch(err) {
+				append(e.data);
+			}
+		};
+		events.addEventListener("end", function() {
+			stop();
+			$("#status").text("end of logs");
+		});
+		events.addEventListener("error", function(e) {
+			stop();
+			var err = "the logs can't be read";
+			if (e.data) {
+				try {
+					err = JSON.parse(e.data).detail;
+				} catch(e) {}
+			}
+			$("#status").text(err);
+		});
+		return false;
+	}
+
+	jQuery(function($, undefined) {
+		$.ajax({url: "/runtime/services", dataType: "json"}).done(function(rsp) {
+			(rsp.services || []).forEach(function(s) {
+				$("#services").append($("<option>").attr("value", s.name));
+			});
+		});
+		if ($.trim($("#service").val()).length > 0) {
+			tail();
+		}
+	});
+</script>
+{{end}}
+`
+
+	debugTemplate = `
+{{define "title"}}Debug{{end}}
+{{define "heading"}}<h3>Stats</h3>{{end}}
+{{define "style"}}
+.chart { margin-bottom: 30px; }
+.chart .legend { font-size: 12px; text-align: center; }
+.chart .legend span { margin-right: 10px; white-space: nowrap; }
+{{end}}
+{{define "content"}}
+	<form class="form-inline" onsubmit="return refresh();">
+		<div class="form-group">
+			<select class="form-control" id="service">
+				<option value="">All services</option>
+			</select>
+		</div>
+		<span class="small text-muted" id="status"></span>
+	</form>
+	<p></p>
+	<div class="row">
+		<div class="col-sm-6 chart"><canvas id="requests"></canvas><div class="legend" id="requests-legend"></div></div>
+		<div class="col-sm-6 chart"><canvas id="errors"></canvas><div class="legend" id="errors-legend"></div></div>
+		<div class="col-sm-6 chart"><canvas id="memory"></canvas><div class="legend" id="memory-legend"></div></div>
+		<div class="col-sm-6 chart"><canvas id="threads"></canvas><div class="legend" id="threads-legend"></div></div>
+	</div>
+	<table class="table small" id="nodes">
+		<thead>
+			<th>Service</th>
+			<th>Version</th>
+			<th>Node</th>
+			<th>Started</th>
+			<th>Requests/s</th>
+			<th>Errors/s</th>
+			<th>Memory</th>
+			<th>Goroutines</th>
+		</thead>
+		<tbody></tbody>
+	</table>
+{{end}}
+{{define "script"}}
+<script type="text/javascript">
+	// the snapshots are scraped by the debug service, refresh at the same pace
+	var interval = 10000;
+	var selected = {{.Results.Service}};
+	var charts = {};
+	var colors = ["#23527c", "#5cb85c", "#f0ad4e", "#d9534f", "#5bc0de", "#777777", "#9b59b6", "#1abc9c"];
+
+	// the charts are drawn on canvases by plot so no scripts are loaded from other sites
+	function chart(id, title) {
+		charts[id] = {id: id, canvas: document.getElementById(id), title: title};
+	}
+
+	// plot draws the lines of the datasets from zero to the max value, the gaps in a line are spanned
+	function plot(c, labels, datasets) {
+		var canvas = c.canvas;
+		canvas.width = canvas.parentNode.clientWidth - 30;
+		canvas.height = 260;
+
+		var ctx = canvas.getContext("2d");
+		var x0 = 50, y0 = canvas.height - 20, x1 = canvas.width - 10, y1 = 25;
+		ctx.clearRect(0, 0, canvas.width, canvas.height);
+		ctx.font = "12px sans-serif";
+		ctx.fillStyle = "#333";
+		ctx.textAlign = "center";
+		ctx.fillText(c.title, canvas.width / 2, 15);
+
+		var max = 0;
+		datasets.forEach(function(d) {
+			d.data.forEach(function(v) { if (v !== null && v > max) max = v; });
+		});
+		if (max == 0) {
+			max = 1;
+		}
+
+		ctx.strokeStyle = "#ccc";
+		ctx.beginPath();
+		ctx.moveTo(x0, y1);
+		ctx.lineTo(x0, y0);
+		ctx.lineTo(x1, y0);
+		ctx.stroke();
+		ctx.textAlign = "right";
+		ctx.fillText(String(round(max)), x0 - 5, y1 + 4);
+		ctx.fillText("0", x0 - 5, y0 + 4);
+		if (labels.length > 0) {
+			ctx.fillText(labels[labels.length - 1], x1, canvas.height - 5);
+			ctx.textAlign = "left";
+			ctx.fillText(labels[0], x0, canvas.height - 5);
+		}
+
+		var step = labels.length > 1 ? (x1 - x0) / (labels.length - 1) : 0;
+		datasets.forEach(function(d) {
+			var started = false;
+			ctx.strokeStyle = d.color;
+			ctx.beginPath();
+			d.data.forEach(function(v, i) {
+				if (v === null) {
+					return;
+				}
+				var x = x0 + i * step, y = y0 - v / max * (y0 - y1);
+				if (started) {
+					ctx.lineTo(x, y);
+				} else {
+					ctx.moveTo(x, y);
+					started = true;
+				}
+			});
+			ctx.stroke();
+		});
+
+		var legend = $("#" + c.id + "-legend").empty();
+		datasets.forEach(function(d) {
+			legend.append($("<span>").css("color", d.color).text("\u25A0 " + d.label));
+		});
+	}
+
+	function label(s) {
+		return (selected.length > 0 ? "" : s.service + " ") + s.node;
+	}
+
+	// draw sets the values of the series at the timestamps of the snapshots
+	function draw(id, series, timestamps, value) {
+		var labels = timestamps.map(function(t) { return new Date(t * 1000).toLocaleTimeString(); });
+		var datasets = series.map(function(s, i) {
+			var points = {};
+			s.points.forEach(function(p) { points[p.timestamp] = value(p); });
+			return {
+				label: label(s),
+				color: colors[i % colors.length],
+				data: timestamps.map(function(t) { return t in points ? points[t] : null; })
+			};
+		});
+		plot(charts[id], labels, datasets);
+	}
+
+	function round(v) {
+		return Math.round(v * 100) / 100;
+	}
+
+	function refresh() {
+		selected = $("#service").val() || "";
+		var url = "/debug/stats/series" + (selected.length > 0 ? "?service=" + encodeURIComponent(selected) : "");
+		window.history.replaceState(null, "", "/debug/stats" + (selected.length > 0 ? "?service=" + encodeURIComponent(selected) : ""));
+
+		$.ajax({url: url, dataType: "json"}).done(function(rsp) {
+			var series = rsp.series || [];
+			$("#status").text(series.length == 0 ? "No stats have been scraped yet" : "");
+
+			// keep the list of services when one is selected
+			if (selected.length == 0) {
+				$("#service option:not(:first)").remove();
+				var seen = {};
+				series.forEach(function(s) {
+					if (!seen[s.service]) {
+						seen[s.service] = true;
+						$("#service").append($("<option>").attr("value", s.service).text(s.service));
+					}
+				});
+			}
+
+			var ts = {};
+			series.forEach(function(s) { s.points.forEach(function(p) { ts[p.timestamp] = true; }); });
+			var timestamps = Object.keys(ts).map(Number).sort(function(a, b) { return a - b; });
+
+			draw("requests", series, timestamps, function(p) { return round(p.requests); });
+			draw("errors", series, timestamps, function(p) { return round(p.errors); });
+			draw("memory", series, timestamps, function(p) { return round(p.memory / 1024 / 1024); });
+			draw("threads", series, timestamps, function(p) { return p.threads; });
+
+			$("#nodes tbody").empty();
+			series.forEach(function(s) {
+				var p = s.points[s.points.length - 1];
+				$("#nodes tbody").append($("<tr>")
+					.append($("<td>").text(s.service))
+					.append($("<td>").text(s.version))
+					.append($("<td>").text(s.node))
+					.append($("<td>").text(s.started ? new Date(s.started * 1000).toLocaleString() : "n/a"))
+					.append($("<td>").text(round(p.requests)))
+					.append($("<td>").text(round(p.errors)))
+					.append($("<td>").text(round(p.memory / 1024 / 1024) + " MB"))
+					.append($("<td>").text(p.threads)));
+			});
+		}).fail(function(xhr) {
+			$("#status").text("Error reading the stats: " + xhr.responseText);
+		});
+		return false;
+	}
+
+	jQuery(function($, undefined) {
+		chart("requests", "Requests/s");
+		chart("errors", "Errors/s");
+		chart("memory", "Memory (MB)");
+		chart("threads", "Goroutines");
+
+		if (selected.length > 0) {
+			$("#service").append($("<option>").attr("value", selected).text(selected)).val(selected);
+		}
+		$("#service").on("change", refresh);
+		refresh();
+		setInterval(refresh, interval);
+	});
+</script>
+{{end}}
 `
 
 	notFoundTemplate = `
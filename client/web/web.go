@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"io"
 	"net/http"
 	"net/http/httputil"
 	"os"
@@ -31,7 +32,8 @@ import (
 	"github.com/micro/go-micro/v2/registry"
 	"github.com/micro/go-micro/v2/sync/memory"
 	apiAuth "github.com/micro/micro/v2/client/api/auth"
-	inauth "github.com/micro/micro/v2/internal/auth"
+	"github.com/micro/micro/v2/client/web/console"
+	"github.com/micro/micro/v2/internal/accesslog"
 	"github.com/micro/micro/v2/internal/handler"
 	"github.com/micro/micro/v2/internal/helper"
 	"github.com/micro/micro/v2/internal/resolver/web"
@@ -195,6 +197,23 @@ func (s *srv) notFoundHandler(w http.ResponseWriter, r *http.Request) {
 	s.render(w, r, notFoundTemplate, nil)
 }
 
+// consolePaths are the paths of the operations console which shadow the web services of the same
+// name, e.g. go.micro.web.runtime
+var consolePaths = []string{"runtime", "debug"}
+
+// shadowWarned are the web services which were logged as shadowed by the console
+var shadowWarned sync.Map
+
+// isConsolePath returns true if the name of a web service is a path of the console
+func isConsolePath(name string) bool {
+	for _, p := range consolePaths {
+		if p == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *srv) indexHandler(w http.ResponseWriter, r *http.Request) {
 	cors.SetHeaders(w, r)
 
@@ -229,6 +248,14 @@ func (s *srv) indexHandler(w http.ResponseWriter, r *http.Request) {
 
 		name := strings.TrimPrefix(srv.Name, prefix)
 
+		// the paths of the console take precedence over the web services of the same name
+		if isConsolePath(name) {
+			if _, warned := shadowWarned.LoadOrStore(srv.Name, true); !warned {
+				log.Warnf("Web service %v isn't served, its path /%v is used by the console", srv.Name, name)
+			}
+			continue
+		}
+
 		// in the case of 3 letter things e.g m3o convert to M3O
 		if len(name) <= 3 && strings.ContainsAny(name, "012345789") {
 			name = strings.ToUpper(name)
@@ -272,7 +299,7 @@ func (s *srv) registryHandler(w http.ResponseWriter, r *http.Request) {
 
 		if r.Header.Get("Content-Type") == "application/json" {
 			b, err := json.Marshal(map[string]interface{}{
-				"services": s,
+				"services": sv,
 			})
 			if err != nil {
 				http.Error(w, "Error occurred:"+err.Error(), 500)
@@ -354,7 +381,31 @@ func (s *srv) callHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	s.render(w, r, callTemplate, serviceMap)
+	// the service and endpoint to call are preselected, e.g. from the service page
+	s.render(w, r, callTemplate, map[string]interface{}{
+		"Services":      serviceMap,
+		"Service":       r.URL.Query().Get("service"),
+		"Endpoint":      r.URL.Query().Get("endpoint"),
+		"HistorySize":   HistorySize,
+		"FavoritesSize": FavoritesSize,
+	})
+}
+
+func (s *srv) runtimeHandler(w http.ResponseWriter, r *http.Request) {
+	s.render(w, r, runtimeTemplate, nil)
+}
+
+func (s *srv) logsHandler(w http.ResponseWriter, r *http.Request) {
+	s.render(w, r, logsTemplate, map[string]interface{}{
+		"Service": r.URL.Query().Get("service"),
+		"Lines":   console.LogLines,
+	})
+}
+
+func (s *srv) debugHandler(w http.ResponseWriter, r *http.Request) {
+	s.render(w, r, debugTemplate, map[string]interface{}{
+		"Service": r.URL.Query().Get("service"),
+	})
 }
 
 func (s *srv) render(w http.ResponseWriter, r *http.Request, tmpl string, data interface{}) {
@@ -382,12 +433,9 @@ func (s *srv) render(w http.ResponseWriter, r *http.Request, tmpl string, data i
 	loginTitle := "Login"
 	user := ""
 
-	if c, err := r.Cookie(inauth.TokenCookieName); err == nil && c != nil {
-		token := strings.TrimPrefix(c.Value, inauth.TokenCookieName+"=")
-		if acc, err := s.auth.Inspect(token); err == nil {
-			loginTitle = "Account"
-			user = acc.ID
-		}
+	if acc, ok := account(s.auth, r); ok {
+		loginTitle = "Account"
+		user = acc.ID
 	}
 
 	if err := t.ExecuteTemplate(w, "layout", map[string]interface{}{
@@ -470,13 +518,35 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 	// create the proxy
 	p := s.proxy()
 
+	// the service explorer keeps the calls of the users in the store
+	ex := &explorer{
+		store: service.Options().Store,
+		auth:  service.Options().Auth,
+	}
+
+	// the operations console calls the runtime and debug services as the user
+	con := console.New(service.Client())
+
 	// the web handler itself
 	s.HandleFunc("/favicon.ico", faviconHandler)
 	s.HandleFunc("/404", s.notFoundHandler)
 	s.HandleFunc("/client", s.callHandler)
+	s.HandleFunc("/client/history", ex.Handler(historyPrefix, HistorySize))
+	s.HandleFunc("/client/favorites", ex.Handler(favoritesPrefix, FavoritesSize))
 	s.HandleFunc("/services", s.registryHandler)
 	s.HandleFunc("/service/{name}", s.registryHandler)
 	s.HandleFunc("/rpc", handler.RPC)
+	s.HandleFunc("/rpc/stream", handler.Stream)
+	s.HandleFunc("/rpc/stream/ticket", handler.Ticket)
+	// the console's paths are matched before the web services, see consolePaths
+	s.HandleFunc("/runtime", s.runtimeHandler)
+	s.HandleFunc("/runtime/services", con.Services)
+	s.HandleFunc("/runtime/restart", con.Restart)
+	s.HandleFunc("/runtime/kill", con.Kill)
+	s.HandleFunc("/runtime/logs", s.logsHandler)
+	s.HandleFunc("/runtime/logs/stream", con.Logs)
+	s.HandleFunc("/debug/stats", s.debugHandler)
+	s.HandleFunc("/debug/stats/series", con.Stats)
 	s.PathPrefix("/{service:[a-zA-Z0-9]+}").Handler(p)
 	s.HandleFunc("/", s.indexHandler)
 
@@ -551,9 +621,16 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 		h = plugins[i-1].Handler()(h)
 	}
 
-	// create the service and add the auth wrapper
+	// create the access logger, the requests are tagged with a request id whether they're logged
+	var accessLog io.Writer
+	if ctx.Bool("enable_access_log") {
+		accessLog = os.Stdout
+	}
+	accessLogger := accesslog.New(accessLog, ctx.Float64("access_log_sample"))
+
+	// create the service and add the auth wrapper, it's wrapped by the access logger
 	aw := apiAuth.Wrapper(s.resolver, Namespace+"."+Type)
-	srv := httpapi.NewServer(Address, server.WrapHandler(aw))
+	srv := httpapi.NewServer(Address, server.WrapHandler(aw), server.WrapHandler(accessLogger.Wrapper()))
 
 	srv.Init(opts...)
 	srv.Handle("/", h)
@@ -172,6 +172,17 @@ func setup(app *ccli.App) {
 			Usage:   "Enable stats",
 			EnvVars: []string{"MICRO_ENABLE_STATS"},
 		},
+		&ccli.BoolFlag{
+			Name:    "enable_access_log",
+			Usage:   "Log the requests to the api and web as json to stdout",
+			EnvVars: []string{"MICRO_ENABLE_ACCESS_LOG"},
+		},
+		&ccli.Float64Flag{
+			Name:    "access_log_sample",
+			Usage:   "Set the fraction of requests which are logged, failed requests are always logged",
+			EnvVars: []string{"MICRO_ACCESS_LOG_SAMPLE"},
+			Value:   1,
+		},
 		&ccli.BoolFlag{
 			Name:    "auto_update",
 			Usage:   "Enable automatic updates",
@@ -0,0 +1,174 @@
+// Package accesslog logs the requests to the api and web gateways as json and tags them with a
+// request id which is forwarded to the services
+package accesslog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/api/server"
+)
+
+// RequestIDHeader is the header of the request id, it's passed to the services in the metadata
+// and returned in the responses
+var RequestIDHeader = "X-Request-Id"
+
+// Entry is the access log of a request
+type Entry struct {
+	Time      string  `json:"time"`
+	RequestID string  `json:"request_id"`
+	TraceID   string  `json:"trace_id,omitempty"`
+	Method    string  `json:"method"`
+	Path      string  `json:"path"`
+	Remote    string  `json:"remote"`
+	Service   string  `json:"service,omitempty"`
+	Endpoint  string  `json:"endpoint,omitempty"`
+	Account   string  `json:"account,omitempty"`
+	Namespace string  `json:"namespace,omitempty"`
+	Status    int     `json:"status"`
+	Bytes     int64   `json:"bytes"`
+	Latency   float64 `json:"latency_ms"`
+}
+
+type entryKey struct{}
+
+// FromContext returns the entry of the request so it can be annotated by the wrapped handlers, e.g.
+// with the resolved service and account. It's nil if the request isn't logged.
+func FromContext(ctx context.Context) *Entry {
+	e, _ := ctx.Value(entryKey{}).(*Entry)
+	return e
+}
+
+// Logger writes the access logs
+type Logger struct {
+	sync.Mutex
+	out    io.Writer
+	sample float64
+}
+
+// New returns a logger which writes the entries to out. The fraction of the requests in sample are
+// logged, the requests which fail are always logged. Only the request ids are set if out is nil.
+func New(out io.Writer, sample float64) *Logger {
+	return &Logger{out: out, sample: sample}
+}
+
+// writer records the status and size of the response
+type writer struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *writer) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *writer) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack the connection, e.g. to upgrade it to a websocket
+func (w *writer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("connection can't be hijacked")
+	}
+	if w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return h.Hijack()
+}
+
+// traceID returns the id of the trace the request is part of, either from the micro trace header or
+// a w3c traceparent
+func traceID(req *http.Request) string {
+	if id := req.Header.Get("Micro-Trace-Id"); len(id) > 0 {
+		return id
+	}
+	// traceparent is version-trace_id-parent_id-flags
+	if parts := strings.Split(req.Header.Get("traceparent"), "-"); len(parts) == 4 {
+		return parts[1]
+	}
+	return ""
+}
+
+// log writes the entry unless it isn't sampled
+func (l *Logger) log(e *Entry) {
+	if e.Status < 500 && l.sample < 1 && rand.Float64() >= l.sample {
+		return
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+
+	l.Lock()
+	l.out.Write(append(b, '\n'))
+	l.Unlock()
+}
+
+// Wrapper sets the request id of the requests and logs them. It's expected to be the outermost
+// wrapper so the requests rejected by the others are logged.
+func (l *Logger) Wrapper() server.Wrapper {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			// the request id is propagated if the client or a proxy in front of the gateway set it
+			id := req.Header.Get(RequestIDHeader)
+			if len(id) == 0 {
+				id = uuid.New().String()
+				req.Header.Set(RequestIDHeader, id)
+			}
+			w.Header().Set(RequestIDHeader, id)
+
+			if l.out == nil {
+				h.ServeHTTP(w, req)
+				return
+			}
+
+			start := time.Now()
+			e := &Entry{
+				Time:      start.UTC().Format(time.RFC3339Nano),
+				RequestID: id,
+				TraceID:   traceID(req),
+				Method:    req.Method,
+				Path:      req.URL.Path,
+				Remote:    req.RemoteAddr,
+			}
+			rw := &writer{ResponseWriter: w}
+			h.ServeHTTP(rw, req.WithContext(context.WithValue(req.Context(), entryKey{}, e)))
+
+			e.Status = rw.status
+			if e.Status == 0 {
+				e.Status = http.StatusOK
+			}
+			e.Bytes = rw.bytes
+			e.Latency = float64(time.Since(start)) / float64(time.Millisecond)
+			l.log(e)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package accesslog
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWrapper(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, 1)
+
+	var requestID string
+	h := l.Wrapper()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = r.Header.Get(RequestIDHeader)
+		if e := FromContext(r.Context()); e != nil {
+			e.Service = "go.micro.api.users"
+			e.Account = "john"
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/users/create", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	h.ServeHTTP(w, req)
+
+	if len(requestID) == 0 || w.Header().Get(RequestIDHeader) != requestID {
+		t.Fatalf("Expected the request id to be set and returned, got %v and %v", requestID, w.Header().Get(RequestIDHeader))
+	}
+
+	var e Entry
+	if err := json.Unmarshal(buf.Bytes(), &e); err != nil {
+		t.Fatalf("Error decoding the log %v: %v", buf.String(), err)
+	}
+	if e.RequestID != requestID || e.Status != http.StatusCreated || e.Bytes != 10 || e.Method != "POST" {
+		t.Errorf("Unexpected entry %+v", e)
+	}
+	if e.Service != "go.micro.api.users" || e.Account != "john" || e.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected the annotated entry, got %+v", e)
+	}
+
+	// the request id of the client is propagated
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/users/read", nil)
+	req.Header.Set(RequestIDHeader, "abc")
+	h.ServeHTTP(w, req)
+	if requestID != "abc" || w.Header().Get(RequestIDHeader) != "abc" {
+		t.Errorf("Expected the request id to be propagated, got %v", requestID)
+	}
+}
+
+func TestSample(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(&buf, 0)
+
+	status := http.StatusOK
+	h := l.Wrapper()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if buf.Len() > 0 {
+		t.Fatalf("Expected the request not to be sampled, got %v", buf.String())
+	}
+
+	// failed requests are always logged
+	status = http.StatusInternalServerError
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if lines := strings.Count(buf.String(), "\n"); lines != 1 {
+		t.Errorf("Expected the failed request to be logged, got %v lines", lines)
+	}
+}
@@ -0,0 +1,36 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/micro/go-micro/v2/errors"
+)
+
+// WriteError writes the error as json with its code as the status. Errors which aren't micro errors
+// are written as internal server errors with the id, e.g. go.micro.api.
+func WriteError(w http.ResponseWriter, id string, err error) {
+	ce := errors.Parse(err.Error())
+	if ce.Code == 0 {
+		ce.Code = 500
+		ce.Id = id
+		ce.Status = http.StatusText(500)
+		ce.Detail = "error during request: " + ce.Detail
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(int(ce.Code))
+	w.Write([]byte(ce.Error()))
+}
+
+// WriteJSON writes the value as json, an error with the id is written if it can't be encoded
+func WriteJSON(w http.ResponseWriter, id string, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		WriteError(w, id, errors.InternalServerError(id, "error encoding response: %v", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(len(b)))
+	w.Write(b)
+}
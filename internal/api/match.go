@@ -0,0 +1,21 @@
+package api
+
+import "strings"
+
+// Match returns true if the pattern matches the value. A blank pattern or * matches any value and a
+// pattern ending in * matches the values with its prefix, e.g. Users.*
+func Match(pattern, value string) bool {
+	if len(pattern) == 0 || pattern == "*" {
+		return true
+	}
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(value, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == value
+}
+
+// MatchService returns true if the pattern matches the full name of the service or its name without
+// the prefix of the gateway, e.g. go.micro.api.greeter or greeter
+func MatchService(pattern, prefix, service string) bool {
+	return Match(pattern, service) || Match(pattern, strings.TrimPrefix(service, prefix+"."))
+}
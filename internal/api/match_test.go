@@ -0,0 +1,25 @@
+package api
+
+import "testing"
+
+func TestMatchService(t *testing.T) {
+	tests := []struct {
+		pattern string
+		service string
+		expect  bool
+	}{
+		{"", "go.micro.api.greeter", true},
+		{"*", "go.micro.api.greeter", true},
+		{"greeter", "go.micro.api.greeter", true},
+		{"go.micro.api.greeter", "go.micro.api.greeter", true},
+		{"go.micro.api.*", "go.micro.api.greeter", true},
+		{"greet*", "go.micro.api.greeter", true},
+		{"users", "go.micro.api.greeter", false},
+		{"greeter", "go.micro.service.greeter", false},
+	}
+	for _, tc := range tests {
+		if ok := MatchService(tc.pattern, "go.micro.api", tc.service); ok != tc.expect {
+			t.Errorf("Expected %v matching %v to be %v", tc.pattern, tc.service, tc.expect)
+		}
+	}
+}
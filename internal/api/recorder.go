@@ -0,0 +1,50 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+)
+
+// Recorder records a response so it can be written later, e.g. once it's cached or if it isn't
+// retried
+type Recorder struct {
+	header http.Header
+	// Status of the response, http.StatusOK if the handler wrote the body without one
+	Status int
+	Body   bytes.Buffer
+}
+
+// NewRecorder returns a recorder with no response
+func NewRecorder() *Recorder {
+	return &Recorder{header: make(http.Header)}
+}
+
+func (r *Recorder) Header() http.Header {
+	return r.header
+}
+
+func (r *Recorder) Write(b []byte) (int, error) {
+	if r.Status == 0 {
+		r.Status = http.StatusOK
+	}
+	return r.Body.Write(b)
+}
+
+func (r *Recorder) WriteHeader(status int) {
+	if r.Status == 0 {
+		r.Status = status
+	}
+}
+
+// WriteResponse writes the recorded response to the client, a response without a status is written as
+// http.StatusOK
+func (r *Recorder) WriteResponse(w http.ResponseWriter) {
+	for k, v := range r.header {
+		w.Header()[k] = v
+	}
+	if r.Status == 0 {
+		r.Status = http.StatusOK
+	}
+	w.WriteHeader(r.Status)
+	w.Write(r.Body.Bytes())
+}
@@ -0,0 +1,32 @@
+// Package api provides the helpers shared by the http handlers and wrappers of the api, proxy and
+// web gateways
+package api
+
+import (
+	"context"
+	"strings"
+)
+
+type routedKey struct{}
+
+// WithRouted marks the endpoint of the request as routed to the full name of a service, e.g. by the
+// routes file, rather than resolved from the path to a name the prefix of the gateway is added to
+func WithRouted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, routedKey{}, true)
+}
+
+// IsRouted returns true if the endpoint of the request was routed to the full name of a service
+func IsRouted(ctx context.Context) bool {
+	v, _ := ctx.Value(routedKey{}).(bool)
+	return v
+}
+
+// ServiceName returns the name of the service the endpoint of the request was resolved to, e.g.
+// greeter => go.micro.api.greeter. Names which already have the prefix and the names of routed
+// endpoints are returned as they are.
+func ServiceName(ctx context.Context, prefix, name string) string {
+	if IsRouted(ctx) || strings.HasPrefix(name, prefix+".") {
+		return name
+	}
+	return prefix + "." + name
+}
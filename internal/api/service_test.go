@@ -0,0 +1,26 @@
+package api
+
+import (
+	"context"
+	"testing"
+)
+
+func TestServiceName(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		ctx    context.Context
+		name   string
+		expect string
+	}{
+		{ctx, "greeter", "go.micro.api.greeter"},
+		{ctx, "v1.greeter", "go.micro.api.v1.greeter"},
+		{ctx, "go.micro.api.greeter", "go.micro.api.greeter"},
+		{WithRouted(ctx), "go.micro.service.users", "go.micro.service.users"},
+		{WithRouted(ctx), "users", "users"},
+	}
+	for _, tc := range tests {
+		if name := ServiceName(tc.ctx, "go.micro.api", tc.name); name != tc.expect {
+			t.Errorf("Expected %v to be %v, got %v", tc.name, tc.expect, name)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/micro/go-micro/v2/api/router"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/config/cmd"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/micro/v2/internal/helper"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     checkOrigin,
+}
+
+// checkOrigin allows websockets from other origins once their token has been verified, the cookies
+// of the gateway are only trusted from its own origin
+func checkOrigin(r *http.Request) bool {
+	return hasVerifiedToken(r) || sameOrigin(r)
+}
+
+// isEventStream returns true if the request is for server-sent events
+func isEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// IsStreamRequest returns true if the request is for server-sent events or a websocket
+func IsStreamRequest(r *http.Request) bool {
+	return isEventStream(r) || websocket.IsWebSocketUpgrade(r)
+}
+
+// streamError returns the error of a stream as a micro error
+func streamError(err error) *errors.Error {
+	if e := errors.Parse(err.Error()); e.Code > 0 {
+		return e
+	}
+	return &errors.Error{
+		Id:     "go.micro.rpc",
+		Code:   500,
+		Detail: "error during request: " + err.Error(),
+		Status: http.StatusText(500),
+	}
+}
+
+// streamRequest reads the first request of a server-sent events stream from the request param or
+// the body of a post
+func streamRequest(r *http.Request) (json.RawMessage, error) {
+	var req []byte
+	if r.Method == "POST" {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		req = b
+	} else {
+		req = []byte(r.URL.Query().Get("request"))
+	}
+	if len(req) == 0 {
+		req = []byte("{}")
+	}
+	if !json.Valid(req) {
+		return nil, fmt.Errorf("invalid json request")
+	}
+	return json.RawMessage(req), nil
+}
+
+// serveEvents streams the responses of a server streaming endpoint as server-sent events. The
+// responses are sent as message events, errors as error events and the end of the stream as an end
+// event so the event source doesn't reconnect.
+func serveEvents(w http.ResponseWriter, r *http.Request, c client.Client, service, endpoint string) {
+	fl, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	request, err := streamRequest(r)
+	if err != nil {
+		e := errors.BadRequest("go.micro.rpc", err.Error())
+		http.Error(w, e.Error(), 400)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(event string, data []byte) {
+		if len(event) > 0 {
+			fmt.Fprintf(w, "event: %s\n", event)
+		}
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		fl.Flush()
+	}
+
+	// the stream is closed when the client goes away
+	ctx, cancel := context.WithCancel(helper.RequestToContext(r))
+	defer cancel()
+	go func() {
+		select {
+		case <-r.Context().Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	req := c.NewRequest(service, endpoint, &request, client.WithContentType("application/json"), client.StreamingRequest())
+	stream, err := c.Stream(ctx, req)
+	if err != nil {
+		b, _ := json.Marshal(streamError(err))
+		send("error", b)
+		return
+	}
+	defer stream.Close()
+
+	if err := stream.Send(&request); err != nil {
+		b, _ := json.Marshal(streamError(err))
+		send("error", b)
+		return
+	}
+
+	for {
+		var rsp json.RawMessage
+		if err := stream.Recv(&rsp); err == io.EOF {
+			send("end", []byte("{}"))
+			return
+		} else if err != nil {
+			// the client has gone away
+			if ctx.Err() != nil {
+				return
+			}
+			b, _ := json.Marshal(streamError(err))
+			send("error", b)
+			return
+		}
+		send("", rsp)
+	}
+}
+
+// serveWebsocket streams the messages of a bidirectional endpoint over a websocket. Each text
+// message from the client is sent as a request and each response is sent as a text message. The
+// socket is closed with the error if the stream fails.
+func serveWebsocket(w http.ResponseWriter, r *http.Request, c client.Client, service, endpoint string) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader has written the error
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(helper.RequestToContext(r))
+	defer cancel()
+
+	closeWith := func(code int, err error) {
+		msg := ""
+		if err != nil {
+			b, _ := json.Marshal(streamError(err))
+			conn.WriteMessage(websocket.TextMessage, b)
+			msg = streamError(err).Detail
+		}
+		// close reasons are limited to 123 bytes
+		if len(msg) > 123 {
+			msg = msg[:123]
+		}
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, msg))
+	}
+
+	// the requests are sent as they're read from the socket
+	req := c.NewRequest(service, endpoint, nil, client.WithContentType("application/json"), client.StreamingRequest())
+	stream, err := c.Stream(ctx, req)
+	if err != nil {
+		closeWith(websocket.CloseInternalServerErr, err)
+		return
+	}
+	defer stream.Close()
+
+	// the requests are read from the socket until the client closes it, which closes the stream
+	go func() {
+		defer cancel()
+		defer stream.Close()
+
+		for {
+			typ, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if typ != websocket.TextMessage && typ != websocket.BinaryMessage {
+				continue
+			}
+			if !json.Valid(msg) {
+				continue
+			}
+			request := json.RawMessage(msg)
+			if err := stream.Send(&request); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		var rsp json.RawMessage
+		if err := stream.Recv(&rsp); err == io.EOF {
+			closeWith(websocket.CloseNormalClosure, nil)
+			return
+		} else if err != nil {
+			if ctx.Err() == nil {
+				closeWith(websocket.CloseInternalServerErr, err)
+			}
+			return
+		}
+		if err := conn.WriteMessage(websocket.TextMessage, rsp); err != nil {
+			return
+		}
+	}
+}
+
+// ServeStream serves a stream request to the endpoint as server-sent events or over a websocket
+func ServeStream(w http.ResponseWriter, r *http.Request, c client.Client, service, endpoint string) {
+	if websocket.IsWebSocketUpgrade(r) {
+		serveWebsocket(w, r, c, service, endpoint)
+		return
+	}
+	serveEvents(w, r, c, service, endpoint)
+}
+
+// Stream handler streams the responses of a streaming endpoint as server-sent events or over a
+// websocket, e.g. /rpc/stream?service=go.micro.srv.greeter&endpoint=Greeter.Stream
+func Stream(w http.ResponseWriter, r *http.Request) {
+	service := r.URL.Query().Get("service")
+	endpoint := r.URL.Query().Get("endpoint")
+
+	if len(service) == 0 || len(endpoint) == 0 {
+		e := errors.BadRequest("go.micro.rpc", "invalid service or endpoint")
+		http.Error(w, e.Error(), 400)
+		return
+	}
+	if !IsStreamRequest(r) {
+		e := errors.BadRequest("go.micro.rpc", "expected an event stream or websocket request")
+		http.Error(w, e.Error(), 400)
+		return
+	}
+
+	ServeStream(w, r, *cmd.DefaultOptions().Client, service, endpoint)
+}
+
+// Streams serves the requests to the streaming endpoints resolved by the router as server-sent
+// events or over a websocket, the other requests are served by the handler
+func Streams(rt router.Router, c client.Client, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !IsStreamRequest(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		service, err := rt.Route(r)
+		if err != nil || service.Endpoint == nil {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		for _, s := range service.Services {
+			for _, ep := range s.Endpoints {
+				if ep.Name == service.Endpoint.Name && ep.Metadata["stream"] == "true" {
+					ServeStream(w, r, c, service.Name, ep.Name)
+					return
+				}
+			}
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/v2/api"
+	"github.com/micro/go-micro/v2/api/router"
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/registry"
+)
+
+type testRouter struct {
+	router.Router
+	endpoint string
+}
+
+func (r *testRouter) Route(req *http.Request) (*api.Service, error) {
+	return &api.Service{
+		Name:     "greeter",
+		Endpoint: &api.Endpoint{Name: r.endpoint},
+		Services: []*registry.Service{{
+			Name: "greeter",
+			Endpoints: []*registry.Endpoint{
+				{Name: "Greeter.Hello"},
+				{Name: "Greeter.Stream", Metadata: map[string]string{"stream": "true"}},
+			},
+		}},
+	}, nil
+}
+
+// testStreamClient streams the request back twice, or the error after the first response
+type testStreamClient struct {
+	client.Client
+	err error
+}
+
+func (c *testStreamClient) NewRequest(service, endpoint string, req interface{}, opts ...client.RequestOption) client.Request {
+	return nil
+}
+
+func (c *testStreamClient) Stream(ctx context.Context, req client.Request, opts ...client.CallOption) (client.Stream, error) {
+	return &testStream{err: c.err}, nil
+}
+
+type testStream struct {
+	client.Stream
+	err  error
+	req  json.RawMessage
+	sent int
+}
+
+func (s *testStream) Send(msg interface{}) error {
+	s.req = *msg.(*json.RawMessage)
+	return nil
+}
+
+func (s *testStream) Recv(msg interface{}) error {
+	if s.sent == 2 || (s.sent == 1 && s.err != nil) {
+		if s.err != nil {
+			return s.err
+		}
+		return io.EOF
+	}
+	s.sent++
+	*msg.(*json.RawMessage) = s.req
+	return nil
+}
+
+func (s *testStream) Close() error {
+	return nil
+}
+
+func TestStreamEvents(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(418)
+	})
+	h := Streams(&testRouter{endpoint: "Greeter.Stream"}, &testStreamClient{}, next)
+
+	req := httptest.NewRequest("GET", `/greeter/stream?request={"name":"John"}`, nil)
+	req.Header.Set("Accept", "text/event-stream")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %v", ct)
+	}
+	expected := "data: {\"name\":\"John\"}\n\ndata: {\"name\":\"John\"}\n\nevent: end\ndata: {}\n\n"
+	if body := w.Body.String(); body != expected {
+		t.Errorf("Expected the responses and end event, got %q", body)
+	}
+
+	// requests which aren't streams are served by the handler
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("POST", "/greeter/stream", nil))
+	if w.Code != 418 {
+		t.Errorf("Expected the request to be served by the handler, got %v", w.Code)
+	}
+
+	// as are requests to endpoints which aren't streams
+	h = Streams(&testRouter{endpoint: "Greeter.Hello"}, &testStreamClient{}, next)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if w.Code != 418 {
+		t.Errorf("Expected the request to be served by the handler, got %v", w.Code)
+	}
+}
+
+func TestStreamEventsError(t *testing.T) {
+	c := &testStreamClient{err: errors.NotFound("greeter", "not found")}
+	h := Streams(&testRouter{endpoint: "Greeter.Stream"}, c, http.NotFoundHandler())
+
+	req := httptest.NewRequest("POST", "/greeter/stream", strings.NewReader(`{"name":"John"}`))
+	req.Header.Set("Accept", "text/event-stream")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	events := strings.Split(strings.TrimSpace(w.Body.String()), "\n\n")
+	if len(events) != 2 {
+		t.Fatalf("Expected a response and an error, got %q", w.Body.String())
+	}
+	if !strings.HasPrefix(events[1], "event: error\ndata: ") {
+		t.Fatalf("Expected an error event, got %q", events[1])
+	}
+
+	var e errors.Error
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(events[1], "event: error\ndata: ")), &e); err != nil {
+		t.Fatalf("Error decoding the error: %v", err)
+	}
+	if e.Code != 404 {
+		t.Errorf("Expected a not found error, got %v", e.Code)
+	}
+}
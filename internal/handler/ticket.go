@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/config/cmd"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+)
+
+// TicketParam is the query param the stream tickets are passed in. Browsers can't set the headers
+// of event sources and websockets so a ticket is redeemed for the token of the stream instead, the
+// token itself is never put in the url where it would end up in logs and browser history.
+const TicketParam = "ticket"
+
+// TicketTTL is how long a stream ticket can be redeemed for
+var TicketTTL = 30 * time.Second
+
+// ticketPrefix is the prefix of the tickets in the store
+const ticketPrefix = "stream/ticket/"
+
+var (
+	// tickets is the store of the tickets, the default store so the tickets can be redeemed by any
+	// instance of the gateway
+	tickets = func() store.Store { return *cmd.DefaultOptions().Store }
+	// redeemMtx ensures a ticket is redeemed once by the gateway
+	redeemMtx sync.Mutex
+)
+
+type ticket struct {
+	Token   string `json:"token"`
+	Expires int64  `json:"expires"`
+}
+
+// IssueTicket returns a single use ticket which can be redeemed for the token within the ticket ttl
+func IssueTicket(token string) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(b)
+
+	val, err := json.Marshal(&ticket{Token: token, Expires: time.Now().Add(TicketTTL).Unix()})
+	if err != nil {
+		return "", err
+	}
+	err = tickets().Write(&store.Record{Key: ticketPrefix + id, Value: val, Expiry: TicketTTL})
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// RedeemTicket returns the token of the ticket and deletes it so it can't be redeemed again
+func RedeemTicket(id string) (string, error) {
+	redeemMtx.Lock()
+	defer redeemMtx.Unlock()
+
+	s := tickets()
+	recs, err := s.Read(ticketPrefix + id)
+	if err != nil {
+		return "", err
+	}
+	if len(recs) == 0 {
+		return "", store.ErrNotFound
+	}
+	if err := s.Delete(ticketPrefix + id); err != nil {
+		return "", err
+	}
+
+	var t ticket
+	if err := json.Unmarshal(recs[0].Value, &t); err != nil {
+		return "", err
+	}
+	if time.Now().Unix() > t.Expires {
+		return "", store.ErrNotFound
+	}
+	return t.Token, nil
+}
+
+type verifiedTokenKey struct{}
+
+// WithVerifiedToken marks the request as authenticated by a verified token which was passed
+// explicitly, i.e. in the headers or as a ticket rather than in the cookies of the browser
+func WithVerifiedToken(ctx context.Context) context.Context {
+	return context.WithValue(ctx, verifiedTokenKey{}, true)
+}
+
+// hasVerifiedToken returns true if the request was authenticated by an explicitly passed token
+func hasVerifiedToken(r *http.Request) bool {
+	v, _ := r.Context().Value(verifiedTokenKey{}).(bool)
+	return v
+}
+
+// sameOrigin returns true if the request isn't from a browser on another site
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if len(origin) == 0 {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// Ticket handler issues a stream ticket for the token of the request, e.g. POST /rpc/stream/ticket.
+// The ticket is passed as the ?ticket= param of a stream request. Tickets are only issued for the
+// cookies of the gateway to its own origin.
+func Ticket(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		e := errors.New("go.micro.rpc", "method not allowed", http.StatusMethodNotAllowed)
+		http.Error(w, e.Error(), http.StatusMethodNotAllowed)
+		return
+	}
+	if !hasVerifiedToken(r) && !sameOrigin(r) {
+		e := errors.Forbidden("go.micro.rpc", "tickets aren't issued to other origins")
+		http.Error(w, e.Error(), 403)
+		return
+	}
+
+	header := r.Header.Get("Authorization")
+	if _, ok := auth.AccountFromContext(r.Context()); !ok || !strings.HasPrefix(header, auth.BearerScheme) {
+		e := errors.Unauthorized("go.micro.rpc", "a token is required for a ticket")
+		http.Error(w, e.Error(), 401)
+		return
+	}
+
+	id, err := IssueTicket(header[len(auth.BearerScheme):])
+	if err != nil {
+		e := errors.InternalServerError("go.micro.rpc", "error issuing ticket: %v", err)
+		http.Error(w, e.Error(), 500)
+		return
+	}
+
+	b, _ := json.Marshal(map[string]interface{}{
+		"ticket":  id,
+		"expires": int64(TicketTTL.Seconds()),
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(b)
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+)
+
+func TestTickets(t *testing.T) {
+	s := memory.NewStore()
+	tickets = func() store.Store { return s }
+
+	id, err := IssueTicket("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token, err := RedeemTicket(id); err != nil || token != "abc" {
+		t.Fatalf("Expected the token of the ticket, got %v %v", token, err)
+	}
+	// the tickets are single use
+	if _, err := RedeemTicket(id); err == nil {
+		t.Errorf("Expected the ticket to be redeemed once")
+	}
+	if _, err := RedeemTicket("foo"); err == nil {
+		t.Errorf("Expected an unknown ticket not to be redeemed")
+	}
+
+	// the expired tickets can't be redeemed if the store hasn't expired them yet
+	TicketTTL = -time.Minute
+	defer func() { TicketTTL = 30 * time.Second }()
+	id, err = IssueTicket("abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RedeemTicket(id); err == nil {
+		t.Errorf("Expected an expired ticket not to be redeemed")
+	}
+}
+
+func TestTicketHandler(t *testing.T) {
+	s := memory.NewStore()
+	tickets = func() store.Store { return s }
+
+	request := func(origin string, account, verified bool) *http.Request {
+		req := httptest.NewRequest("POST", "http://localhost:8080/rpc/stream/ticket", nil)
+		req.Header.Set("Authorization", auth.BearerScheme+"abc")
+		if len(origin) > 0 {
+			req.Header.Set("Origin", origin)
+		}
+		ctx := req.Context()
+		if account {
+			ctx = auth.ContextWithAccount(ctx, &auth.Account{ID: "john"})
+		}
+		if verified {
+			ctx = WithVerifiedToken(ctx)
+		}
+		return req.WithContext(ctx)
+	}
+
+	tests := []struct {
+		name   string
+		req    *http.Request
+		status int
+	}{
+		{"same origin", request("http://localhost:8080", true, false), http.StatusOK},
+		{"no origin", request("", true, false), http.StatusOK},
+		{"cookie from other origin", request("http://example.com", true, false), http.StatusForbidden},
+		{"verified token from other origin", request("http://example.com", true, true), http.StatusOK},
+		{"no account", request("", false, false), http.StatusUnauthorized},
+	}
+
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		Ticket(w, tc.req)
+		if w.Code != tc.status {
+			t.Errorf("Expected %v for %v, got %v: %v", tc.status, tc.name, w.Code, w.Body.String())
+			continue
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+
+		var rsp struct {
+			Ticket string `json:"ticket"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &rsp); err != nil {
+			t.Fatal(err)
+		}
+		if token, err := RedeemTicket(rsp.Ticket); err != nil || token != "abc" {
+			t.Errorf("Expected the ticket of %v to be redeemed for the token, got %v %v", tc.name, token, err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	Ticket(w, httptest.NewRequest("GET", "/rpc/stream/ticket", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected tickets to be posted, got %v", w.Code)
+	}
+}
+
+func TestCheckOrigin(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://localhost:8080/rpc/stream", nil)
+	req.Header.Set("Origin", "http://example.com")
+	if checkOrigin(req) {
+		t.Errorf("Expected a websocket from another origin to be rejected")
+	}
+
+	// an unverified authorization header, e.g. set from the cookie, doesn't bypass the check
+	req.Header.Set("Authorization", auth.BearerScheme+"abc")
+	if checkOrigin(req) {
+		t.Errorf("Expected an unverified token not to bypass the origin check")
+	}
+
+	if !checkOrigin(req.WithContext(WithVerifiedToken(req.Context()))) {
+		t.Errorf("Expected a verified token to bypass the origin check")
+	}
+
+	req = httptest.NewRequest("GET", "http://localhost:8080/rpc/stream", nil)
+	req.Header.Set("Origin", "http://localhost:8080")
+	if !checkOrigin(req) {
+		t.Errorf("Expected a websocket from the same origin to be allowed")
+	}
+}
@@ -0,0 +1,378 @@
+// Package jsonschema validates json documents against a subset of JSON Schema (draft 7). The
+// supported keywords are type, enum, const, properties, required, additionalProperties, items,
+// minItems, maxItems, minimum, maximum, exclusiveMinimum, exclusiveMaximum, minLength, maxLength,
+// pattern, allOf, anyOf and oneOf. Unknown keywords are ignored.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema is a compiled JSON Schema
+type Schema struct {
+	// Bool is set for the boolean schemas true and false
+	Bool *bool
+
+	Type                 []string
+	Enum                 []interface{}
+	Const                interface{}
+	HasConst             bool
+	Properties           map[string]*Schema
+	Required             []string
+	AdditionalProperties *Schema
+	Items                *Schema
+	MinItems             *int
+	MaxItems             *int
+	Minimum              *float64
+	Maximum              *float64
+	ExclusiveMinimum     *float64
+	ExclusiveMaximum     *float64
+	MinLength            *int
+	MaxLength            *int
+	Pattern              *regexp.Regexp
+	AllOf                []*Schema
+	AnyOf                []*Schema
+	OneOf                []*Schema
+}
+
+// Error is a value which failed validation
+type Error struct {
+	// Path to the value, e.g. db.hosts.0
+	Path string
+	// Message describing why the value is invalid
+	Message string
+}
+
+func (e *Error) Error() string {
+	if len(e.Path) == 0 {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Compile parses a JSON Schema
+func Compile(data []byte) (*Schema, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, fmt.Errorf("Invalid schema: %v", err)
+	}
+	return compile(v, "")
+}
+
+func compile(v interface{}, path string) (*Schema, error) {
+	if b, ok := v.(bool); ok {
+		return &Schema{Bool: &b}, nil
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Invalid schema at %v: expected an object", loc(path))
+	}
+
+	s := &Schema{}
+	var err error
+
+	switch t := m["type"].(type) {
+	case nil:
+	case string:
+		s.Type = []string{t}
+	case []interface{}:
+		for _, v := range t {
+			str, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("Invalid schema at %v: type must be a string", loc(path))
+			}
+			s.Type = append(s.Type, str)
+		}
+	default:
+		return nil, fmt.Errorf("Invalid schema at %v: type must be a string or array", loc(path))
+	}
+
+	if e, ok := m["enum"]; ok {
+		if s.Enum, ok = e.([]interface{}); !ok {
+			return nil, fmt.Errorf("Invalid schema at %v: enum must be an array", loc(path))
+		}
+	}
+	if c, ok := m["const"]; ok {
+		s.Const, s.HasConst = c, true
+	}
+
+	if p, ok := m["properties"]; ok {
+		props, ok := p.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Invalid schema at %v: properties must be an object", loc(path))
+		}
+		s.Properties = make(map[string]*Schema, len(props))
+		for k, v := range props {
+			if s.Properties[k], err = compile(v, join(path, k)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if r, ok := m["required"]; ok {
+		req, ok := r.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Invalid schema at %v: required must be an array", loc(path))
+		}
+		for _, v := range req {
+			str, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("Invalid schema at %v: required must contain strings", loc(path))
+			}
+			s.Required = append(s.Required, str)
+		}
+	}
+	if a, ok := m["additionalProperties"]; ok {
+		if s.AdditionalProperties, err = compile(a, path); err != nil {
+			return nil, err
+		}
+	}
+	if i, ok := m["items"]; ok {
+		if s.Items, err = compile(i, join(path, "items")); err != nil {
+			return nil, err
+		}
+	}
+
+	ints := map[string]**int{"minItems": &s.MinItems, "maxItems": &s.MaxItems, "minLength": &s.MinLength, "maxLength": &s.MaxLength}
+	for k, dst := range ints {
+		if v, ok := m[k]; ok {
+			f, ok := v.(float64)
+			if !ok || f < 0 || f != math.Trunc(f) {
+				return nil, fmt.Errorf("Invalid schema at %v: %v must be a non-negative integer", loc(path), k)
+			}
+			n := int(f)
+			*dst = &n
+		}
+	}
+	nums := map[string]**float64{"minimum": &s.Minimum, "maximum": &s.Maximum, "exclusiveMinimum": &s.ExclusiveMinimum, "exclusiveMaximum": &s.ExclusiveMaximum}
+	for k, dst := range nums {
+		if v, ok := m[k]; ok {
+			f, ok := v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("Invalid schema at %v: %v must be a number", loc(path), k)
+			}
+			*dst = &f
+		}
+	}
+
+	if p, ok := m["pattern"]; ok {
+		str, ok := p.(string)
+		if !ok {
+			return nil, fmt.Errorf("Invalid schema at %v: pattern must be a string", loc(path))
+		}
+		if s.Pattern, err = regexp.Compile(str); err != nil {
+			return nil, fmt.Errorf("Invalid schema at %v: %v", loc(path), err)
+		}
+	}
+
+	subs := map[string]*[]*Schema{"allOf": &s.AllOf, "anyOf": &s.AnyOf, "oneOf": &s.OneOf}
+	for k, dst := range subs {
+		v, ok := m[k]
+		if !ok {
+			continue
+		}
+		arr, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Invalid schema at %v: %v must be an array", loc(path), k)
+		}
+		for _, v := range arr {
+			sub, err := compile(v, path)
+			if err != nil {
+				return nil, err
+			}
+			*dst = append(*dst, sub)
+		}
+	}
+
+	return s, nil
+}
+
+// Validate the json document against the schema, returning every value which is invalid
+func (s *Schema) Validate(data []byte) ([]*Error, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return s.ValidateValue(v), nil
+}
+
+// ValidateValue validates a decoded json value against the schema
+func (s *Schema) ValidateValue(v interface{}) []*Error {
+	var errs []*Error
+	s.validate(v, "", &errs)
+	return errs
+}
+
+func (s *Schema) validate(v interface{}, path string, errs *[]*Error) {
+	fail := func(format string, a ...interface{}) {
+		*errs = append(*errs, &Error{Path: path, Message: fmt.Sprintf(format, a...)})
+	}
+
+	if s.Bool != nil {
+		if !*s.Bool {
+			fail("no value is allowed")
+		}
+		return
+	}
+
+	if len(s.Type) > 0 && !matchesType(v, s.Type) {
+		fail("expected %v but got %v", strings.Join(s.Type, " or "), typeOf(v))
+		return
+	}
+	if len(s.Enum) > 0 {
+		var found bool
+		for _, e := range s.Enum {
+			if reflect.DeepEqual(e, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			fail("must be one of %v", encode(s.Enum))
+		}
+	}
+	if s.HasConst && !reflect.DeepEqual(s.Const, v) {
+		fail("must be %v", encode(s.Const))
+	}
+
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for _, r := range s.Required {
+			if _, ok := val[r]; !ok {
+				*errs = append(*errs, &Error{Path: join(path, r), Message: "is required"})
+			}
+		}
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if p, ok := s.Properties[k]; ok {
+				p.validate(val[k], join(path, k), errs)
+			} else if s.AdditionalProperties != nil {
+				if s.AdditionalProperties.Bool != nil && !*s.AdditionalProperties.Bool {
+					*errs = append(*errs, &Error{Path: join(path, k), Message: "is not allowed"})
+					continue
+				}
+				s.AdditionalProperties.validate(val[k], join(path, k), errs)
+			}
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(val) < *s.MinItems {
+			fail("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(val) > *s.MaxItems {
+			fail("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range val {
+				s.Items.validate(item, join(path, fmt.Sprintf("%d", i)), errs)
+			}
+		}
+	case float64:
+		if s.Minimum != nil && val < *s.Minimum {
+			fail("must be >= %v", *s.Minimum)
+		}
+		if s.Maximum != nil && val > *s.Maximum {
+			fail("must be <= %v", *s.Maximum)
+		}
+		if s.ExclusiveMinimum != nil && val <= *s.ExclusiveMinimum {
+			fail("must be > %v", *s.ExclusiveMinimum)
+		}
+		if s.ExclusiveMaximum != nil && val >= *s.ExclusiveMaximum {
+			fail("must be < %v", *s.ExclusiveMaximum)
+		}
+	case string:
+		n := utf8.RuneCountInString(val)
+		if s.MinLength != nil && n < *s.MinLength {
+			fail("must be at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			fail("must be at most %d characters", *s.MaxLength)
+		}
+		if s.Pattern != nil && !s.Pattern.MatchString(val) {
+			fail("must match the pattern %v", s.Pattern.String())
+		}
+	}
+
+	for _, sub := range s.AllOf {
+		sub.validate(v, path, errs)
+	}
+	if len(s.AnyOf) > 0 && countValid(s.AnyOf, v) == 0 {
+		fail("must match at least one schema in anyOf")
+	}
+	if len(s.OneOf) > 0 {
+		if n := countValid(s.OneOf, v); n != 1 {
+			fail("must match exactly one schema in oneOf but matched %d", n)
+		}
+	}
+}
+
+// countValid returns the number of schemas the value is valid against
+func countValid(schemas []*Schema, v interface{}) int {
+	var n int
+	for _, s := range schemas {
+		if len(s.ValidateValue(v)) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// typeOf returns the json type of a decoded value
+func typeOf(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if val == math.Trunc(val) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "unknown"
+}
+
+func matchesType(v interface{}, types []string) bool {
+	t := typeOf(v)
+	for _, want := range types {
+		if want == t || (want == "number" && t == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func join(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + "." + key
+}
+
+func loc(path string) string {
+	if len(path) == 0 {
+		return "the root"
+	}
+	return path
+}
+
+func encode(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
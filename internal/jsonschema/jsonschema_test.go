@@ -0,0 +1,67 @@
+package jsonschema
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	schema, err := Compile([]byte(`{
+		"type": "object",
+		"required": ["host", "port"],
+		"additionalProperties": false,
+		"properties": {
+			"host": {"type": "string", "minLength": 1},
+			"port": {"type": "integer", "minimum": 1, "maximum": 65535},
+			"mode": {"enum": ["primary", "replica"]},
+			"tags": {"type": "array", "items": {"type": "string", "pattern": "^[a-z]+$"}}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Unexpected error compiling schema: %v", err)
+	}
+
+	tt := []struct {
+		Name  string
+		Data  string
+		Paths []string
+	}{
+		{"Valid", `{"host":"localhost","port":5432,"mode":"primary","tags":["db"]}`, nil},
+		{"Missing", `{"host":"localhost"}`, []string{"port"}},
+		{"WrongType", `{"host":"localhost","port":"5432"}`, []string{"port"}},
+		{"Range", `{"host":"localhost","port":70000}`, []string{"port"}},
+		{"Enum", `{"host":"localhost","port":1,"mode":"other"}`, []string{"mode"}},
+		{"Items", `{"host":"localhost","port":1,"tags":["ok","Not OK"]}`, []string{"tags.1"}},
+		{"Additional", `{"host":"localhost","port":1,"hots":"x"}`, []string{"hots"}},
+		{"Root", `[]`, []string{""}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			errs, err := schema.Validate([]byte(tc.Data))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(errs) != len(tc.Paths) {
+				t.Fatalf("Expected %v errors but got %v", len(tc.Paths), errs)
+			}
+			for i, p := range tc.Paths {
+				if errs[i].Path != p {
+					t.Errorf("Expected an error at %q but got %v", p, errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	invalid := []string{
+		`[]`,
+		`{"type": 1}`,
+		`{"properties": []}`,
+		`{"pattern": "("}`,
+		`{"minLength": -1}`,
+	}
+	for _, s := range invalid {
+		if _, err := Compile([]byte(s)); err == nil {
+			t.Errorf("Expected %v to be invalid", s)
+		}
+	}
+}
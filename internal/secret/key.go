@@ -0,0 +1,50 @@
+package secret
+
+import (
+	"fmt"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/internal/config"
+)
+
+var (
+	// savedKey and saveKey read and write the keys generated for a service in the local config
+	savedKey = func(service string) (string, error) { return config.Get(service, "secret_key") }
+	saveKey  = func(service, key string) error { return config.Set(key, service, "secret_key") }
+)
+
+// localStore returns true if the store is local to the host, i.e. it isn't shared by the replicas of
+// a service and doesn't outlive the host
+func localStore(s store.Store) bool {
+	switch s.String() {
+	case "memory", "file":
+		return true
+	}
+	return false
+}
+
+// LoadKey returns the key a service encrypts the secrets in its store with. The key is decoded from
+// v, e.g. the secret_key flag of the service. If it's blank and the store is local a key is generated
+// and saved in the local config so the secrets can still be decrypted on restart. Any other store is
+// shared by replicas on other hosts which couldn't decrypt the secrets, so a key is required.
+func LoadKey(service, v string, s store.Store) ([]byte, error) {
+	if len(v) > 0 {
+		return DecodeKey(v)
+	}
+	if !localStore(s) {
+		return nil, fmt.Errorf("A secret key is required with the %v store, secrets encrypted with a generated key can't be decrypted by other replicas or after the host is replaced", s.String())
+	}
+
+	if v, _ = savedKey(service); len(v) > 0 {
+		return DecodeKey(v)
+	}
+
+	key, err := GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := saveKey(service, EncodeKey(key)); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
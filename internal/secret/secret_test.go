@@ -0,0 +1,99 @@
+package secret
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/micro/go-micro/v2/store"
+)
+
+func TestEncrypt(t *testing.T) {
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("Unexpected error generating key: %v", err)
+	}
+
+	ciphertext, err := Encrypt(key, []byte("hunter2"))
+	if err != nil {
+		t.Fatalf("Unexpected error encrypting: %v", err)
+	}
+	if bytes.Contains(ciphertext, []byte("hunter2")) {
+		t.Fatalf("Ciphertext contains the plaintext")
+	}
+
+	plaintext, err := Decrypt(key, ciphertext)
+	if err != nil {
+		t.Fatalf("Unexpected error decrypting: %v", err)
+	}
+	if string(plaintext) != "hunter2" {
+		t.Errorf("Expected hunter2 but got %v", string(plaintext))
+	}
+
+	// decrypting with another key should fail
+	other, _ := GenerateKey()
+	if _, err := Decrypt(other, ciphertext); err != ErrInvalidCiphertext {
+		t.Errorf("Expected ErrInvalidCiphertext decrypting with the wrong key but got %v", err)
+	}
+}
+
+func TestKey(t *testing.T) {
+	key, _ := GenerateKey()
+	decoded, err := DecodeKey(EncodeKey(key))
+	if err != nil {
+		t.Fatalf("Unexpected error decoding key: %v", err)
+	}
+	if !bytes.Equal(key, decoded) {
+		t.Errorf("Decoded key doesn't match")
+	}
+
+	if _, err := DecodeKey(EncodeKey([]byte("short"))); err == nil {
+		t.Errorf("Expected an error decoding a short key")
+	}
+}
+
+type testStore struct {
+	store.Store
+	name string
+}
+
+func (s *testStore) String() string {
+	return s.name
+}
+
+func TestLoadKey(t *testing.T) {
+	saved := make(map[string]string)
+	savedKey = func(service string) (string, error) { return saved[service], nil }
+	saveKey = func(service, key string) error { saved[service] = key; return nil }
+
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("Unexpected error generating key: %v", err)
+	}
+	shared := &testStore{name: "cockroach"}
+
+	// the key provided is used with any store
+	if k, err := LoadKey("config", EncodeKey(key), shared); err != nil || !bytes.Equal(k, key) {
+		t.Errorf("Expected the key provided to be used but got %v", err)
+	}
+
+	// a key is required when the store is shared by replicas
+	if _, err := LoadKey("config", "", shared); err == nil {
+		t.Errorf("Expected a key to be required with a shared store")
+	}
+	if len(saved) > 0 {
+		t.Errorf("Expected no key to be generated with a shared store")
+	}
+
+	// a key is generated once for a local store
+	local := &testStore{name: "file"}
+	generated, err := LoadKey("config", "", local)
+	if err != nil || len(generated) != KeySize {
+		t.Fatalf("Expected a key to be generated with a local store but got %v", err)
+	}
+	if k, err := LoadKey("config", "", local); err != nil || !bytes.Equal(k, generated) {
+		t.Errorf("Expected the generated key to be reused but got %v", err)
+	}
+	if k, err := LoadKey("runtime", "", local); err != nil || bytes.Equal(k, generated) {
+		t.Errorf("Expected each service to generate its own key but got %v", err)
+	}
+}
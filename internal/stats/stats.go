@@ -20,6 +20,9 @@ type stats struct {
 
 	Counters []*counter `json:"counters"`
 
+	// collectors add the stats of other components, e.g. the circuit breakers of the api
+	collectors map[string]func() interface{}
+
 	running bool
 	exit    chan bool
 }
@@ -122,11 +125,39 @@ func (s *stats) ServeHTTP(h http.Handler) http.Handler {
 	})
 }
 
+// Collect adds the value returned by fn to the stats under the name
+func (s *stats) Collect(name string, fn func() interface{}) {
+	s.Lock()
+	if s.collectors == nil {
+		s.collectors = make(map[string]func() interface{})
+	}
+	s.collectors[name] = fn
+	s.Unlock()
+}
+
+// marshal the stats along with the collected values
+func (s *stats) marshal() ([]byte, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	b, err := json.Marshal(s)
+	if err != nil || len(s.collectors) == 0 {
+		return b, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	for name, fn := range s.collectors {
+		m[name] = fn()
+	}
+	return json.Marshal(m)
+}
+
 func (s *stats) StatsHandler(w http.ResponseWriter, r *http.Request) {
 	if ct := r.Header.Get("Content-Type"); ct == "application/json" {
-		s.RLock()
-		b, err := json.Marshal(s)
-		s.RUnlock()
+		b, err := s.marshal()
 		if err != nil {
 			http.Error(w, err.Error(), 500)
 			return
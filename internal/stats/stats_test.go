@@ -1,6 +1,7 @@
 package stats
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -41,3 +42,26 @@ func TestStats(t *testing.T) {
 		}
 	}
 }
+
+func TestCollect(t *testing.T) {
+	s := New()
+	s.Collect("breakers", func() interface{} {
+		return []string{"go.micro.api.users"}
+	})
+
+	b, err := s.marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := m["breakers"].([]interface{}); !ok || len(v) != 1 {
+		t.Fatalf("Expected the collected breakers, got %+v", m["breakers"])
+	}
+	if _, ok := m["counters"]; !ok {
+		t.Fatal("Expected the counters")
+	}
+}
@@ -83,6 +83,19 @@ var (
                     </tr>
                   </tbody>
                 </table>
+
+                <table class="table table-bordered breakers" style="display: none;">
+                  <caption>Circuit Breakers</caption>
+                  <thead>
+                    <tr>
+                      <th>Endpoint</th>
+                      <th>State</th>
+                      <th>Trips</th>
+                      <th>Rejected</th>
+                    </tr>
+                  </thead>
+                  <tbody></tbody>
+                </table>
 	      </div>
 	      <div class="col-sm-8">
                 {{ template "content" . }}
@@ -284,6 +297,20 @@ var (
             $('.50x').text(fx);
 
             loadChart(data["counters"]);
+
+            // circuit breakers
+            var breakers = data["breakers"] || [];
+            var rows = $('.breakers tbody').empty();
+            for (i = 0; i < breakers.length; i++) {
+              var b = breakers[i];
+              var row = $('<tr>');
+              row.append($('<td>').text(b["service"] + " " + b["endpoint"]));
+              row.append($('<td>').text(b["state"]));
+              row.append($('<td>').text(b["trips"]));
+              row.append($('<td>').text(b["rejected"]));
+              rows.append(row);
+            };
+            $('.breakers').toggle(breakers.length > 0);
 	}
     }
 
@@ -90,6 +90,15 @@ resource "kubernetes_deployment" "config" {
               }
             }
           }
+          env {
+            name = "MICRO_CONFIG_SECRET_KEY"
+            value_from {
+              secret_key_ref {
+                name = kubernetes_secret.micro_secret_keys.metadata[0].name
+                key  = "config"
+              }
+            }
+          }
           args              = ["config"]
           image             = var.micro_image
           image_pull_policy = var.image_pull_policy
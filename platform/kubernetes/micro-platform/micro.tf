@@ -52,6 +52,29 @@ resource "kubernetes_secret" "micro_keypair" {
   }
 }
 
+# the keys secrets are encrypted with by the config and runtime services, the replicas of each share
+# the key so any of them can decrypt the secrets in the store
+resource "random_id" "config_secret_key" {
+  byte_length = 32
+}
+
+resource "random_id" "runtime_secret_key" {
+  byte_length = 32
+}
+
+resource "kubernetes_secret" "micro_secret_keys" {
+  metadata {
+    name        = "micro-secret-keys"
+    namespace   = kubernetes_namespace.platform.id
+    labels      = local.common_labels
+    annotations = local.common_annotations
+  }
+  data = {
+    config  = random_id.config_secret_key.b64_std
+    runtime = random_id.runtime_secret_key.b64_std
+  }
+}
+
 resource "kubernetes_secret" "platform_ca" {
   metadata {
     name        = "platform-ca"
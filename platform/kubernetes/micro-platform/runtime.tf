@@ -91,6 +91,15 @@ resource "kubernetes_deployment" "runtime" {
               }
             }
           }
+          env {
+            name = "MICRO_RUNTIME_SECRET_KEY"
+            value_from {
+              secret_key_ref {
+                name = kubernetes_secret.micro_secret_keys.metadata[0].name
+                key  = "runtime"
+              }
+            }
+          }
           args              = ["runtime"]
           image             = var.micro_image
           image_pull_policy = var.image_pull_policy
@@ -3,8 +3,11 @@ package config
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/micro/cli/v2"
@@ -14,7 +17,10 @@ import (
 	log "github.com/micro/go-micro/v2/logger"
 	"github.com/micro/micro/v2/internal/client"
 	"github.com/micro/micro/v2/internal/helper"
+	"github.com/micro/micro/v2/internal/secret"
+	"github.com/micro/micro/v2/service/config/format"
 	"github.com/micro/micro/v2/service/config/handler"
+	hpb "github.com/micro/micro/v2/service/config/proto"
 )
 
 var (
@@ -39,11 +45,31 @@ func Run(c *cli.Context, srvOpts ...micro.Option) {
 
 	service := micro.NewService(srvOpts...)
 
+	// get the key to encrypt the data keys of secrets with
+	st := *cmd.DefaultCmd.Options().Store
+	key, err := secret.LoadKey("config", c.String("secret_key"), st)
+	if err != nil {
+		log.Fatalf("config failed to load secret key, set it with --secret_key or MICRO_CONFIG_SECRET_KEY: %v", err)
+	}
+
 	h := &handler.Config{
-		Store: *cmd.DefaultCmd.Options().Store,
+		Store:     st,
+		SecretKey: key,
+		// config paths are verified against the auth rules, e.g. config:write:payments/*
+		Auth: service.Options().Auth,
+	}
+	if c.IsSet("secret_scopes") {
+		h.SecretScopes = c.StringSlice("secret_scopes")
 	}
 
 	proto.RegisterConfigHandler(service.Server(), h)
+	hpb.RegisterHistoryHandler(service.Server(), &handler.History{Config: h})
+	hpb.RegisterSchemaHandler(service.Server(), &handler.Schema{Config: h})
+	hpb.RegisterSecretsHandler(service.Server(), &handler.Secrets{Config: h})
+	hpb.RegisterLayersHandler(service.Server(), &handler.Layers{Config: h})
+	hpb.RegisterNamespacesHandler(service.Server(), &handler.Namespaces{Config: h})
+	hpb.RegisterDocumentsHandler(service.Server(), &handler.Documents{Config: h})
+	hpb.RegisterChangesHandler(service.Server(), &handler.Changes{Config: h})
 	micro.RegisterSubscriber(handler.WatchTopic, service.Server(), handler.Watcher)
 
 	if err := service.Run(); err != nil {
@@ -51,6 +77,14 @@ func Run(c *cli.Context, srvOpts ...micro.Option) {
 	}
 }
 
+// layerNamespace returns the namespace of the layer set by the --layer flag, the namespace if none is set
+func layerNamespace(ctx *cli.Context) string {
+	if layer := ctx.String("layer"); len(layer) > 0 {
+		return Namespace + handler.LayerSplitter + layer
+	}
+	return Namespace
+}
+
 func setConfig(ctx *cli.Context) error {
 	pb := proto.NewConfigService("go.micro.config", client.New(ctx))
 
@@ -65,12 +99,26 @@ func setConfig(ctx *cli.Context) error {
 	key := args.Get(0)
 	val := args.Get(1)
 
+	// secrets are encrypted by the config service before they're stored
+	if ctx.Bool("secret") {
+		_, err := hpb.NewSecretsService("go.micro.config", client.New(ctx)).Set(context.TODO(), &hpb.SetSecretRequest{
+			Namespace: layerNamespace(ctx),
+			Path:      key,
+			Value:     val,
+		})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return nil
+	}
+
 	// TODO: allow the specifying of a config.Key. This will be service name
 	// The actuall key-val set is a path e.g micro/accounts/key
 	_, err := pb.Update(context.TODO(), &proto.UpdateRequest{
 		Change: &proto.Change{
-			// global key
-			Namespace: Namespace,
+			// global key, or one of its layers
+			Namespace: layerNamespace(ctx),
 			// actual key for the value
 			Path: key,
 			// The value
@@ -107,6 +155,11 @@ func getConfig(ctx *cli.Context) error {
 		log.Fatal("key cannot be blank")
 	}
 
+	// show the layer each value came from
+	if ctx.Bool("layer") {
+		return resolveConfig(ctx, key)
+	}
+
 	// TODO: allow the specifying of a config.Key. This will be service name
 	// The actuall key-val set is a path e.g micro/accounts/key
 
@@ -165,7 +218,7 @@ func delConfig(ctx *cli.Context) error {
 	_, err := pb.Delete(context.TODO(), &proto.DeleteRequest{
 		Change: &proto.Change{
 			// The global key,
-			Namespace: Namespace,
+			Namespace: layerNamespace(ctx),
 			// The actual key for the val
 			Path: key,
 		},
@@ -178,6 +231,391 @@ func delConfig(ctx *cli.Context) error {
 	return nil
 }
 
+func importConfig(ctx *cli.Context) error {
+	file := ctx.String("file")
+	if len(file) == 0 {
+		fmt.Println("Required usage: micro config import -f file.{json,yaml,toml,env} [--path key] [--replace]")
+		os.Exit(1)
+	}
+
+	docs := hpb.NewDocumentsService("go.micro.config", client.New(ctx))
+	if err := importFile(docs, layerNamespace(ctx), ctx.String("path"), file, ctx.String("format"), ctx.Bool("replace")); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// importFile imports a json, yaml, toml or env file into the namespace at the path. The document is
+// merged into the config unless it replaces the value at the path.
+func importFile(docs hpb.DocumentsService, namespace, path, file, f string, replace bool) error {
+	if len(f) == 0 {
+		var err error
+		if f, err = format.FromFilename(file); err != nil {
+			return err
+		}
+	}
+
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	data, err := format.Decode(f, b)
+	if err != nil {
+		return fmt.Errorf("Error decoding %v: %v", file, err)
+	}
+
+	// the whole document is imported in one revision
+	_, err = docs.Import(context.TODO(), &hpb.ImportRequest{
+		Namespace: namespace,
+		Path:      path,
+		Data:      string(data),
+		Replace:   replace,
+	})
+	return err
+}
+
+func exportConfig(ctx *cli.Context) error {
+	cfg := proto.NewConfigService("go.micro.config", client.New(ctx))
+	b, err := exportData(cfg, Namespace, ctx.String("path"), ctx.String("format"))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if out := ctx.String("output"); len(out) > 0 {
+		if err := ioutil.WriteFile(out, b, 0600); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return nil
+	}
+
+	os.Stdout.Write(b)
+	return nil
+}
+
+// exportData returns the config of the namespace at the path encoded in the format
+func exportData(cfg proto.ConfigService, namespace, path, f string) ([]byte, error) {
+	rsp, err := cfg.Read(context.TODO(), &proto.ReadRequest{
+		Namespace: namespace,
+		Path:      path,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rsp.Change == nil || rsp.Change.ChangeSet == nil || rsp.Change.ChangeSet.Data == "null" {
+		return nil, fmt.Errorf("not found")
+	}
+
+	return format.Encode(f, []byte(rsp.Change.ChangeSet.Data))
+}
+
+func listConfig(ctx *cli.Context) error {
+	rsp, err := hpb.NewNamespacesService("go.micro.config", client.New(ctx)).List(context.TODO(), &hpb.ListNamespacesRequest{
+		Prefix: ctx.String("prefix"),
+		Limit:  int64(ctx.Int("limit")),
+		Offset: int64(ctx.Int("offset")),
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tUPDATED")
+	for _, ns := range rsp.Namespaces {
+		fmt.Fprintf(w, "%s\t%s\n", ns.Namespace, time.Unix(ns.Timestamp, 0).Format(time.RFC3339))
+	}
+	w.Flush()
+
+	if rsp.NextOffset > 0 {
+		fmt.Printf("\nMore namespaces; micro config list --offset %d\n", rsp.NextOffset)
+	}
+
+	return nil
+}
+
+func watchConfig(ctx *cli.Context) error {
+	stream, err := hpb.NewChangesService("go.micro.config", client.New(ctx)).Watch(context.TODO(), &hpb.WatchChangesRequest{
+		Namespace:    Namespace,
+		Path:         ctx.String("path"),
+		FromRevision: int64(ctx.Int("from")),
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer stream.Close()
+
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		action := ev.Action
+		if ev.Resync {
+			action = "resync"
+		}
+		fmt.Printf("%d\t%s\t%s\n", ev.Revision, action, ev.Data)
+	}
+}
+
+func configHistory(ctx *cli.Context) error {
+	rsp, err := hpb.NewHistoryService("go.micro.config", client.New(ctx)).List(context.TODO(), &hpb.HistoryRequest{
+		Namespace: Namespace,
+		Path:      ctx.String("path"),
+		Limit:     int64(ctx.Int("limit")),
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	parse := func(m string) string {
+		if len(m) == 0 {
+			return "n/a"
+		}
+		return m
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "REVISION\tTIME\tAUTHOR\tACTION\tPATH\tOLD\tNEW")
+	for _, rev := range rsp.Revisions {
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			rev.Id,
+			time.Unix(rev.Timestamp, 0).Format(time.RFC3339),
+			parse(rev.Author),
+			rev.Action,
+			parse(rev.Path),
+			parse(rev.OldValue),
+			parse(rev.NewValue))
+	}
+	writer.Flush()
+
+	return nil
+}
+
+func configDiff(ctx *cli.Context) error {
+	args := ctx.Args()
+
+	if args.Len() == 0 {
+		fmt.Println("Required usage: micro config diff rev1 [rev2]")
+		os.Exit(1)
+	}
+
+	var revs [2]int64
+	for i := 0; i < args.Len() && i < 2; i++ {
+		rev, err := strconv.ParseInt(args.Get(i), 10, 64)
+		if err != nil {
+			fmt.Println("Invalid revision:", args.Get(i))
+			os.Exit(1)
+		}
+		revs[i] = rev
+	}
+
+	rsp, err := hpb.NewHistoryService("go.micro.config", client.New(ctx)).Diff(context.TODO(), &hpb.DiffRequest{
+		Namespace: Namespace,
+		From:      revs[0],
+		To:        revs[1],
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	for _, ch := range rsp.Changes {
+		if len(ch.OldValue) > 0 {
+			fmt.Printf("- %s: %s\n", ch.Path, ch.OldValue)
+		}
+		if len(ch.NewValue) > 0 {
+			fmt.Printf("+ %s: %s\n", ch.Path, ch.NewValue)
+		}
+	}
+
+	return nil
+}
+
+func configRevert(ctx *cli.Context) error {
+	args := ctx.Args()
+
+	if args.Len() == 0 {
+		fmt.Println("Required usage: micro config revert rev")
+		os.Exit(1)
+	}
+
+	rev, err := strconv.ParseInt(args.Get(0), 10, 64)
+	if err != nil {
+		fmt.Println("Invalid revision:", args.Get(0))
+		os.Exit(1)
+	}
+
+	rsp, err := hpb.NewHistoryService("go.micro.config", client.New(ctx)).Revert(context.TODO(), &hpb.RevertRequest{
+		Namespace: Namespace,
+		Revision:  rev,
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Reverted to revision %d as revision %d\n", rev, rsp.Revision.Id)
+	return nil
+}
+
+// readInput returns the first arg, or the contents of the file set by the file flag
+func readInput(ctx *cli.Context) (string, error) {
+	if f := ctx.String("file"); len(f) > 0 {
+		b, err := ioutil.ReadFile(f)
+		return string(b), err
+	}
+	return ctx.Args().Get(0), nil
+}
+
+func setSchema(ctx *cli.Context) error {
+	schema, err := readInput(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(schema) == 0 {
+		fmt.Println("Required usage: micro config schema set [--path key] schema|--file schema.json")
+		os.Exit(1)
+	}
+
+	_, err = hpb.NewSchemaService("go.micro.config", client.New(ctx)).Set(context.TODO(), &hpb.SetSchemaRequest{
+		Namespace: Namespace,
+		Path:      ctx.String("path"),
+		Schema:    schema,
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func getSchema(ctx *cli.Context) error {
+	rsp, err := hpb.NewSchemaService("go.micro.config", client.New(ctx)).Get(context.TODO(), &hpb.GetSchemaRequest{
+		Namespace: Namespace,
+		Path:      ctx.String("path"),
+	})
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "not found") {
+			fmt.Println("not found")
+			os.Exit(1)
+		}
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(rsp.Schema)
+	return nil
+}
+
+func validateSchema(ctx *cli.Context) error {
+	data, err := readInput(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	rsp, err := hpb.NewSchemaService("go.micro.config", client.New(ctx)).Validate(context.TODO(), &hpb.ValidateRequest{
+		Namespace: Namespace,
+		Data:      data,
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if len(rsp.Errors) == 0 {
+		fmt.Println("valid")
+		return nil
+	}
+	for _, e := range rsp.Errors {
+		if len(e.Path) == 0 {
+			fmt.Println(e.Message)
+		} else {
+			fmt.Printf("%s: %s\n", e.Path, e.Message)
+		}
+	}
+	os.Exit(1)
+
+	return nil
+}
+
+func resolveConfig(ctx *cli.Context, key string) error {
+	rsp, err := hpb.NewLayersService("go.micro.config", client.New(ctx)).Resolve(context.TODO(), &hpb.ResolveRequest{
+		Namespace: Namespace,
+		Path:      key,
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if len(rsp.Values) == 0 {
+		fmt.Println("not found")
+		os.Exit(1)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PATH\tVALUE\tLAYER")
+	for _, v := range rsp.Values {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", v.Path, v.Value, v.Layer)
+	}
+	w.Flush()
+
+	return nil
+}
+
+func setLayers(ctx *cli.Context) error {
+	_, err := hpb.NewLayersService("go.micro.config", client.New(ctx)).Set(context.TODO(), &hpb.SetLayersRequest{
+		Namespace: Namespace,
+		Layers:    ctx.Args().Slice(),
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func getLayers(ctx *cli.Context) error {
+	rsp, err := hpb.NewLayersService("go.micro.config", client.New(ctx)).Get(context.TODO(), &hpb.GetLayersRequest{
+		Namespace: Namespace,
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	for _, layer := range rsp.Layers {
+		fmt.Println(layer)
+	}
+
+	return nil
+}
+
+func rotateKey(ctx *cli.Context) error {
+	rsp, err := hpb.NewSecretsService("go.micro.config", client.New(ctx)).Rotate(context.TODO(), &hpb.RotateKeyRequest{
+		Namespace: Namespace,
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Rotated to data key %d, re-encrypted %d secrets\n", rsp.Version, rsp.Reencrypted)
+	return nil
+}
+
 func Commands(options ...micro.Option) []*cli.Command {
 	command := &cli.Command{
 		Name:  "config",
@@ -185,18 +623,215 @@ func Commands(options ...micro.Option) []*cli.Command {
 		Subcommands: []*cli.Command{
 			{
 				Name:   "get",
-				Usage:  "Get a value; micro config get key",
+				Usage:  "Get a value; micro config get [--layer] key",
 				Action: getConfig,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "layer",
+						Usage: "Show the layer each value came from",
+					},
+				},
 			},
 			{
 				Name:   "set",
-				Usage:  "Set a key-val; micro config set key val",
+				Usage:  "Set a key-val; micro config set [--secret] [--layer name] key val",
 				Action: setConfig,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "layer",
+						Usage: "Set the value in a layer of the config, e.g. prod",
+					},
+					&cli.BoolFlag{
+						Name:  "secret",
+						Usage: "Encrypt the value, it's redacted for accounts which can't read secrets",
+					},
+				},
+			},
+			{
+				Name:   "rotate",
+				Usage:  "Rotate the key used to encrypt secrets and re-encrypt them; micro config rotate",
+				Action: rotateKey,
 			},
 			{
 				Name:   "del",
-				Usage:  "Delete a value; micro config del key",
+				Usage:  "Delete a value; micro config del [--layer name] key",
 				Action: delConfig,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "layer",
+						Usage: "Delete the value from a layer of the config, e.g. prod",
+					},
+				},
+			},
+			{
+				Name:  "layers",
+				Usage: "Manage the layers merged over the config when it's read",
+				Subcommands: []*cli.Command{
+					{
+						Name:   "set",
+						Usage:  "Set the layers in the order they're merged; micro config layers set prod region-eu",
+						Action: setLayers,
+					},
+					{
+						Name:   "get",
+						Usage:  "Get the layers in the order they're merged; micro config layers get",
+						Action: getLayers,
+					},
+				},
+			},
+			{
+				Name:   "import",
+				Usage:  "Import a json, yaml, toml or env file; micro config import -f file [--path key] [--replace]",
+				Action: importConfig,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "file",
+						Aliases: []string{"f"},
+						Usage:   "Set the file to import",
+					},
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Set the format of the file, the extension of the file is used if blank",
+					},
+					&cli.StringFlag{
+						Name:  "path",
+						Usage: "Import the file at the key, the whole config if blank",
+					},
+					&cli.StringFlag{
+						Name:  "layer",
+						Usage: "Import the file into a layer of the config, e.g. prod",
+					},
+					&cli.BoolFlag{
+						Name:  "replace",
+						Usage: "Replace the value at the key with the file rather than merging it, removing the keys which aren't in the file",
+					},
+				},
+			},
+			{
+				Name:   "export",
+				Usage:  "Export the config as json, yaml, toml or env; micro config export [--format yaml] [--path key]",
+				Action: exportConfig,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "format",
+						Usage: "Set the format to export; json, yaml, toml or env",
+						Value: format.JSON,
+					},
+					&cli.StringFlag{
+						Name:  "path",
+						Usage: "Export the value at the key, the whole config if blank",
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "Write the config to a file rather than stdout",
+					},
+				},
+			},
+			{
+				Name:   "list",
+				Usage:  "List the namespaces; micro config list [--prefix name] [--limit n] [--offset n]",
+				Action: listConfig,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "prefix",
+						Usage: "Only list the namespaces with the prefix",
+					},
+					&cli.IntFlag{
+						Name:  "limit",
+						Usage: "Set the number of namespaces to list",
+						Value: 100,
+					},
+					&cli.IntFlag{
+						Name:  "offset",
+						Usage: "Set the number of namespaces to skip",
+					},
+				},
+			},
+			{
+				Name:   "watch",
+				Usage:  "Watch the changes to the config; micro config watch [--path key] [--from rev]",
+				Action: watchConfig,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "path",
+						Usage: "Only watch the changes to the key",
+					},
+					&cli.IntFlag{
+						Name:  "from",
+						Usage: "Replay the changes after the revision",
+					},
+				},
+			},
+			{
+				Name:   "history",
+				Usage:  "List the revisions of the config; micro config history [--path key]",
+				Action: configHistory,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "path",
+						Usage: "Only list the revisions which changed the key",
+					},
+					&cli.IntFlag{
+						Name:  "limit",
+						Usage: "Set the number of revisions to list",
+					},
+				},
+			},
+			{
+				Name:   "diff",
+				Usage:  "Show the values changed between revisions; micro config diff rev1 [rev2]",
+				Action: configDiff,
+			},
+			{
+				Name:   "revert",
+				Usage:  "Revert the config to a revision; micro config revert rev",
+				Action: configRevert,
+			},
+			{
+				Name:  "schema",
+				Usage: "Manage the JSON Schemas config is validated against",
+				Subcommands: []*cli.Command{
+					{
+						Name:   "set",
+						Usage:  "Set the schema; micro config schema set [--path key] schema",
+						Action: setSchema,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "path",
+								Usage: "Set the key the schema applies to, the whole config if blank",
+							},
+							&cli.StringFlag{
+								Name:    "file",
+								Aliases: []string{"f"},
+								Usage:   "Read the schema from a file",
+							},
+						},
+					},
+					{
+						Name:   "get",
+						Usage:  "Get the schema; micro config schema get [--path key]",
+						Action: getSchema,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:  "path",
+								Usage: "Set the key the schema applies to, the whole config if blank",
+							},
+						},
+					},
+					{
+						Name:   "validate",
+						Usage:  "Validate the config, or a json document, against the schemas; micro config schema validate [data]",
+						Action: validateSchema,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:    "file",
+								Aliases: []string{"f"},
+								Usage:   "Read the json document to validate from a file",
+							},
+						},
+					},
+				},
 			},
 		},
 		Action: func(ctx *cli.Context) error {
@@ -217,6 +852,16 @@ func Commands(options ...micro.Option) []*cli.Command {
 				EnvVars: []string{"MICRO_CONFIG_WATCH_TOPIC"},
 				Usage:   "watch the change event.",
 			},
+			&cli.StringFlag{
+				Name:    "secret_key",
+				EnvVars: []string{"MICRO_CONFIG_SECRET_KEY"},
+				Usage:   "Set the key used to encrypt secrets (base64 encoded, 32 bytes). Required unless the store is local, one is generated then",
+			},
+			&cli.StringSliceFlag{
+				Name:    "secret_scopes",
+				EnvVars: []string{"MICRO_CONFIG_SECRET_SCOPES"},
+				Usage:   "Set the scopes of the accounts which can read secrets, defaults to admin and service",
+			},
 		},
 	}
 
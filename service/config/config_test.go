@@ -0,0 +1,138 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/client"
+	proto "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/store/memory"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/internal/secret"
+	"github.com/micro/micro/v2/service/config/format"
+	"github.com/micro/micro/v2/service/config/handler"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+// testConfig calls the config handler as the account of the context
+type testConfig struct {
+	proto.ConfigService
+	handler *handler.Config
+	ctx     context.Context
+}
+
+func (c *testConfig) Read(ctx context.Context, req *proto.ReadRequest, opts ...client.CallOption) (*proto.ReadResponse, error) {
+	rsp := &proto.ReadResponse{}
+	return rsp, c.handler.Read(c.ctx, req, rsp)
+}
+
+// testDocuments calls the documents handler as the account of the context
+type testDocuments struct {
+	handler *handler.Documents
+	ctx     context.Context
+}
+
+func (d *testDocuments) Import(ctx context.Context, req *hpb.ImportRequest, opts ...client.CallOption) (*hpb.ImportResponse, error) {
+	rsp := &hpb.ImportResponse{}
+	return rsp, d.handler.Import(d.ctx, req, rsp)
+}
+
+func TestImportExport(t *testing.T) {
+	key, err := secret.GenerateKey()
+	if err != nil {
+		t.Fatalf("Unexpected error generating key: %v", err)
+	}
+	h := &handler.Config{Store: memory.NewStore(), SecretKey: key}
+
+	admin := namespace.ContextWithNamespace(auth.ContextWithAccount(context.Background(), &auth.Account{ID: "admin", Scopes: []string{"admin"}}), "micro")
+	user := namespace.ContextWithNamespace(auth.ContextWithAccount(context.Background(), &auth.Account{ID: "user", Scopes: []string{"namespace.micro"}}), "micro")
+
+	err = h.Update(admin, &proto.UpdateRequest{Change: &proto.Change{
+		Namespace: "global",
+		ChangeSet: &proto.ChangeSet{Data: `{"db":{"host":"localhost","port":5432},"name":"app"}`, Format: "json"},
+	}}, &proto.UpdateResponse{})
+	if err != nil {
+		t.Fatalf("Unexpected error setting config: %v", err)
+	}
+	err = (&handler.Secrets{Config: h}).Set(admin, &hpb.SetSecretRequest{Namespace: "global", Path: "db.password", Value: "hunter2"}, &hpb.SetSecretResponse{})
+	if err != nil {
+		t.Fatalf("Unexpected error setting secret: %v", err)
+	}
+
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "db.yaml")
+	write := func(data []byte) {
+		if err := ioutil.WriteFile(file, data, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// expect reads the config as the admin and compares it to the json
+	expect := func(desc, data string) {
+		t.Helper()
+		b, err := exportData(&testConfig{handler: h, ctx: admin}, "global", "", format.JSON)
+		if err != nil {
+			t.Fatalf("Unexpected error exporting config %v: %v", desc, err)
+		}
+		var got, want interface{}
+		json.Unmarshal(b, &got)
+		json.Unmarshal([]byte(data), &want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected the config %v to be %v but got %s", desc, data, b)
+		}
+
+		// the secret stays encrypted and redacted for accounts which can't read it
+		recs, _ := h.Store.Read("micro:global")
+		if len(recs) == 0 || strings.Contains(string(recs[0].Value), "hunter2") || strings.Contains(string(recs[0].Value), "s3cret") {
+			t.Errorf("Expected the secret to be stored encrypted %v", desc)
+		}
+		b, _ = exportData(&testConfig{handler: h, ctx: user}, "global", "db.password", format.JSON)
+		if string(b) != `"`+handler.RedactedValue+`"` {
+			t.Errorf("Expected the secret to be redacted %v but got %s", desc, b)
+		}
+	}
+
+	// export the db by an account which can't read the secret and import it back at the path
+	b, err := exportData(&testConfig{handler: h, ctx: user}, "global", "db", format.YAML)
+	if err != nil {
+		t.Fatalf("Unexpected error exporting config: %v", err)
+	}
+	write(b)
+	docs := &testDocuments{handler: &handler.Documents{Config: h}, ctx: user}
+	if err := importFile(docs, "global", "db", file, "", false); err != nil {
+		t.Fatalf("Unexpected error importing config: %v", err)
+	}
+	expect("after the round trip", `{"db":{"host":"localhost","password":"hunter2","port":5432},"name":"app"}`)
+
+	// the values at the paths of secrets are encrypted again
+	write([]byte(`{"password": "s3cret"}`))
+	if err := importFile(docs, "global", "db", file, "", false); err != nil {
+		t.Fatalf("Unexpected error importing config: %v", err)
+	}
+	expect("after importing a secret", `{"db":{"host":"localhost","password":"s3cret","port":5432},"name":"app"}`)
+
+	// the keys which aren't in the file are removed when it replaces the value at the path
+	write([]byte(`{"host": "db.local", "password": "` + handler.RedactedValue + `"}`))
+	if err := importFile(docs, "global", "db", file, "", true); err != nil {
+		t.Fatalf("Unexpected error importing config: %v", err)
+	}
+	expect("after replacing the db", `{"db":{"host":"db.local","password":"s3cret"},"name":"app"}`)
+
+	// a redacted value which isn't a secret in the config can't be imported
+	write([]byte(`{"token": "` + handler.RedactedValue + `"}`))
+	if err := importFile(docs, "global", "api", file, "", false); err == nil {
+		t.Errorf("Expected a redacted value which isn't a secret not to be imported")
+	}
+	expect("after a failed import", `{"db":{"host":"db.local","password":"s3cret"},"name":"app"}`)
+}
@@ -0,0 +1,230 @@
+// Package format converts config documents between json, which the config service stores, and the
+// yaml, toml and .env formats config is imported from and exported to
+package format
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/micro/go-micro/v2/config/encoder"
+	jsonenc "github.com/micro/go-micro/v2/config/encoder/json"
+	"github.com/micro/go-micro/v2/config/encoder/toml"
+	"github.com/micro/go-micro/v2/config/encoder/yaml"
+)
+
+const (
+	JSON = "json"
+	YAML = "yaml"
+	TOML = "toml"
+	Env  = "env"
+
+	// EnvSplitter separates the keys of nested values in .env files, e.g. DB__HOST=localhost
+	EnvSplitter = "__"
+)
+
+var encoders = map[string]encoder.Encoder{
+	JSON: jsonenc.NewEncoder(),
+	YAML: yaml.NewEncoder(),
+	TOML: toml.NewEncoder(),
+}
+
+// FromFilename returns the format of a file from its extension
+func FromFilename(name string) (string, error) {
+	base := filepath.Base(name)
+	if base == ".env" || strings.HasPrefix(base, ".env.") {
+		return Env, nil
+	}
+
+	switch ext := strings.TrimPrefix(filepath.Ext(base), "."); ext {
+	case "json":
+		return JSON, nil
+	case "yaml", "yml":
+		return YAML, nil
+	case "toml":
+		return TOML, nil
+	case "env":
+		return Env, nil
+	default:
+		return "", fmt.Errorf("Unknown format of %v, expected a json, yaml, toml or env file", name)
+	}
+}
+
+// Decode a document in the format to json. The document must be an object so it can be mapped into a
+// namespace.
+func Decode(format string, data []byte) ([]byte, error) {
+	var doc map[string]interface{}
+
+	switch format {
+	case Env:
+		var err error
+		if doc, err = decodeEnv(data); err != nil {
+			return nil, err
+		}
+	default:
+		enc, ok := encoders[format]
+		if !ok {
+			return nil, fmt.Errorf("Unknown format %v", format)
+		}
+		if err := enc.Decode(data, &doc); err != nil {
+			return nil, err
+		}
+	}
+
+	if doc == nil {
+		doc = map[string]interface{}{}
+	}
+	return json.Marshal(doc)
+}
+
+// Encode a json document in the format
+func Encode(format string, data []byte) ([]byte, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	if format == Env {
+		return encodeEnv(doc)
+	}
+
+	enc, ok := encoders[format]
+	if !ok {
+		return nil, fmt.Errorf("Unknown format %v", format)
+	}
+	// toml distinguishes integers and floats where json doesn't
+	return enc.Encode(integers(doc))
+}
+
+// integers converts the whole numbers decoded from json to integers
+func integers(v interface{}) interface{} {
+	switch val := v.(type) {
+	case float64:
+		if val == math.Trunc(val) && math.Abs(val) < 1<<53 {
+			return int64(val)
+		}
+	case map[string]interface{}:
+		for k, child := range val {
+			val[k] = integers(child)
+		}
+	case []interface{}:
+		for i, child := range val {
+			val[i] = integers(child)
+		}
+	}
+	return v
+}
+
+// decodeEnv decodes the KEY=value lines of a .env file. Keys are split into nested values with the
+// EnvSplitter, values which are json (numbers, bools, arrays and objects) are decoded and the rest
+// are strings.
+func decodeEnv(data []byte) (map[string]interface{}, error) {
+	doc := map[string]interface{}{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 || len(strings.TrimSpace(parts[0])) == 0 {
+			return nil, fmt.Errorf("Invalid line %d, expected KEY=value", n)
+		}
+
+		value, err := envValue(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value on line %d: %v", n, err)
+		}
+
+		keys := strings.Split(strings.TrimSpace(parts[0]), EnvSplitter)
+		parent := doc
+		for _, k := range keys[:len(keys)-1] {
+			child, ok := parent[k].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				parent[k] = child
+			}
+			parent = child
+		}
+		parent[keys[len(keys)-1]] = value
+	}
+
+	return doc, scanner.Err()
+}
+
+// envValue decodes the value of a .env line
+func envValue(v string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(v, `"`):
+		var s string
+		err := json.Unmarshal([]byte(v), &s)
+		return s, err
+	case strings.HasPrefix(v, "'") && strings.HasSuffix(v, "'") && len(v) > 1:
+		return v[1 : len(v)-1], nil
+	}
+
+	var val interface{}
+	if err := json.Unmarshal([]byte(v), &val); err == nil {
+		return val, nil
+	}
+	return v, nil
+}
+
+// encodeEnv encodes the json document as KEY=value lines, sorted by key. Strings are quoted when
+// they would otherwise be decoded as another type.
+func encodeEnv(doc interface{}) ([]byte, error) {
+	obj, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("Only objects can be encoded as env")
+	}
+
+	lines := []string{}
+
+	var walk func(prefix string, v interface{}) error
+	walk = func(prefix string, v interface{}) error {
+		if m, ok := v.(map[string]interface{}); ok && len(m) > 0 {
+			for k, child := range m {
+				key := k
+				if len(prefix) > 0 {
+					key = prefix + EnvSplitter + k
+				}
+				if err := walk(key, child); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if s, ok := v.(string); ok {
+			if decoded, err := envValue(s); err == nil && decoded == s && s == strings.TrimSpace(s) && !strings.ContainsAny(s, "\n\r") {
+				lines = append(lines, prefix+"="+s)
+				return nil
+			}
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, prefix+"="+string(b))
+		return nil
+	}
+
+	if err := walk("", obj); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(lines)
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
@@ -0,0 +1,70 @@
+package format
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestFromFilename(t *testing.T) {
+	tests := map[string]string{
+		"config.yaml":     YAML,
+		"config.yml":      YAML,
+		"/etc/app.toml":   TOML,
+		"config.json":     JSON,
+		".env":            Env,
+		".env.production": Env,
+		"prod.env":        Env,
+	}
+	for name, format := range tests {
+		f, err := FromFilename(name)
+		if err != nil || f != format {
+			t.Errorf("Expected %v to be %v but got %v %v", name, format, f, err)
+		}
+	}
+	if _, err := FromFilename("config.ini"); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
+
+func TestEnv(t *testing.T) {
+	env := []byte(`# database
+DB__HOST=localhost
+DB__PORT=5432
+export DEBUG=true
+NAME="true"
+GREETING='hello world'
+TAGS=["a","b"]
+`)
+
+	data, err := Decode(Env, env)
+	if err != nil {
+		t.Fatalf("Unexpected error decoding env: %v", err)
+	}
+
+	var doc, expected map[string]interface{}
+	json.Unmarshal(data, &doc)
+	json.Unmarshal([]byte(`{"DB":{"HOST":"localhost","PORT":5432},"DEBUG":true,"NAME":"true","GREETING":"hello world","TAGS":["a","b"]}`), &expected)
+	if !reflect.DeepEqual(doc, expected) {
+		t.Errorf("Expected %v but got %v", expected, doc)
+	}
+
+	// encoding and decoding again should give the same document
+	encoded, err := Encode(Env, data)
+	if err != nil {
+		t.Fatalf("Unexpected error encoding env: %v", err)
+	}
+	data, err = Decode(Env, encoded)
+	if err != nil {
+		t.Fatalf("Unexpected error decoding env: %v", err)
+	}
+	doc = nil
+	json.Unmarshal(data, &doc)
+	if !reflect.DeepEqual(doc, expected) {
+		t.Errorf("Expected the env to round trip but got %v from %s", doc, encoded)
+	}
+
+	if _, err := Decode(Env, []byte("NOVALUE")); err == nil {
+		t.Errorf("Expected an error decoding a line without a value")
+	}
+}
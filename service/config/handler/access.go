@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/errors"
+)
+
+const (
+	// ResourceType is the type of the auth resources of config paths
+	ResourceType = "config"
+	// AccessRead is the name of the resources verified to read a config path
+	AccessRead = "read"
+	// AccessWrite is the name of the resources verified to write a config path
+	AccessWrite = "write"
+)
+
+// pathResource returns the auth resource of a path in a namespace. The endpoint is the namespace and
+// the path split by slashes so rules can match a subtree, e.g. the rule config:write:payments/* lets
+// an account write any path in the payments namespace. The endpoint of the namespace itself ends in a
+// slash so the rules of the subtree apply to it too. Layers share the rules of their namespace.
+func pathResource(access, namespace, path string) *auth.Resource {
+	endpoint := strings.SplitN(configNamespace(namespace), LayerSplitter, 2)[0] + "/"
+	if len(path) > 0 {
+		endpoint += strings.Replace(path, PathSplitter, "/", -1)
+	}
+	return &auth.Resource{Type: ResourceType, Name: access, Endpoint: endpoint}
+}
+
+// canAccess returns true if the account making the request can access the path in the namespace.
+// Access isn't verified if the config has no auth.
+func (c *Config) canAccess(ctx context.Context, access, namespace, path string) bool {
+	if c.Auth == nil {
+		return true
+	}
+	acc, _ := auth.AccountFromContext(ctx)
+	return c.Auth.Verify(acc, pathResource(access, namespace, path), auth.VerifyContext(ctx)) == nil
+}
+
+// checkRead returns a forbidden error if the caller can't read the path
+func (c *Config) checkRead(ctx context.Context, id, namespace, path string) error {
+	if !c.canAccess(ctx, AccessRead, namespace, path) {
+		return errors.Forbidden(id, "read access to %v denied", pathResource(AccessRead, namespace, path).Endpoint)
+	}
+	return nil
+}
+
+// checkWrite returns a forbidden error if the caller can't write the path, or any of the values which
+// differ between the old and new data
+func (c *Config) checkWrite(ctx context.Context, id, namespace, path, oldData, newData string) error {
+	if c.Auth == nil {
+		return nil
+	}
+
+	paths := []string{path}
+	changes, err := diff(oldData, newData)
+	if err != nil {
+		return errors.InternalServerError(id, "diff error: %v", err)
+	}
+	for _, ch := range changes {
+		paths = append(paths, ch.Path)
+	}
+
+	for _, p := range paths {
+		if !c.canAccess(ctx, AccessWrite, namespace, p) {
+			return errors.Forbidden(id, "write access to %v denied", pathResource(AccessWrite, namespace, p).Endpoint)
+		}
+	}
+	return nil
+}
+
+// checkReadAll returns a forbidden error if the caller can't read every value in the json data of
+// the namespace
+func (c *Config) checkReadAll(ctx context.Context, id, namespace, data string) error {
+	if err := c.checkRead(ctx, id, namespace, ""); err != nil {
+		return err
+	}
+	visible, err := c.readable(ctx, namespace, data)
+	if err != nil {
+		return errors.InternalServerError(id, "read error: %v", err)
+	}
+	if visible != data {
+		return errors.Forbidden(id, "read access to part of %v denied", pathResource(AccessRead, namespace, "").Endpoint)
+	}
+	return nil
+}
+
+// readable removes the values the caller can't read from the json data of the namespace
+func (c *Config) readable(ctx context.Context, namespace, data string) (string, error) {
+	if c.Auth == nil || len(data) == 0 {
+		return data, nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		return "", err
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		if !c.canAccess(ctx, AccessRead, namespace, "") {
+			return "null", nil
+		}
+		return data, nil
+	}
+
+	// values are removed if the caller can't read their path, objects left empty are removed too
+	var removed bool
+	var walk func(prefix string, m map[string]interface{})
+	walk = func(prefix string, m map[string]interface{}) {
+		for k, child := range m {
+			path := k
+			if len(prefix) > 0 {
+				path = prefix + PathSplitter + k
+			}
+
+			if cm, ok := child.(map[string]interface{}); ok && len(cm) > 0 {
+				walk(path, cm)
+				if len(cm) == 0 {
+					delete(m, k)
+				}
+				continue
+			}
+
+			if !c.canAccess(ctx, AccessRead, namespace, path) {
+				delete(m, k)
+				removed = true
+			}
+		}
+	}
+	walk("", obj)
+
+	if !removed {
+		return data, nil
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// visibleData returns the json data of the namespace as the caller should see it, with the secrets
+// decrypted or redacted and the values the caller can't read removed
+func (c *Config) visibleData(ctx context.Context, namespace, data string) (string, error) {
+	data, err := c.secretData(ctx, namespace, data)
+	if err != nil {
+		return "", err
+	}
+	return c.readable(ctx, namespace, data)
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/v2/auth"
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/store/memory"
+	"github.com/micro/micro/v2/internal/namespace"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+// testAuth verifies access against the rules with the rule verifier of go-micro
+type testAuth struct {
+	auth.Auth
+	rules []*auth.Rule
+}
+
+func (a *testAuth) Verify(acc *auth.Account, res *auth.Resource, opts ...auth.VerifyOption) error {
+	return auth.Verify(a.rules, acc, res)
+}
+
+// restrict returns the rules which only let the accounts with the scope access the endpoints below
+// the endpoint, e.g. config:write:global/payments/*
+func restrict(scope, access, endpoint string) []*auth.Rule {
+	res := &auth.Resource{Type: ResourceType, Name: access, Endpoint: endpoint + "/*"}
+	return []*auth.Rule{
+		{ID: scope + "-" + endpoint, Scope: scope, Resource: res, Access: auth.AccessGranted, Priority: 10},
+		{ID: "deny-" + endpoint, Scope: auth.ScopeAccount, Resource: res, Access: auth.AccessDenied, Priority: 5},
+	}
+}
+
+// newTestAuth returns an auth which lets any account access anything other than the endpoints
+// restricted to the payments team
+func newTestAuth() *testAuth {
+	rules := []*auth.Rule{{
+		ID:       "default",
+		Scope:    auth.ScopeAccount,
+		Resource: &auth.Resource{Type: "*", Name: "*", Endpoint: "*"},
+		Access:   auth.AccessGranted,
+	}}
+	rules = append(rules, restrict("payments", AccessWrite, "global/payments")...)
+	rules = append(rules, restrict("payments", AccessRead, "global/keys")...)
+	rules = append(rules, restrict("payments", "*", "payments")...)
+	return &testAuth{rules: rules}
+}
+
+func TestAccess(t *testing.T) {
+	c := &Config{
+		Store: memory.NewStore(),
+		Auth:  newTestAuth(),
+	}
+
+	team := namespace.ContextWithNamespace(auth.ContextWithAccount(context.Background(), &auth.Account{ID: "jane", Scopes: []string{"payments"}}), "micro")
+	other := namespace.ContextWithNamespace(auth.ContextWithAccount(context.Background(), &auth.Account{ID: "john", Scopes: []string{"orders"}}), "micro")
+
+	update := func(ctx context.Context, path, data string) error {
+		req := &pb.UpdateRequest{Change: &pb.Change{Namespace: "global", Path: path, ChangeSet: &pb.ChangeSet{Data: data, Format: "json"}}}
+		return c.Update(ctx, req, &pb.UpdateResponse{})
+	}
+
+	if err := update(team, "", `{"payments":{"currency":"gbp"},"keys":{"stripe":"sk"},"orders":{"limit":"10"}}`); err != nil {
+		t.Fatalf("Unexpected error updating config: %v", err)
+	}
+
+	// other teams can't write the payments config, either at the path or by writing the whole document
+	if err := update(other, "payments.currency", "usd"); err == nil {
+		t.Errorf("Expected an error writing a restricted path")
+	}
+	if err := update(other, "", `{"payments":{"currency":"usd"}}`); err == nil {
+		t.Errorf("Expected an error writing a restricted value")
+	}
+	if err := c.Delete(other, &pb.DeleteRequest{Change: &pb.Change{Namespace: "global"}}, &pb.DeleteResponse{}); err == nil {
+		t.Errorf("Expected an error deleting a namespace with restricted values")
+	}
+	if err := update(other, "orders.limit", "20"); err != nil {
+		t.Errorf("Unexpected error writing an unrestricted path: %v", err)
+	}
+
+	// values other teams can't read are removed
+	rsp := &pb.ReadResponse{}
+	if err := c.Read(other, &pb.ReadRequest{Namespace: "global"}, rsp); err != nil {
+		t.Fatalf("Unexpected error reading config: %v", err)
+	}
+	if rsp.Change.ChangeSet.Data != `{"orders":{"limit":"20"},"payments":{"currency":"gbp"}}` {
+		t.Errorf("Expected the restricted values to be removed but got %v", rsp.Change.ChangeSet.Data)
+	}
+	if err := c.Read(other, &pb.ReadRequest{Namespace: "global", Path: "keys.stripe"}, &pb.ReadResponse{}); err == nil {
+		t.Errorf("Expected an error reading a restricted path")
+	}
+
+	rsp = &pb.ReadResponse{}
+	if err := c.Read(team, &pb.ReadRequest{Namespace: "global", Path: "keys.stripe"}, rsp); err != nil {
+		t.Fatalf("Unexpected error reading config: %v", err)
+	}
+	if rsp.Change.ChangeSet.Data != `"sk"` {
+		t.Errorf("Expected the team to read the value but got %v", rsp.Change.ChangeSet.Data)
+	}
+
+	list := &pb.ListResponse{}
+	if err := c.List(other, &pb.ListRequest{}, list); err != nil {
+		t.Fatalf("Unexpected error listing config: %v", err)
+	}
+	if len(list.Values) != 1 || strings.Contains(list.Values[0].ChangeSet.Data, "stripe") {
+		t.Errorf("Expected the restricted values to be removed from the list but got %+v", list.Values)
+	}
+}
+
+func TestAccessSchemasAndLayers(t *testing.T) {
+	c := &Config{Store: memory.NewStore(), Auth: newTestAuth()}
+	schema := &Schema{Config: c}
+	layers := &Layers{Config: c}
+
+	team := namespace.ContextWithNamespace(auth.ContextWithAccount(context.Background(), &auth.Account{ID: "jane", Scopes: []string{"payments"}}), "micro")
+	other := namespace.ContextWithNamespace(auth.ContextWithAccount(context.Background(), &auth.Account{ID: "john", Scopes: []string{"orders"}}), "micro")
+
+	for _, ns := range []string{"global", "payments"} {
+		req := &pb.UpdateRequest{Change: &pb.Change{Namespace: ns, ChangeSet: &pb.ChangeSet{Data: `{"payments":{"currency":"gbp"},"keys":{"stripe":"sk"}}`, Format: "json"}}}
+		if err := c.Update(team, req, &pb.UpdateResponse{}); err != nil {
+			t.Fatalf("Unexpected error updating config: %v", err)
+		}
+	}
+
+	setSchema := func(ctx context.Context, ns, path string) error {
+		req := &hpb.SetSchemaRequest{Namespace: ns, Path: path, Schema: `{"type":"object"}`}
+		return schema.Set(ctx, req, &hpb.SetSchemaResponse{})
+	}
+
+	// a schema can't be attached to the paths the caller can't write, it would reject the writes of others
+	if err := setSchema(other, "global", "payments"); err == nil {
+		t.Errorf("Expected an error setting a schema on a restricted path")
+	}
+	if err := setSchema(other, "payments", ""); err == nil {
+		t.Errorf("Expected an error setting a schema on a restricted namespace")
+	}
+	if err := setSchema(other, "global", "orders"); err != nil {
+		t.Errorf("Unexpected error setting a schema on an unrestricted path: %v", err)
+	}
+	if err := setSchema(team, "payments", ""); err != nil {
+		t.Errorf("Unexpected error setting a schema on the team's namespace: %v", err)
+	}
+
+	// the schemas of the paths the caller can't read aren't returned
+	if err := setSchema(team, "global", "keys"); err != nil {
+		t.Fatalf("Unexpected error setting schema: %v", err)
+	}
+	if err := schema.Get(other, &hpb.GetSchemaRequest{Namespace: "global", Path: "keys"}, &hpb.GetSchemaResponse{}); err == nil {
+		t.Errorf("Expected an error getting the schema of a restricted path")
+	}
+	if err := schema.Get(team, &hpb.GetSchemaRequest{Namespace: "global", Path: "keys"}, &hpb.GetSchemaResponse{}); err != nil {
+		t.Errorf("Unexpected error getting schema: %v", err)
+	}
+
+	// the current config is only validated if the caller can read all of it
+	if err := schema.Validate(other, &hpb.ValidateRequest{Namespace: "global"}, &hpb.ValidateResponse{}); err == nil {
+		t.Errorf("Expected an error validating config with restricted values")
+	}
+	if err := schema.Validate(other, &hpb.ValidateRequest{Namespace: "payments", Data: `{}`}, &hpb.ValidateResponse{}); err == nil {
+		t.Errorf("Expected an error validating against the schemas of a restricted namespace")
+	}
+	if err := schema.Validate(other, &hpb.ValidateRequest{Namespace: "global", Data: `{"orders":{}}`}, &hpb.ValidateResponse{}); err != nil {
+		t.Errorf("Unexpected error validating data: %v", err)
+	}
+	if err := schema.Validate(team, &hpb.ValidateRequest{Namespace: "global"}, &hpb.ValidateResponse{}); err != nil {
+		t.Errorf("Unexpected error validating config: %v", err)
+	}
+
+	// the layers of a namespace can only be managed by the accounts which can access it
+	if err := layers.Set(other, &hpb.SetLayersRequest{Namespace: "payments", Layers: []string{"prod"}}, &hpb.SetLayersResponse{}); err == nil {
+		t.Errorf("Expected an error setting the layers of a restricted namespace")
+	}
+	if err := layers.Get(other, &hpb.GetLayersRequest{Namespace: "payments"}, &hpb.GetLayersResponse{}); err == nil {
+		t.Errorf("Expected an error getting the layers of a restricted namespace")
+	}
+	if err := layers.Set(team, &hpb.SetLayersRequest{Namespace: "payments", Layers: []string{"prod"}}, &hpb.SetLayersResponse{}); err != nil {
+		t.Errorf("Unexpected error setting layers: %v", err)
+	}
+	rsp := &hpb.GetLayersResponse{}
+	if err := layers.Get(team, &hpb.GetLayersRequest{Namespace: "payments"}, rsp); err != nil || len(rsp.Layers) != 1 {
+		t.Errorf("Expected the team to get the layers but got %v %v", rsp.Layers, err)
+	}
+}
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+// MaxReplay is the most revisions replayed to a watcher, watchers further behind are sent a resync
+var MaxReplay int64 = 100
+
+// Changes streams the revisions of namespaces to watchers
+type Changes struct {
+	Config *Config
+}
+
+// changeWatcher is the state of a watcher of the revisions of a namespace
+type changeWatcher struct {
+	config    *Config
+	namespace string
+	path      string
+	// revision is the last revision sent to the watcher
+	revision int64
+	// data is the last data sent to the watcher, with the layers of the namespace merged over it
+	data string
+	// layered is true if the namespace had layers when the data was last compared
+	layered bool
+	send    func(*hpb.ChangeEvent) error
+}
+
+// eventData returns the data of the event as the watcher should see it, with the current layers of
+// the namespace merged over it
+func (w *changeWatcher) eventData(ctx context.Context, data string) (string, error) {
+	data, err := w.config.layeredData(ctx, w.namespace, data)
+	if err != nil {
+		return "", err
+	}
+	if len(w.path) == 0 {
+		return data, nil
+	}
+	return subDocument(data, w.path)
+}
+
+// revisionData returns the data of the last revision sent to the watcher as it should see it now
+func (w *changeWatcher) revisionData(ctx context.Context) (string, error) {
+	rev, err := w.config.readRevision(w.namespace, w.revision)
+	if err == store.ErrNotFound {
+		return w.eventData(ctx, "")
+	} else if err != nil {
+		return "", err
+	}
+	return w.eventData(ctx, rev.Data)
+}
+
+// resync sends the current config of the namespace to the watcher
+func (w *changeWatcher) resync(ctx context.Context, latest int64) error {
+	data, err := w.config.readData(w.namespace)
+	if err != nil {
+		return err
+	}
+	if data, err = w.eventData(ctx, data); err != nil {
+		return err
+	}
+
+	w.revision = latest
+	w.data = data
+
+	return w.send(&hpb.ChangeEvent{
+		Namespace: configNamespace(w.namespace),
+		Revision:  latest,
+		Data:      data,
+		Timestamp: time.Now().Unix(),
+		Resync:    true,
+	})
+}
+
+// catchUp sends the watcher the revisions after the last one it was sent, then any change to the
+// layers of the namespace. The watcher is resynced if it's too far behind or the revisions are no
+// longer in the history.
+func (w *changeWatcher) catchUp(ctx context.Context) error {
+	latest, err := w.config.latestRevision(w.namespace)
+	if err != nil {
+		return err
+	}
+	if latest < w.revision || latest-w.revision > MaxReplay {
+		return w.resync(ctx, latest)
+	}
+
+	for id := w.revision + 1; id <= latest; id++ {
+		rev, err := w.config.readRevision(w.namespace, id)
+		if err == store.ErrNotFound {
+			return w.resync(ctx, latest)
+		} else if err != nil {
+			return err
+		}
+
+		data, err := w.eventData(ctx, rev.Data)
+		if err != nil {
+			return err
+		}
+		w.revision = id
+
+		// watchers of a path are only sent the revisions which changed the value at the path
+		if len(w.path) > 0 && data == w.data {
+			continue
+		}
+		w.data = data
+
+		if err := w.send(&hpb.ChangeEvent{
+			Namespace: rev.Namespace,
+			Revision:  rev.Id,
+			Action:    rev.Action,
+			Data:      data,
+			Timestamp: rev.Timestamp,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return w.layerChange(ctx)
+}
+
+// layerChange sends the watcher the data of the last revision sent if a change to the layers of the
+// namespace, which aren't revisions of the namespace, changed it since it was last sent
+func (w *changeWatcher) layerChange(ctx context.Context) error {
+	order, err := w.config.layerOrder(w.namespace)
+	if err != nil {
+		return err
+	}
+	layered := len(order) > 0
+	if !layered && !w.layered {
+		return nil
+	}
+	w.layered = layered
+
+	data, err := w.revisionData(ctx)
+	if err != nil {
+		return err
+	}
+	if data == w.data {
+		return nil
+	}
+	w.data = data
+
+	return w.send(&hpb.ChangeEvent{
+		Namespace: configNamespace(w.namespace),
+		Revision:  w.revision,
+		Action:    actionLayer,
+		Data:      data,
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// Watch streams the revisions of a namespace, replaying the revisions after the one provided
+func (c *Changes) Watch(ctx context.Context, req *hpb.WatchChangesRequest, stream hpb.Changes_WatchStream) error {
+	if len(req.Namespace) == 0 || !validNamespace(req.Namespace) {
+		return errors.BadRequest("go.micro.config.Changes.Watch", "invalid id")
+	}
+	if req.FromRevision < 0 {
+		return errors.BadRequest("go.micro.config.Changes.Watch", "invalid revision")
+	}
+
+	namespace := setNamespace(ctx, req.Namespace)
+
+	// the path must be readable, values below it the caller can't read are removed
+	if len(req.Path) > 0 {
+		if err := c.Config.checkRead(ctx, "go.micro.config.Changes.Watch", namespace, req.Path); err != nil {
+			return err
+		}
+	}
+
+	// the watcher is registered before the revisions are read so no revisions are missed, the
+	// published changes only wake the watcher and the revisions are read from the history
+	watch, err := Watch(namespace)
+	if err != nil {
+		return errors.BadRequest("go.micro.config.Changes.Watch", "watch error: %v", err)
+	}
+	defer watch.Stop()
+
+	go func() {
+		<-ctx.Done()
+		watch.Stop()
+		stream.Close()
+	}()
+
+	w := &changeWatcher{
+		config:    c.Config,
+		namespace: namespace,
+		path:      req.Path,
+		revision:  req.FromRevision,
+		send:      stream.Send,
+	}
+
+	if w.revision == 0 {
+		if w.revision, err = c.Config.latestRevision(namespace); err != nil {
+			return errors.InternalServerError("go.micro.config.Changes.Watch", "read revision error: %v", err)
+		}
+	}
+
+	// the data of the revision is compared to the data of the revisions and layer changes which follow
+	if w.data, err = w.revisionData(ctx); err != nil {
+		return errors.InternalServerError("go.micro.config.Changes.Watch", "read error: %v", err)
+	}
+
+	for {
+		if err := w.catchUp(ctx); err != nil {
+			return errors.InternalServerError("go.micro.config.Changes.Watch", "send error: %v", err)
+		}
+		if _, err := watch.Next(); err != nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/micro/go-micro/v2/auth"
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/store/memory"
+	"github.com/micro/micro/v2/internal/namespace"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+func TestChangesCatchUp(t *testing.T) {
+	c := &Config{Store: memory.NewStore()}
+	ctx := namespace.ContextWithNamespace(auth.ContextWithAccount(context.Background(), &auth.Account{ID: "admin", Scopes: []string{"admin"}}), "micro")
+
+	update := func(path, data string) {
+		req := &pb.UpdateRequest{Change: &pb.Change{Namespace: "global", Path: path, ChangeSet: &pb.ChangeSet{Data: data, Format: "json"}}}
+		if err := c.Update(ctx, req, &pb.UpdateResponse{}); err != nil {
+			t.Fatalf("Unexpected error updating config: %v", err)
+		}
+	}
+	update("db.host", "localhost")
+	update("debug", "true")
+	update("db.host", "10.0.0.1")
+
+	var events []*hpb.ChangeEvent
+	w := &changeWatcher{
+		config:    c,
+		namespace: "micro:global",
+		revision:  1,
+		send: func(ev *hpb.ChangeEvent) error {
+			events = append(events, ev)
+			return nil
+		},
+	}
+
+	// the revisions after the one the watcher resumed from are replayed in order
+	if err := w.catchUp(ctx); err != nil {
+		t.Fatalf("Unexpected error catching up: %v", err)
+	}
+	if len(events) != 2 || events[0].Revision != 2 || events[1].Revision != 3 || events[0].Resync {
+		t.Fatalf("Expected revisions 2 and 3 to be replayed but got %+v", events)
+	}
+	if events[1].Data != `{"db":{"host":"10.0.0.1"},"debug":"true"}` {
+		t.Errorf("Expected the data after the revision but got %v", events[1].Data)
+	}
+
+	// watchers of a path are only sent the revisions which changed it
+	events = nil
+	w = &changeWatcher{config: c, namespace: "micro:global", path: "db.host", revision: 1, data: `"localhost"`, send: w.send}
+	if err := w.catchUp(ctx); err != nil {
+		t.Fatalf("Unexpected error catching up: %v", err)
+	}
+	if len(events) != 1 || events[0].Revision != 3 || events[0].Data != `"10.0.0.1"` {
+		t.Errorf("Expected only revision 3 to be sent but got %+v", events)
+	}
+
+	// watchers too far behind are resynced with the current config
+	defer func(max int64) { MaxReplay = max }(MaxReplay)
+	MaxReplay = 1
+	events = nil
+	w = &changeWatcher{config: c, namespace: "micro:global", revision: 1, send: w.send}
+	if err := w.catchUp(ctx); err != nil {
+		t.Fatalf("Unexpected error catching up: %v", err)
+	}
+	if len(events) != 1 || !events[0].Resync || events[0].Revision != 3 {
+		t.Errorf("Expected a resync at revision 3 but got %+v", events)
+	}
+}
+
+func TestWatcherSend(t *testing.T) {
+	w, _ := Watch("micro:test")
+	defer w.Stop()
+
+	// a watcher which hasn't read its changes gets the latest one rather than blocking the publisher
+	for i := 0; i < 3; i++ {
+		w.send(&pb.WatchResponse{Namespace: "micro:test", ChangeSet: &pb.ChangeSet{Data: fmt.Sprint(i)}})
+	}
+
+	ch, err := w.Next()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ch.ChangeSet.Data != "2" {
+		t.Errorf("Expected the latest change but got %v", ch.ChangeSet.Data)
+	}
+}
+
+func TestChangesLayers(t *testing.T) {
+	c := &Config{Store: memory.NewStore()}
+	ctx := namespace.ContextWithNamespace(auth.ContextWithAccount(context.Background(), &auth.Account{ID: "admin", Scopes: []string{"admin"}}), "micro")
+
+	update := func(ns, path, data string) {
+		req := &pb.UpdateRequest{Change: &pb.Change{Namespace: ns, Path: path, ChangeSet: &pb.ChangeSet{Data: data, Format: "json"}}}
+		if err := c.Update(ctx, req, &pb.UpdateResponse{}); err != nil {
+			t.Fatalf("Unexpected error updating config: %v", err)
+		}
+	}
+	update("global", "db.host", "localhost")
+
+	var events []*hpb.ChangeEvent
+	w := &changeWatcher{
+		config:    c,
+		namespace: "micro:global",
+		path:      "db.host",
+		revision:  1,
+		data:      `"localhost"`,
+		send: func(ev *hpb.ChangeEvent) error {
+			events = append(events, ev)
+			return nil
+		},
+	}
+
+	// writing a layer which isn't merged over the namespace doesn't change it
+	update("global@prod", "db.host", "10.0.0.1")
+	if err := w.catchUp(ctx); err != nil {
+		t.Fatalf("Unexpected error catching up: %v", err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("Expected no events but got %+v", events)
+	}
+
+	// merging the layer over the namespace changes the value at the path
+	l := &Layers{Config: c}
+	if err := l.Set(ctx, &hpb.SetLayersRequest{Namespace: "global", Layers: []string{"prod"}}, &hpb.SetLayersResponse{}); err != nil {
+		t.Fatalf("Unexpected error setting layers: %v", err)
+	}
+	if err := w.catchUp(ctx); err != nil {
+		t.Fatalf("Unexpected error catching up: %v", err)
+	}
+	if len(events) != 1 || events[0].Action != actionLayer || events[0].Revision != 1 || events[0].Data != `"10.0.0.1"` {
+		t.Fatalf("Expected a layer event with the merged value but got %+v", events)
+	}
+
+	// writes to the layer change the merged value
+	update("global@prod", "db.host", "10.0.0.2")
+	if err := w.catchUp(ctx); err != nil {
+		t.Fatalf("Unexpected error catching up: %v", err)
+	}
+	if len(events) != 2 || events[1].Action != actionLayer || events[1].Data != `"10.0.0.2"` {
+		t.Fatalf("Expected a layer event with the new value but got %+v", events)
+	}
+
+	// revisions of the namespace which are overridden by the layer don't change the merged value
+	update("global", "db.host", "127.0.0.1")
+	if err := w.catchUp(ctx); err != nil {
+		t.Fatalf("Unexpected error catching up: %v", err)
+	}
+	if len(events) != 2 || w.revision != 2 {
+		t.Fatalf("Expected no new events at revision 2 but got %+v", events)
+	}
+
+	// removing the layers reveals the value of the namespace
+	if err := l.Set(ctx, &hpb.SetLayersRequest{Namespace: "global"}, &hpb.SetLayersResponse{}); err != nil {
+		t.Fatalf("Unexpected error setting layers: %v", err)
+	}
+	if err := w.catchUp(ctx); err != nil {
+		t.Fatalf("Unexpected error catching up: %v", err)
+	}
+	if len(events) != 3 || events[2].Action != actionLayer || events[2].Revision != 2 || events[2].Data != `"127.0.0.1"` {
+		t.Fatalf("Expected a layer event with the value of the namespace but got %+v", events)
+	}
+}
@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+// actionImport is recorded in the history for imported documents
+const actionImport = "import"
+
+// Documents imports json documents into namespaces
+type Documents struct {
+	Config *Config
+}
+
+// Import the json document into the namespace at the path. The document is merged into the value
+// at the path unless it replaces it. Values at the paths of secrets are encrypted again and
+// redacted secrets, e.g. from an export by an account which can't read them, keep their value.
+func (d *Documents) Import(ctx context.Context, req *hpb.ImportRequest, rsp *hpb.ImportResponse) error {
+	if len(req.Namespace) == 0 || !validNamespace(req.Namespace) {
+		return errors.BadRequest("go.micro.config.Documents.Import", "invalid id")
+	}
+
+	var doc interface{}
+	if err := json.Unmarshal([]byte(req.Data), &doc); err != nil {
+		return errors.BadRequest("go.micro.config.Documents.Import", "invalid json: %v", err)
+	}
+	if _, ok := doc.(map[string]interface{}); !ok && len(req.Path) == 0 {
+		return errors.BadRequest("go.micro.config.Documents.Import", "the document must be an object to import it as the config")
+	}
+
+	namespace := setNamespace(ctx, req.Namespace)
+
+	oldData, err := d.Config.readData(namespace)
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.Documents.Import", "read old value error: %v", err)
+	}
+	config := make(map[string]interface{})
+	if len(oldData) > 0 {
+		if err := json.Unmarshal([]byte(oldData), &config); err != nil {
+			return errors.InternalServerError("go.micro.config.Documents.Import", "unmarshal value error: %v", err)
+		}
+	}
+
+	var parts []string
+	if len(req.Path) > 0 {
+		parts = strings.Split(req.Path, PathSplitter)
+	}
+	old := lookup(config, parts)
+
+	s := &importSecrets{config: d.Config, namespace: namespace}
+	if doc, err = s.value(req.Path, old, doc); err != nil {
+		if _, ok := err.(*errors.Error); ok {
+			return err
+		}
+		return errors.InternalServerError("go.micro.config.Documents.Import", "encrypt error: %v", err)
+	}
+	if !req.Replace {
+		doc = mergeValue(old, doc)
+	}
+
+	var data interface{} = doc
+	if len(parts) > 0 {
+		setValue(config, parts, doc)
+		data = config
+	}
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.Documents.Import", "marshal error: %v", err)
+	}
+	newData := string(bytes)
+
+	// nothing is recorded if the document is already in the config
+	if len(oldData) > 0 {
+		changes, err := diff(oldData, newData)
+		if err != nil {
+			return errors.InternalServerError("go.micro.config.Documents.Import", "diff error: %v", err)
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+	}
+
+	if err := d.Config.checkWrite(ctx, "go.micro.config.Documents.Import", namespace, req.Path, oldData, newData); err != nil {
+		return err
+	}
+	if err := d.Config.checkSchema("go.micro.config.Documents.Import", namespace, newData); err != nil {
+		return err
+	}
+
+	change := &pb.Change{
+		Namespace: req.Namespace,
+		Path:      req.Path,
+		ChangeSet: &pb.ChangeSet{
+			Data:      newData,
+			Format:    "json",
+			Source:    "import",
+			Timestamp: time.Now().Unix(),
+		},
+	}
+	record := &store.Record{Key: namespace}
+	if record.Value, err = json.Marshal(change); err != nil {
+		return errors.InternalServerError("go.micro.config.Documents.Import", "marshal error: %v", err)
+	}
+	if err := d.Config.Store.Write(record); err != nil {
+		return errors.InternalServerError("go.micro.config.Documents.Import", "update into db error: %v", err)
+	}
+
+	rev, err := d.Config.recordRevision(ctx, namespace, req.Path, actionImport, oldData, newData)
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.Documents.Import", "record revision error: %v", err)
+	}
+	rsp.Revision = rev.Id
+
+	d.Config.notify(ctx, namespace, change.ChangeSet)
+
+	return nil
+}
+
+// importSecrets keeps the secrets of a namespace encrypted when a document is imported into it
+type importSecrets struct {
+	config    *Config
+	namespace string
+	keys      *dataKeys
+}
+
+// value returns the value to import at the path. If the old value is a secret the new value is
+// encrypted, unless it's redacted or the secret already has the value, then the old value is kept.
+func (s *importSecrets) value(path string, old, v interface{}) (interface{}, error) {
+	if enc, ok := old.(string); ok && strings.HasPrefix(enc, SecretPrefix) {
+		if v == RedactedValue {
+			return old, nil
+		}
+		plaintext, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		if s.keys == nil {
+			if s.keys, err = s.config.readDataKeys(s.namespace); err != nil {
+				return nil, err
+			}
+		}
+		if current, err := s.config.decryptValue(s.keys, enc); err == nil && equalJSON(current, plaintext) {
+			return old, nil
+		}
+		return s.config.encryptValue(s.namespace, plaintext)
+	}
+
+	switch val := v.(type) {
+	case string:
+		if val == RedactedValue {
+			return nil, errors.BadRequest("go.micro.config.Documents.Import", "%v is a redacted secret which isn't in the config", path)
+		}
+	case map[string]interface{}:
+		oldMap, _ := old.(map[string]interface{})
+		for k, child := range val {
+			p := k
+			if len(path) > 0 {
+				p = path + PathSplitter + k
+			}
+			var err error
+			if val[k], err = s.value(p, oldMap[k], child); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return v, nil
+}
+
+// equalJSON returns true if the json values are equal
+func equalJSON(a, b []byte) bool {
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// lookup returns the value at the path, nil if there's none
+func lookup(v interface{}, path []string) interface{} {
+	for _, p := range path {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[p]
+	}
+	return v
+}
+
+// setValue sets the value at the path, replacing any values on the way which aren't objects
+func setValue(m map[string]interface{}, path []string, v interface{}) {
+	for _, p := range path[:len(path)-1] {
+		child, ok := m[p].(map[string]interface{})
+		if !ok {
+			child = make(map[string]interface{})
+			m[p] = child
+		}
+		m = child
+	}
+	m[path[len(path)-1]] = v
+}
+
+// mergeValue deep merges the src value into the dst value, values which aren't objects are replaced
+func mergeValue(dst, src interface{}) interface{} {
+	dm, ok := dst.(map[string]interface{})
+	if !ok {
+		return src
+	}
+	sm, ok := src.(map[string]interface{})
+	if !ok {
+		return src
+	}
+	for k, v := range sm {
+		dm[k] = mergeValue(dm[k], v)
+	}
+	return dm
+}
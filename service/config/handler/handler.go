@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/micro/go-micro/v2/auth"
 	"github.com/micro/go-micro/v2/client"
 	cr "github.com/micro/go-micro/v2/config/reader"
 	jr "github.com/micro/go-micro/v2/config/reader/json"
@@ -29,6 +30,13 @@ var (
 
 type Config struct {
 	Store store.Store
+	// SecretKey encrypts the data keys used to encrypt secrets, secrets are disabled if not set
+	SecretKey []byte
+	// SecretScopes are the scopes of the accounts which can read secrets, defaults to
+	// DefaultSecretScopes
+	SecretScopes []string
+	// Auth verifies the access of callers to config paths, access isn't verified if not set
+	Auth auth.Auth
 }
 
 // setNamespace figures out what the namespace should be
@@ -48,18 +56,24 @@ func (c *Config) Read(ctx context.Context, req *pb.ReadRequest, rsp *pb.ReadResp
 
 	namespace := setNamespace(ctx, req.Namespace)
 
-	ch, err := c.Store.Read(namespace)
+	// the path must be readable, values below it the caller can't read are removed
+	if len(req.Path) > 0 {
+		if err := c.checkRead(ctx, "go.micro.config.Read", namespace, req.Path); err != nil {
+			return err
+		}
+	}
+
+	// the layers of the namespace are merged over it and the secrets are decrypted if the caller is
+	// allowed to read them, otherwise they're redacted
+	ch, err := c.readChange(ctx, namespace)
 	if err == store.ErrNotFound {
 		return errors.NotFound("go.micro.config.Read", "Not found")
 	} else if err != nil {
 		return errors.BadRequest("go.micro.config.Read", "read error: %v: %v", err, req.Namespace)
 	}
-
-	rsp.Change = new(pb.Change)
-
-	// Unmarshal value
-	if err = json.Unmarshal(ch[0].Value, rsp.Change); err != nil {
-		return errors.BadRequest("go.micro.config.Read", "unmarshal value error: %v", err)
+	rsp.Change = ch
+	if rsp.Change.ChangeSet == nil {
+		return nil
 	}
 
 	// if dont need path, we return all of the data
@@ -95,7 +109,7 @@ func (c *Config) Create(ctx context.Context, req *pb.CreateRequest, rsp *pb.Crea
 		return errors.BadRequest("go.micro.config.Create", "invalid change")
 	}
 
-	if len(req.Change.Namespace) == 0 {
+	if len(req.Change.Namespace) == 0 || !validNamespace(req.Change.Namespace) {
 		return errors.BadRequest("go.micro.config.Create", "invalid id")
 	}
 
@@ -119,11 +133,25 @@ func (c *Config) Create(ctx context.Context, req *pb.CreateRequest, rsp *pb.Crea
 
 	namespace := setNamespace(ctx, req.Change.Namespace)
 
+	// the existing data is recorded in the history
+	oldData, err := c.readData(namespace)
+	if err != nil {
+		return errors.BadRequest("go.micro.config.Create", "read old value error: %v", err)
+	}
+
+	if err := c.checkWrite(ctx, "go.micro.config.Create", namespace, "", oldData, req.Change.ChangeSet.Data); err != nil {
+		return err
+	}
+
+	// the config must be valid against the schemas of the namespace
+	if err := c.checkSchema("go.micro.config.Create", namespace, req.Change.ChangeSet.Data); err != nil {
+		return err
+	}
+
 	record := &store.Record{
 		Key: namespace,
 	}
 
-	var err error
 	record.Value, err = json.Marshal(req.Change)
 	if err != nil {
 		return errors.BadRequest("go.micro.config.Create", "marshal error: %v", err)
@@ -133,7 +161,11 @@ func (c *Config) Create(ctx context.Context, req *pb.CreateRequest, rsp *pb.Crea
 		return errors.BadRequest("go.micro.config.Create", "create new into db error: %v", err)
 	}
 
-	_ = publish(ctx, &pb.WatchResponse{Namespace: namespace, ChangeSet: req.Change.ChangeSet})
+	if _, err := c.recordRevision(ctx, namespace, "", actionCreate, oldData, req.Change.ChangeSet.Data); err != nil {
+		return errors.InternalServerError("go.micro.config.Create", "record revision error: %v", err)
+	}
+
+	c.notify(ctx, namespace, req.Change.ChangeSet)
 
 	return nil
 }
@@ -143,7 +175,7 @@ func (c *Config) Update(ctx context.Context, req *pb.UpdateRequest, rsp *pb.Upda
 		return errors.BadRequest("go.micro.config.Update", "invalid change")
 	}
 
-	if len(req.Change.Namespace) == 0 {
+	if len(req.Change.Namespace) == 0 || !validNamespace(req.Change.Namespace) {
 		return errors.BadRequest("go.micro.config.Update", "invalid id")
 	}
 
@@ -220,6 +252,19 @@ func (c *Config) Update(ctx context.Context, req *pb.UpdateRequest, rsp *pb.Upda
 		}
 	}
 
+	// the merged config must be valid against the schemas of the namespace, not just the change
+	if err := c.checkSchema("go.micro.config.Update", namespace, string(newChange.Data)); err != nil {
+		return err
+	}
+
+	var oldData string
+	if oldCh.ChangeSet != nil {
+		oldData = oldCh.ChangeSet.Data
+	}
+	if err := c.checkWrite(ctx, "go.micro.config.Update", namespace, req.Change.Path, oldData, string(newChange.Data)); err != nil {
+		return err
+	}
+
 	// update change set
 	req.Change.ChangeSet = &pb.ChangeSet{
 		Timestamp: newChange.Timestamp.Unix(),
@@ -238,7 +283,11 @@ func (c *Config) Update(ctx context.Context, req *pb.UpdateRequest, rsp *pb.Upda
 		return errors.BadRequest("go.micro.config.Update", "update into db error: %v", err)
 	}
 
-	_ = publish(ctx, &pb.WatchResponse{Namespace: namespace, ChangeSet: req.Change.ChangeSet})
+	if _, err := c.recordRevision(ctx, namespace, req.Change.Path, actionUpdate, oldData, req.Change.ChangeSet.Data); err != nil {
+		return errors.InternalServerError("go.micro.config.Update", "record revision error: %v", err)
+	}
+
+	c.notify(ctx, namespace, req.Change.ChangeSet)
 
 	return nil
 }
@@ -248,7 +297,7 @@ func (c *Config) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.Dele
 		return errors.BadRequest("go.micro.srv.Delete", "invalid change")
 	}
 
-	if len(req.Change.Namespace) == 0 {
+	if len(req.Change.Namespace) == 0 || !validNamespace(req.Change.Namespace) {
 		return errors.BadRequest("go.micro.srv.Delete", "invalid id")
 	}
 
@@ -262,9 +311,19 @@ func (c *Config) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.Dele
 
 	// We're going to delete the record as we have no path and no data
 	if len(req.Change.Path) == 0 {
+		oldData, err := c.readData(namespace)
+		if err != nil {
+			return errors.BadRequest("go.micro.srv.Delete", "read old value error: %v", err)
+		}
+		if err := c.checkWrite(ctx, "go.micro.srv.Delete", namespace, "", oldData, ""); err != nil {
+			return err
+		}
 		if err := c.Store.Delete(namespace); err != nil {
 			return errors.BadRequest("go.micro.srv.Delete", "delete from db error: %v", err)
 		}
+		if _, err := c.recordRevision(ctx, namespace, "", actionDelete, oldData, ""); err != nil {
+			return errors.InternalServerError("go.micro.srv.Delete", "record revision error: %v", err)
+		}
 		return nil
 	}
 
@@ -285,6 +344,9 @@ func (c *Config) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.Dele
 		return errors.BadRequest("go.micro.config.Read", "unmarshal value error: %v", err)
 	}
 
+	// the existing data is recorded in the history
+	oldData := ch.ChangeSet.Data
+
 	// Get the current config as values
 	values, err := values(&source.ChangeSet{
 		Timestamp: time.Unix(ch.ChangeSet.Timestamp, 0),
@@ -306,6 +368,15 @@ func (c *Config) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.Dele
 		return errors.BadRequest("go.micro.srv.Delete", "Create a change record from the values error: %v", err)
 	}
 
+	// removing the value mustn't leave the config invalid, e.g. if the value is required
+	if err := c.checkSchema("go.micro.srv.Delete", namespace, string(change.Data)); err != nil {
+		return err
+	}
+
+	if err := c.checkWrite(ctx, "go.micro.srv.Delete", namespace, req.Change.Path, oldData, string(change.Data)); err != nil {
+		return err
+	}
+
 	// Update change set
 	req.Change.ChangeSet = &pb.ChangeSet{
 		Timestamp: change.Timestamp.Unix(),
@@ -324,42 +395,22 @@ func (c *Config) Delete(ctx context.Context, req *pb.DeleteRequest, rsp *pb.Dele
 		return errors.BadRequest("go.micro.srv.Delete", "update record set to db error: %v", err)
 	}
 
-	_ = publish(ctx, &pb.WatchResponse{Namespace: namespace, ChangeSet: req.Change.ChangeSet})
+	if _, err := c.recordRevision(ctx, namespace, req.Change.Path, actionDelete, oldData, req.Change.ChangeSet.Data); err != nil {
+		return errors.InternalServerError("go.micro.srv.Delete", "record revision error: %v", err)
+	}
+
+	c.notify(ctx, namespace, req.Change.ChangeSet)
 
 	return nil
 }
 
 func (c *Config) List(ctx context.Context, req *pb.ListRequest, rsp *pb.ListResponse) (err error) {
-	list, err := c.Store.List()
+	// only the keys of the namespaces are read rather than the whole store
+	rsp.Values, err = c.listChanges(ctx, "", 0, 0)
 	if err != nil {
 		return errors.BadRequest("go.micro.config.List", "query value error: %v", err)
 	}
 
-	ns := setNamespace(ctx, "")
-
-	// TODO: optimise filtering for prefix listing
-	for _, v := range list {
-		if !strings.HasPrefix(v, ns) {
-			continue
-		}
-
-		rec, err := c.Store.Read(v)
-		if err != nil {
-			return err
-		}
-
-		ch := &pb.Change{}
-		if err := json.Unmarshal(rec[0].Value, ch); err != nil {
-			return errors.BadRequest("go.micro.config.Read", "unmarshal value error: %v", err)
-		}
-
-		if ch.ChangeSet != nil {
-			ch.ChangeSet.Data = string(ch.ChangeSet.Data)
-		}
-
-		rsp.Values = append(rsp.Values, ch)
-	}
-
 	return nil
 }
 
@@ -370,6 +421,24 @@ func (c *Config) Watch(ctx context.Context, req *pb.WatchRequest, stream pb.Conf
 
 	namespace := setNamespace(ctx, req.Namespace)
 
+	// the path must be readable, values below it the caller can't read are removed
+	if len(req.Path) > 0 {
+		if err := c.checkRead(ctx, "go.micro.srv.Watch", namespace, req.Path); err != nil {
+			return err
+		}
+	}
+
+	// watchers of a path are only sent the sub-document at the path when it changes
+	var last string
+	if len(req.Path) > 0 {
+		last = "null"
+		if ch, err := c.readChange(ctx, namespace); err == nil && ch.ChangeSet != nil {
+			if last, err = subDocument(ch.ChangeSet.Data, req.Path); err != nil {
+				return errors.InternalServerError("go.micro.srv.Watch", "read error: %v", err)
+			}
+		}
+	}
+
 	watch, err := Watch(namespace)
 	if err != nil {
 		return errors.BadRequest("go.micro.srv.Watch", "watch error: %v", err)
@@ -389,8 +458,17 @@ func (c *Config) Watch(ctx context.Context, req *pb.WatchRequest, stream pb.Conf
 		if err != nil {
 			return errors.BadRequest("go.micro.srv.Watch", "listen the Next error: %v", err)
 		}
-		if ch.ChangeSet != nil {
-			ch.ChangeSet.Data = string(ch.ChangeSet.Data)
+		if ch, err = c.watchChange(ctx, namespace, ch); err != nil {
+			return errors.InternalServerError("go.micro.srv.Watch", "decrypt error: %v", err)
+		}
+		if len(req.Path) > 0 {
+			if ch, err = subtreeChange(ch, req.Path); err != nil {
+				return errors.InternalServerError("go.micro.srv.Watch", "read error: %v", err)
+			}
+			if ch.ChangeSet.Data == last {
+				continue
+			}
+			last = ch.ChangeSet.Data
 		}
 		if err := stream.Send(ch); err != nil {
 			return errors.BadRequest("go.micro.srv.Watch", "send the Change error: %v", err)
@@ -402,10 +480,7 @@ func (c *Config) Watch(ctx context.Context, req *pb.WatchRequest, stream pb.Conf
 func Watcher(ctx context.Context, ch *pb.WatchResponse) error {
 	mtx.RLock()
 	for _, sub := range watchers[ch.Namespace] {
-		select {
-		case sub.next <- ch:
-		case <-time.After(time.Millisecond * 100):
-		}
+		sub.send(ch)
 	}
 	mtx.RUnlock()
 	return nil
@@ -0,0 +1,398 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/config/source"
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+const (
+	// historyPrefix is prefixed to the key of the revisions of a namespace
+	historyPrefix = "history/"
+	// revisionPrefix is prefixed to the key of the latest revision id of a namespace
+	revisionPrefix = "revision/"
+)
+
+// the actions which are recorded in the history
+const (
+	actionCreate = "create"
+	actionUpdate = "update"
+	actionDelete = "delete"
+	actionRevert = "revert"
+	actionRotate = "rotate"
+	// actionLayer is sent to watchers of the changes when a layer merged over the namespace changed
+	actionLayer = "layer"
+)
+
+var (
+	// HistoryLimit is the default number of revisions returned by History.List
+	HistoryLimit int64 = 20
+
+	// historyMtx serialises the allocation of revision ids. The store can't allocate them atomically
+	// so they're only unique within a process, the config service supports a single replica.
+	historyMtx sync.Mutex
+)
+
+// History returns the revisions of config namespaces. The ids of revisions are allocated by the
+// process which records them, so only a single replica of the config service is supported.
+type History struct {
+	Config *Config
+}
+
+// revisionKey is the key a revision is written to, ids are zero padded so revisions are ordered
+func revisionKey(namespace string, id int64) string {
+	return fmt.Sprintf("%v%v/%020d", historyPrefix, namespace, id)
+}
+
+// configNamespace strips the auth namespace from the key of a config namespace
+func configNamespace(namespace string) string {
+	if parts := strings.SplitN(namespace, ":", 2); len(parts) == 2 {
+		return parts[1]
+	}
+	return namespace
+}
+
+// valueAt returns the json at the path in the data, blank if there's no value at the path
+func valueAt(data, path string) (string, error) {
+	if len(data) == 0 || len(path) == 0 {
+		return data, nil
+	}
+
+	vals, err := values(&source.ChangeSet{Data: []byte(data), Format: "json"})
+	if err != nil {
+		return "", err
+	}
+	v := vals.Get(strings.Split(path, PathSplitter)...)
+	if !v.Exists() {
+		return "", nil
+	}
+	return string(v.Bytes()), nil
+}
+
+// readData returns the data of the namespace, blank if it doesn't exist
+func (c *Config) readData(namespace string) (string, error) {
+	recs, err := c.Store.Read(namespace)
+	if err == store.ErrNotFound {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	ch := &pb.Change{}
+	if err := json.Unmarshal(recs[0].Value, ch); err != nil {
+		return "", err
+	}
+	if ch.ChangeSet == nil {
+		return "", nil
+	}
+	return ch.ChangeSet.Data, nil
+}
+
+// latestRevision returns the id of the latest revision of a namespace, zero if there are none
+func (c *Config) latestRevision(namespace string) (int64, error) {
+	recs, err := c.Store.Read(revisionPrefix + namespace)
+	if err == store.ErrNotFound {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(string(recs[0].Value), 10, 64)
+}
+
+// recordRevision appends a revision to the history of a namespace
+func (c *Config) recordRevision(ctx context.Context, namespace, path, action, oldData, newData string) (*hpb.Revision, error) {
+	rev := &hpb.Revision{
+		Namespace: configNamespace(namespace),
+		Path:      path,
+		Action:    action,
+		Timestamp: time.Now().Unix(),
+		Data:      newData,
+	}
+	if acc, ok := auth.AccountFromContext(ctx); ok {
+		rev.Author = acc.ID
+	}
+
+	var err error
+	if rev.OldValue, err = valueAt(oldData, path); err != nil {
+		return nil, err
+	}
+	if rev.NewValue, err = valueAt(newData, path); err != nil {
+		return nil, err
+	}
+
+	historyMtx.Lock()
+	defer historyMtx.Unlock()
+
+	latest, err := c.latestRevision(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// a revision is never overwritten, e.g. if the latest id was written by another replica
+	for rev.Id = latest + 1; ; rev.Id++ {
+		if _, err := c.Store.Read(revisionKey(namespace, rev.Id)); err == store.ErrNotFound {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	bytes, err := json.Marshal(rev)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Store.Write(&store.Record{Key: revisionKey(namespace, rev.Id), Value: bytes}); err != nil {
+		return nil, err
+	}
+	if err := c.Store.Write(&store.Record{Key: revisionPrefix + namespace, Value: []byte(strconv.FormatInt(rev.Id, 10))}); err != nil {
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+// readRevision returns a revision of the namespace
+func (c *Config) readRevision(namespace string, id int64) (*hpb.Revision, error) {
+	recs, err := c.Store.Read(revisionKey(namespace, id))
+	if err != nil {
+		return nil, err
+	}
+
+	var rev *hpb.Revision
+	if err := json.Unmarshal(recs[0].Value, &rev); err != nil {
+		return nil, err
+	}
+	return rev, nil
+}
+
+// flatten the json data into the values at each leaf path
+func flatten(data string) (map[string]string, error) {
+	flat := make(map[string]string)
+	if len(data) == 0 {
+		return flat, nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		return nil, err
+	}
+
+	var walk func(path string, v interface{})
+	walk = func(path string, v interface{}) {
+		if m, ok := v.(map[string]interface{}); ok && len(m) > 0 {
+			for k, child := range m {
+				if len(path) > 0 {
+					k = path + PathSplitter + k
+				}
+				walk(k, child)
+			}
+			return
+		}
+		b, _ := json.Marshal(v)
+		flat[path] = string(b)
+	}
+	walk("", v)
+
+	return flat, nil
+}
+
+// diff returns the values which differ between the json data, ordered by path
+func diff(from, to string) ([]*hpb.ValueChange, error) {
+	a, err := flatten(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := flatten(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*hpb.ValueChange
+	for path, old := range a {
+		if v, ok := b[path]; !ok || v != old {
+			changes = append(changes, &hpb.ValueChange{Path: path, OldValue: old, NewValue: b[path]})
+		}
+	}
+	for path, v := range b {
+		if _, ok := a[path]; !ok {
+			changes = append(changes, &hpb.ValueChange{Path: path, NewValue: v})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// List the revisions of a namespace, most recent first
+func (h *History) List(ctx context.Context, req *hpb.HistoryRequest, rsp *hpb.HistoryResponse) error {
+	if len(req.Namespace) == 0 {
+		return errors.BadRequest("go.micro.config.History.List", "invalid id")
+	}
+
+	namespace := setNamespace(ctx, req.Namespace)
+
+	recs, err := h.Config.Store.Read(historyPrefix+namespace+"/", store.ReadPrefix())
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.History.List", "read history error: %v", err)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = HistoryLimit
+	}
+
+	// the keys are ordered by id so we iterate backwards to return the most recent first
+	for i := len(recs) - 1; i >= 0 && int64(len(rsp.Revisions)) < limit; i-- {
+		var rev *hpb.Revision
+		if err := json.Unmarshal(recs[i].Value, &rev); err != nil {
+			return errors.InternalServerError("go.micro.config.History.List", "unmarshal revision error: %v", err)
+		}
+
+		// changes to the whole namespace or a parent path also change the path
+		if len(req.Path) > 0 && len(rev.Path) > 0 && rev.Path != req.Path &&
+			!strings.HasPrefix(rev.Path, req.Path+PathSplitter) && !strings.HasPrefix(req.Path, rev.Path+PathSplitter) {
+			continue
+		}
+
+		// revisions of paths the caller can't read aren't listed
+		if len(rev.Path) > 0 && !h.Config.canAccess(ctx, AccessRead, namespace, rev.Path) {
+			continue
+		}
+		if len(rev.Path) == 0 {
+			if rev.OldValue, err = h.Config.readable(ctx, namespace, rev.OldValue); err != nil {
+				return errors.InternalServerError("go.micro.config.History.List", "read error: %v", err)
+			}
+			if rev.NewValue, err = h.Config.readable(ctx, namespace, rev.NewValue); err != nil {
+				return errors.InternalServerError("go.micro.config.History.List", "read error: %v", err)
+			}
+		}
+
+		// the data of the whole namespace is only needed to diff and revert
+		rev.Data = ""
+		rev.OldValue = redact(rev.OldValue)
+		rev.NewValue = redact(rev.NewValue)
+		rsp.Revisions = append(rsp.Revisions, rev)
+	}
+
+	return nil
+}
+
+// Diff returns the values which changed between two revisions of a namespace
+func (h *History) Diff(ctx context.Context, req *hpb.DiffRequest, rsp *hpb.DiffResponse) error {
+	if len(req.Namespace) == 0 {
+		return errors.BadRequest("go.micro.config.History.Diff", "invalid id")
+	}
+
+	namespace := setNamespace(ctx, req.Namespace)
+
+	to := req.To
+	if to == 0 {
+		latest, err := h.Config.latestRevision(namespace)
+		if err != nil {
+			return errors.InternalServerError("go.micro.config.History.Diff", "read revision error: %v", err)
+		}
+		to = latest
+	}
+
+	var data [2]string
+	for i, id := range []int64{req.From, to} {
+		rev, err := h.Config.readRevision(namespace, id)
+		if err == store.ErrNotFound {
+			return errors.NotFound("go.micro.config.History.Diff", "revision %v not found", id)
+		} else if err != nil {
+			return errors.InternalServerError("go.micro.config.History.Diff", "read revision error: %v", err)
+		}
+		data[i] = redact(rev.Data)
+	}
+
+	changes, err := diff(data[0], data[1])
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.History.Diff", "diff error: %v", err)
+	}
+	for _, ch := range changes {
+		if h.Config.canAccess(ctx, AccessRead, namespace, ch.Path) {
+			rsp.Changes = append(rsp.Changes, ch)
+		}
+	}
+
+	return nil
+}
+
+// Revert the namespace to the data it had after a revision, the revert is recorded as a new revision
+func (h *History) Revert(ctx context.Context, req *hpb.RevertRequest, rsp *hpb.RevertResponse) error {
+	if len(req.Namespace) == 0 {
+		return errors.BadRequest("go.micro.config.History.Revert", "invalid id")
+	}
+
+	namespace := setNamespace(ctx, req.Namespace)
+
+	rev, err := h.Config.readRevision(namespace, req.Revision)
+	if err == store.ErrNotFound {
+		return errors.NotFound("go.micro.config.History.Revert", "revision %v not found", req.Revision)
+	} else if err != nil {
+		return errors.InternalServerError("go.micro.config.History.Revert", "read revision error: %v", err)
+	}
+
+	oldData, err := h.Config.readData(namespace)
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.History.Revert", "read old value error: %v", err)
+	}
+	if err := h.Config.checkWrite(ctx, "go.micro.config.History.Revert", namespace, "", oldData, rev.Data); err != nil {
+		return err
+	}
+
+	// the schemas of the namespace may have changed since the revision, the reverted data must be
+	// valid against the current ones
+	if len(rev.Data) > 0 {
+		if err := h.Config.checkSchema("go.micro.config.History.Revert", namespace, rev.Data); err != nil {
+			return err
+		}
+	}
+
+	change := &pb.Change{
+		Namespace: req.Namespace,
+		ChangeSet: &pb.ChangeSet{
+			Data:      rev.Data,
+			Format:    "json",
+			Source:    "revert",
+			Timestamp: time.Now().Unix(),
+		},
+	}
+
+	// the namespace was deleted at the revision
+	if len(rev.Data) == 0 {
+		if err := h.Config.Store.Delete(namespace); err != nil && err != store.ErrNotFound {
+			return errors.InternalServerError("go.micro.config.History.Revert", "delete from db error: %v", err)
+		}
+	} else {
+		bytes, err := json.Marshal(change)
+		if err != nil {
+			return errors.InternalServerError("go.micro.config.History.Revert", "marshal error: %v", err)
+		}
+		if err := h.Config.Store.Write(&store.Record{Key: namespace, Value: bytes}); err != nil {
+			return errors.InternalServerError("go.micro.config.History.Revert", "update into db error: %v", err)
+		}
+	}
+
+	rsp.Revision, err = h.Config.recordRevision(ctx, namespace, "", actionRevert, oldData, rev.Data)
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.History.Revert", "record revision error: %v", err)
+	}
+	rsp.Revision.Data = ""
+
+	h.Config.notify(ctx, namespace, change.ChangeSet)
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+	"github.com/micro/micro/v2/internal/namespace"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+func TestRecordRevision(t *testing.T) {
+	c := &Config{Store: memory.NewStore()}
+	ctx := auth.ContextWithAccount(context.Background(), &auth.Account{ID: "john"})
+
+	rev, err := c.recordRevision(ctx, "micro:global", "", actionCreate, "", `{"db":{"host":"localhost"}}`)
+	if err != nil {
+		t.Fatalf("Unexpected error recording revision: %v", err)
+	}
+	if rev.Id != 1 || rev.Author != "john" || rev.Namespace != "global" {
+		t.Errorf("Unexpected revision %+v", rev)
+	}
+
+	rev, err = c.recordRevision(ctx, "micro:global", "db.host", actionUpdate, `{"db":{"host":"localhost"}}`, `{"db":{"host":"10.0.0.1"}}`)
+	if err != nil {
+		t.Fatalf("Unexpected error recording revision: %v", err)
+	}
+	if rev.Id != 2 {
+		t.Errorf("Expected the revision id to increase but got %v", rev.Id)
+	}
+	if rev.OldValue != `"localhost"` || rev.NewValue != `"10.0.0.1"` {
+		t.Errorf("Expected the values at the path to be recorded but got %v and %v", rev.OldValue, rev.NewValue)
+	}
+
+	// revisions of other namespaces have their own ids
+	rev, err = c.recordRevision(ctx, "micro:other", "", actionCreate, "", `{}`)
+	if err != nil {
+		t.Fatalf("Unexpected error recording revision: %v", err)
+	}
+	if rev.Id != 1 {
+		t.Errorf("Expected the first revision of the namespace but got %v", rev.Id)
+	}
+
+	// a revision written after the latest id was read isn't overwritten
+	if err := c.Store.Write(&store.Record{Key: revisionKey("micro:other", 2), Value: []byte(`{"id":2}`)}); err != nil {
+		t.Fatalf("Unexpected error writing revision: %v", err)
+	}
+	rev, err = c.recordRevision(ctx, "micro:other", "", actionUpdate, `{}`, `{"a":1}`)
+	if err != nil {
+		t.Fatalf("Unexpected error recording revision: %v", err)
+	}
+	if rev.Id != 3 {
+		t.Errorf("Expected the existing revision to be skipped but got %v", rev.Id)
+	}
+
+	read, err := c.readRevision("micro:global", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error reading revision: %v", err)
+	}
+	if read.Data != `{"db":{"host":"localhost"}}` {
+		t.Errorf("Expected the data of the namespace to be recorded but got %v", read.Data)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	changes, err := diff(`{"a":1,"b":{"c":"x","d":true}}`, `{"a":1,"b":{"c":"y"},"e":[1,2]}`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []struct{ Path, Old, New string }{
+		{"b.c", `"x"`, `"y"`},
+		{"b.d", "true", ""},
+		{"e", "", "[1,2]"},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %v changes but got %v", len(expected), changes)
+	}
+	for i, e := range expected {
+		if c := changes[i]; c.Path != e.Path || c.OldValue != e.Old || c.NewValue != e.New {
+			t.Errorf("Expected change %+v but got %+v", e, c)
+		}
+	}
+}
+
+func TestRevertSchema(t *testing.T) {
+	c := &Config{Store: memory.NewStore()}
+	h := &History{Config: c}
+	ctx := namespace.ContextWithNamespace(context.Background(), "micro")
+
+	if _, err := c.recordRevision(ctx, "micro:global", "", actionCreate, "", `{"db":{"port":"5432"}}`); err != nil {
+		t.Fatalf("Unexpected error recording revision: %v", err)
+	}
+	if _, err := c.recordRevision(ctx, "micro:global", "", actionUpdate, `{"db":{"port":"5432"}}`, `{"db":{"host":"localhost"}}`); err != nil {
+		t.Fatalf("Unexpected error recording revision: %v", err)
+	}
+
+	schema := `{"type":"object","required":["host"],"properties":{"host":{"type":"string"},"port":{"type":"integer"}}}`
+	s := &Schema{Config: c}
+	if err := s.Set(ctx, &hpb.SetSchemaRequest{Namespace: "global", Path: "db", Schema: schema}, &hpb.SetSchemaResponse{}); err != nil {
+		t.Fatalf("Unexpected error setting schema: %v", err)
+	}
+
+	// the first revision is invalid against the schema set since
+	err := h.Revert(ctx, &hpb.RevertRequest{Namespace: "global", Revision: 1}, &hpb.RevertResponse{})
+	if err == nil || errors.Parse(err.Error()).Code != 400 {
+		t.Fatalf("Expected a bad request reverting to data which breaks the schema, got %v", err)
+	}
+	if data, err := c.readData("micro:global"); err != nil || len(data) > 0 {
+		t.Errorf("Expected the data not to be reverted, got %v %v", data, err)
+	}
+
+	if err := h.Revert(ctx, &hpb.RevertRequest{Namespace: "global", Revision: 2}, &hpb.RevertResponse{}); err != nil {
+		t.Fatalf("Unexpected error reverting to valid data: %v", err)
+	}
+	if data, err := c.readData("micro:global"); err != nil || data != `{"db":{"host":"localhost"}}` {
+		t.Errorf("Expected the data to be reverted, got %v %v", data, err)
+	}
+}
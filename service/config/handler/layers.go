@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/config/source"
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+const (
+	// LayerSplitter separates a namespace from the name of a layer, e.g. global@prod. Layers are
+	// written using the existing RPCs with the layer in the namespace.
+	LayerSplitter = "@"
+	// layersPrefix is prefixed to the key of the ordered layers of a namespace
+	layersPrefix = "layers/"
+	// BaseLayer is the name the namespace is given when resolving the layer a value came from
+	BaseLayer = "base"
+)
+
+// layerName is the format of a valid layer name
+var layerName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// Layers orders the layers merged over a namespace when it's read
+type Layers struct {
+	Config *Config
+}
+
+// layer is the change of a namespace or one of its layers
+type layer struct {
+	name   string
+	change *pb.Change
+}
+
+// isLayer returns true if the namespace is a layer of another namespace
+func isLayer(namespace string) bool {
+	return strings.Contains(namespace, LayerSplitter)
+}
+
+// validNamespace returns false if the namespace is a layer with an invalid name
+func validNamespace(namespace string) bool {
+	if !isLayer(namespace) {
+		return true
+	}
+	parts := strings.SplitN(namespace, LayerSplitter, 2)
+	return len(parts[0]) > 0 && layerName.MatchString(parts[1]) && parts[1] != BaseLayer
+}
+
+// layerOrder returns the names of the layers merged over the namespace, in the order they're merged
+func (c *Config) layerOrder(namespace string) ([]string, error) {
+	if isLayer(namespace) {
+		return nil, nil
+	}
+
+	recs, err := c.Store.Read(layersPrefix + namespace)
+	if err == store.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var layers []string
+	if err := json.Unmarshal(recs[0].Value, &layers); err != nil {
+		return nil, err
+	}
+	return layers, nil
+}
+
+// readLayers returns the changes of the namespace and its layers in the order they're merged, with
+// the secrets of each decrypted or redacted and the values the caller can't read removed. Layers which haven't been written are
+// skipped.
+func (c *Config) readLayers(ctx context.Context, namespace string) ([]*layer, error) {
+	order, err := c.layerOrder(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	names := append([]string{BaseLayer}, order...)
+	layers := make([]*layer, 0, len(names))
+
+	for _, name := range names {
+		key := namespace
+		if name != BaseLayer {
+			key = namespace + LayerSplitter + name
+		}
+
+		recs, err := c.Store.Read(key)
+		if err == store.ErrNotFound {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		ch := &pb.Change{}
+		if err := json.Unmarshal(recs[0].Value, ch); err != nil {
+			return nil, err
+		}
+
+		// each layer has its own data keys so the secrets are decrypted before the layers are merged
+		if ch.ChangeSet != nil {
+			if ch.ChangeSet.Data, err = c.visibleData(ctx, key, ch.ChangeSet.Data); err != nil {
+				return nil, err
+			}
+		}
+
+		layers = append(layers, &layer{name: name, change: ch})
+	}
+
+	return layers, nil
+}
+
+// readChange returns the change of the namespace as the caller should see it, with its layers
+// merged over it in order. store.ErrNotFound is returned if neither have been written.
+func (c *Config) readChange(ctx context.Context, namespace string) (*pb.Change, error) {
+	layers, err := c.readLayers(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(layers) == 0 {
+		return nil, store.ErrNotFound
+	}
+	if len(layers) == 1 && layers[0].name == BaseLayer {
+		return layers[0].change, nil
+	}
+
+	cs, err := mergeLayers(layers)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Change{Namespace: configNamespace(namespace), ChangeSet: cs}, nil
+}
+
+// mergeLayers merges the changes of the layers in order, the timestamp is that of the latest change
+func mergeLayers(layers []*layer) (*pb.ChangeSet, error) {
+	var timestamp int64
+	changes := make([]*source.ChangeSet, 0, len(layers))
+	for _, l := range layers {
+		if l.change.ChangeSet == nil {
+			continue
+		}
+		if l.change.ChangeSet.Timestamp > timestamp {
+			timestamp = l.change.ChangeSet.Timestamp
+		}
+		changes = append(changes, &source.ChangeSet{Data: []byte(l.change.ChangeSet.Data), Format: "json"})
+	}
+
+	merged, err := merge(changes...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ChangeSet{
+		Timestamp: timestamp,
+		Data:      string(merged.Data),
+		Checksum:  merged.Checksum,
+		Format:    merged.Format,
+		Source:    "layers",
+	}, nil
+}
+
+// layeredData returns the data of the namespace as the caller should see it with the current layers
+// merged over it, e.g. the data of a revision. The data is returned as is if there are no layers.
+func (c *Config) layeredData(ctx context.Context, namespace, data string) (string, error) {
+	data, err := c.visibleData(ctx, namespace, data)
+	if err != nil {
+		return "", err
+	}
+
+	layers, err := c.readLayers(ctx, namespace)
+	if err != nil {
+		return "", err
+	}
+	if len(layers) == 0 || (len(layers) == 1 && layers[0].name == BaseLayer) {
+		return data, nil
+	}
+
+	// the base layer is replaced by the data
+	if layers[0].name == BaseLayer {
+		layers = layers[1:]
+	}
+	if len(data) > 0 {
+		base := &layer{name: BaseLayer, change: &pb.Change{ChangeSet: &pb.ChangeSet{Data: data}}}
+		layers = append([]*layer{base}, layers...)
+	}
+	if len(layers) == 0 {
+		return data, nil
+	}
+
+	cs, err := mergeLayers(layers)
+	if err != nil {
+		return "", err
+	}
+	return cs.Data, nil
+}
+
+// notify the watchers of a change to the namespace. Watchers of the namespace a layer is merged
+// over are also notified when the layer changes.
+func (c *Config) notify(ctx context.Context, namespace string, cs *pb.ChangeSet) {
+	_ = publish(ctx, &pb.WatchResponse{Namespace: namespace, ChangeSet: cs})
+
+	if !isLayer(namespace) {
+		return
+	}
+
+	parts := strings.SplitN(namespace, LayerSplitter, 2)
+	order, err := c.layerOrder(parts[0])
+	if err != nil {
+		return
+	}
+	for _, name := range order {
+		if name == parts[1] {
+			// the watchers read the namespace again to merge the layers
+			_ = publish(ctx, &pb.WatchResponse{Namespace: parts[0], ChangeSet: &pb.ChangeSet{Timestamp: time.Now().Unix()}})
+			return
+		}
+	}
+}
+
+// watchChange returns the change sent to a watcher of the namespace. A namespace with layers is read
+// again so the layers are merged, otherwise the secrets in the change are decrypted or redacted.
+func (c *Config) watchChange(ctx context.Context, namespace string, ch *pb.WatchResponse) (*pb.WatchResponse, error) {
+	order, err := c.layerOrder(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(order) > 0 {
+		change, err := c.readChange(ctx, namespace)
+		if err == store.ErrNotFound {
+			return &pb.WatchResponse{Namespace: ch.Namespace, ChangeSet: &pb.ChangeSet{Data: "{}", Format: "json"}}, nil
+		} else if err != nil {
+			return nil, err
+		}
+		return &pb.WatchResponse{Namespace: ch.Namespace, ChangeSet: change.ChangeSet}, nil
+	}
+
+	if ch.ChangeSet == nil {
+		return ch, nil
+	}
+
+	data, err := c.visibleData(ctx, namespace, ch.ChangeSet.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	// the change is shared by every watcher so it's copied before the data is replaced
+	cs := *ch.ChangeSet
+	cs.Data = data
+	return &pb.WatchResponse{Namespace: ch.Namespace, ChangeSet: &cs}, nil
+}
+
+// Set the layers merged over the namespace, in the order they're merged
+func (l *Layers) Set(ctx context.Context, req *hpb.SetLayersRequest, rsp *hpb.SetLayersResponse) error {
+	if len(req.Namespace) == 0 || isLayer(req.Namespace) {
+		return errors.BadRequest("go.micro.config.Layers.Set", "invalid id")
+	}
+
+	seen := make(map[string]bool, len(req.Layers))
+	for _, name := range req.Layers {
+		if !layerName.MatchString(name) || name == BaseLayer {
+			return errors.BadRequest("go.micro.config.Layers.Set", "invalid layer %v", name)
+		}
+		if seen[name] {
+			return errors.BadRequest("go.micro.config.Layers.Set", "duplicate layer %v", name)
+		}
+		seen[name] = true
+	}
+
+	namespace := setNamespace(ctx, req.Namespace)
+
+	if err := l.Config.checkWrite(ctx, "go.micro.config.Layers.Set", namespace, "", "", ""); err != nil {
+		return err
+	}
+
+	// the layers merged in the new order must be valid against the schemas of the namespace
+	errs, err := l.Config.validateLayers(namespace, req.Layers, "", "")
+	if err := schemaError("go.micro.config.Layers.Set", errs, err); err != nil {
+		return err
+	}
+
+	if len(req.Layers) == 0 {
+		if err := l.Config.Store.Delete(layersPrefix + namespace); err != nil && err != store.ErrNotFound {
+			return errors.InternalServerError("go.micro.config.Layers.Set", "delete from db error: %v", err)
+		}
+	} else {
+		bytes, err := json.Marshal(req.Layers)
+		if err != nil {
+			return errors.InternalServerError("go.micro.config.Layers.Set", "marshal error: %v", err)
+		}
+		if err := l.Config.Store.Write(&store.Record{Key: layersPrefix + namespace, Value: bytes}); err != nil {
+			return errors.InternalServerError("go.micro.config.Layers.Set", "write into db error: %v", err)
+		}
+	}
+
+	// the resolved config changes with the layers
+	_ = publish(ctx, &pb.WatchResponse{Namespace: namespace, ChangeSet: &pb.ChangeSet{Timestamp: time.Now().Unix()}})
+
+	return nil
+}
+
+// Get the layers merged over the namespace
+func (l *Layers) Get(ctx context.Context, req *hpb.GetLayersRequest, rsp *hpb.GetLayersResponse) error {
+	if len(req.Namespace) == 0 || isLayer(req.Namespace) {
+		return errors.BadRequest("go.micro.config.Layers.Get", "invalid id")
+	}
+
+	namespace := setNamespace(ctx, req.Namespace)
+
+	if err := l.Config.checkRead(ctx, "go.micro.config.Layers.Get", namespace, ""); err != nil {
+		return err
+	}
+
+	layers, err := l.Config.layerOrder(namespace)
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.Layers.Get", "read error: %v", err)
+	}
+	rsp.Layers = layers
+
+	return nil
+}
+
+// Resolve returns the values of the namespace with its layers merged, along with the layer each
+// value came from
+func (l *Layers) Resolve(ctx context.Context, req *hpb.ResolveRequest, rsp *hpb.ResolveResponse) error {
+	if len(req.Namespace) == 0 {
+		return errors.BadRequest("go.micro.config.Layers.Resolve", "invalid id")
+	}
+
+	layers, err := l.Config.readLayers(ctx, setNamespace(ctx, req.Namespace))
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.Layers.Resolve", "read error: %v", err)
+	}
+
+	// later layers override the values of earlier ones
+	values := make(map[string]*hpb.LayerValue)
+	for _, l := range layers {
+		if l.change.ChangeSet == nil {
+			continue
+		}
+		flat, err := flatten(l.change.ChangeSet.Data)
+		if err != nil {
+			return errors.InternalServerError("go.micro.config.Layers.Resolve", "read error: %v", err)
+		}
+		for path, v := range flat {
+			// a value replaces any values below it
+			for p := range values {
+				if strings.HasPrefix(p, path+PathSplitter) {
+					delete(values, p)
+				}
+			}
+			values[path] = &hpb.LayerValue{Path: path, Value: v, Layer: l.name}
+		}
+	}
+
+	for path, v := range values {
+		if len(req.Path) > 0 && path != req.Path && !strings.HasPrefix(path, req.Path+PathSplitter) {
+			continue
+		}
+		rsp.Values = append(rsp.Values, v)
+	}
+	sort.Slice(rsp.Values, func(i, j int) bool { return rsp.Values[i].Path < rsp.Values[j].Path })
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/micro/go-micro/v2/auth"
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+	"github.com/micro/micro/v2/internal/namespace"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+func TestLayers(t *testing.T) {
+	c := &Config{Store: memory.NewStore()}
+	l := &Layers{Config: c}
+	ctx := namespace.ContextWithNamespace(auth.ContextWithAccount(context.Background(), &auth.Account{ID: "admin", Scopes: []string{"admin"}}), "micro")
+
+	write := func(key, data string) {
+		bytes, _ := json.Marshal(&pb.Change{Namespace: key, ChangeSet: &pb.ChangeSet{Data: data, Format: "json"}})
+		if err := c.Store.Write(&store.Record{Key: key, Value: bytes}); err != nil {
+			t.Fatalf("Unexpected error writing config: %v", err)
+		}
+	}
+	write("micro:global", `{"db":{"host":"localhost","port":5432},"debug":true}`)
+	write("micro:global@prod", `{"db":{"host":"10.0.0.1"},"debug":false}`)
+	write("micro:global@region-eu", `{"db":{"host":"10.1.0.1"}}`)
+
+	// layers which aren't set aren't merged
+	ch, err := c.readChange(ctx, "micro:global")
+	if err != nil {
+		t.Fatalf("Unexpected error reading config: %v", err)
+	}
+	if ch.ChangeSet.Data != `{"db":{"host":"localhost","port":5432},"debug":true}` {
+		t.Errorf("Expected the base config but got %v", ch.ChangeSet.Data)
+	}
+
+	if err := l.Set(ctx, &hpb.SetLayersRequest{Namespace: "global", Layers: []string{"prod", "bad layer"}}, &hpb.SetLayersResponse{}); err == nil {
+		t.Errorf("Expected an error setting an invalid layer")
+	}
+	if err := l.Set(ctx, &hpb.SetLayersRequest{Namespace: "global", Layers: []string{"prod", "region-eu", "staging"}}, &hpb.SetLayersResponse{}); err != nil {
+		t.Fatalf("Unexpected error setting layers: %v", err)
+	}
+
+	// later layers override earlier ones and layers which haven't been written are skipped
+	ch, err = c.readChange(ctx, "micro:global")
+	if err != nil {
+		t.Fatalf("Unexpected error reading config: %v", err)
+	}
+	var merged map[string]interface{}
+	if err := json.Unmarshal([]byte(ch.ChangeSet.Data), &merged); err != nil {
+		t.Fatalf("Unexpected error unmarshaling config: %v", err)
+	}
+	db := merged["db"].(map[string]interface{})
+	if db["host"] != "10.1.0.1" || db["port"] != float64(5432) || merged["debug"] != false {
+		t.Errorf("Expected the layers to be merged in order but got %v", ch.ChangeSet.Data)
+	}
+
+	rsp := &hpb.ResolveResponse{}
+	if err := l.Resolve(ctx, &hpb.ResolveRequest{Namespace: "global"}, rsp); err != nil {
+		t.Fatalf("Unexpected error resolving config: %v", err)
+	}
+	expected := []struct{ Path, Value, Layer string }{
+		{"db.host", `"10.1.0.1"`, "region-eu"},
+		{"db.port", "5432", BaseLayer},
+		{"debug", "false", "prod"},
+	}
+	if len(rsp.Values) != len(expected) {
+		t.Fatalf("Expected %v values but got %+v", len(expected), rsp.Values)
+	}
+	for i, e := range expected {
+		v := rsp.Values[i]
+		if v.Path != e.Path || v.Value != e.Value || v.Layer != e.Layer {
+			t.Errorf("Expected %+v but got %+v", e, v)
+		}
+	}
+
+	if !validNamespace("global@prod") || validNamespace("global@") || validNamespace("global@"+BaseLayer) {
+		t.Errorf("Unexpected validation of layer namespaces")
+	}
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/micro/go-micro/v2/config/source"
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+// Namespaces lists the config of the namespaces a page at a time
+type Namespaces struct {
+	Config *Config
+}
+
+// listChanges reads the changes of the namespaces with the prefix, skipping offset namespaces and
+// returning at most limit if it's non zero. The data is returned as the caller should see it.
+func (c *Config) listChanges(ctx context.Context, prefix string, limit, offset uint) ([]*pb.Change, error) {
+	opts := []store.ReadOption{store.ReadPrefix()}
+	if limit > 0 {
+		opts = append(opts, store.ReadLimit(limit))
+	}
+	if offset > 0 {
+		opts = append(opts, store.ReadOffset(offset))
+	}
+
+	recs, err := c.Store.Read(setNamespace(ctx, prefix), opts...)
+	if err == store.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*pb.Change, 0, len(recs))
+	for _, rec := range recs {
+		ch := &pb.Change{}
+		if err := json.Unmarshal(rec.Value, ch); err != nil {
+			return nil, err
+		}
+		if ch.ChangeSet != nil {
+			if ch.ChangeSet.Data, err = c.visibleData(ctx, rec.Key, ch.ChangeSet.Data); err != nil {
+				return nil, err
+			}
+		}
+		changes = append(changes, ch)
+	}
+
+	return changes, nil
+}
+
+// subDocument returns the json value at the path in the data, null if there's no value
+func subDocument(data, path string) (string, error) {
+	if len(data) == 0 {
+		return "null", nil
+	}
+
+	vals, err := values(&source.ChangeSet{Data: []byte(data), Format: "json"})
+	if err != nil {
+		return "", err
+	}
+	return string(vals.Get(strings.Split(path, PathSplitter)...).Bytes()), nil
+}
+
+// subtreeChange returns a copy of the change with only the sub-document at the path
+func subtreeChange(ch *pb.WatchResponse, path string) (*pb.WatchResponse, error) {
+	cs := &pb.ChangeSet{Format: "json"}
+	if ch.ChangeSet != nil {
+		c := *ch.ChangeSet
+		cs = &c
+	}
+
+	data, err := subDocument(cs.Data, path)
+	if err != nil {
+		return nil, err
+	}
+	cs.Data = data
+	cs.Checksum = ""
+
+	return &pb.WatchResponse{Namespace: ch.Namespace, ChangeSet: cs}, nil
+}
+
+// List the namespaces with the prefix, a page at a time
+func (n *Namespaces) List(ctx context.Context, req *hpb.ListNamespacesRequest, rsp *hpb.ListNamespacesResponse) error {
+	if req.Limit < 0 || req.Offset < 0 {
+		return errors.BadRequest("go.micro.config.Namespaces.List", "invalid limit or offset")
+	}
+
+	limit := uint(req.Limit)
+	if limit > 0 {
+		// read one more than the page to know if there's another page
+		limit++
+	}
+
+	changes, err := n.Config.listChanges(ctx, req.Prefix, limit, uint(req.Offset))
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.Namespaces.List", "read error: %v", err)
+	}
+
+	if req.Limit > 0 && len(changes) > int(req.Limit) {
+		changes = changes[:req.Limit]
+		rsp.NextOffset = req.Offset + req.Limit
+	}
+
+	for _, ch := range changes {
+		ns := &hpb.Namespace{Namespace: ch.Namespace}
+		if ch.ChangeSet != nil {
+			ns.Data = ch.ChangeSet.Data
+			ns.Timestamp = ch.ChangeSet.Timestamp
+		}
+		rsp.Namespaces = append(rsp.Namespaces, ns)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+	"github.com/micro/micro/v2/internal/namespace"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+func TestNamespacesList(t *testing.T) {
+	c := &Config{Store: memory.NewStore()}
+	n := &Namespaces{Config: c}
+	ctx := namespace.ContextWithNamespace(context.Background(), "micro")
+
+	write := func(key, ns string) {
+		bytes, _ := json.Marshal(&pb.Change{Namespace: ns, ChangeSet: &pb.ChangeSet{Data: `{}`}})
+		if err := c.Store.Write(&store.Record{Key: key, Value: bytes}); err != nil {
+			t.Fatalf("Unexpected error writing config: %v", err)
+		}
+	}
+	for i := 0; i < 5; i++ {
+		write(fmt.Sprintf("micro:svc%d", i), fmt.Sprintf("svc%d", i))
+	}
+	write("micro:global", "global")
+	// namespaces of other tenants and other records aren't listed
+	write("other:svc9", "svc9")
+	write(historyPrefix+"micro:svc0/1", "svc0")
+
+	rsp := &pb.ListResponse{}
+	if err := c.List(ctx, &pb.ListRequest{}, rsp); err != nil {
+		t.Fatalf("Unexpected error listing config: %v", err)
+	}
+	if len(rsp.Values) != 6 {
+		t.Errorf("Expected 6 namespaces but got %v", len(rsp.Values))
+	}
+
+	var listed []string
+	req := &hpb.ListNamespacesRequest{Prefix: "svc", Limit: 2}
+	for i := 0; i < 5; i++ {
+		rsp := &hpb.ListNamespacesResponse{}
+		if err := n.List(ctx, req, rsp); err != nil {
+			t.Fatalf("Unexpected error listing namespaces: %v", err)
+		}
+		for _, ns := range rsp.Namespaces {
+			listed = append(listed, ns.Namespace)
+		}
+		if rsp.NextOffset == 0 {
+			break
+		}
+		req.Offset = rsp.NextOffset
+	}
+
+	expected := []string{"svc0", "svc1", "svc2", "svc3", "svc4"}
+	if fmt.Sprint(listed) != fmt.Sprint(expected) {
+		t.Errorf("Expected the pages to list %v but got %v", expected, listed)
+	}
+}
+
+func TestSubtreeChange(t *testing.T) {
+	ch := &pb.WatchResponse{Namespace: "micro:global", ChangeSet: &pb.ChangeSet{Data: `{"db":{"host":"localhost","port":5432},"debug":true}`, Checksum: "abc"}}
+
+	sub, err := subtreeChange(ch, "db")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sub.ChangeSet.Data != `{"host":"localhost","port":5432}` {
+		t.Errorf("Expected the sub-document at the path but got %v", sub.ChangeSet.Data)
+	}
+	if ch.ChangeSet.Checksum != "abc" {
+		t.Errorf("Expected the change to be copied")
+	}
+
+	sub, err = subtreeChange(&pb.WatchResponse{Namespace: "micro:global"}, "db.host")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sub.ChangeSet.Data != "null" {
+		t.Errorf("Expected null for a deleted namespace but got %v", sub.ChangeSet.Data)
+	}
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/micro/go-micro/v2/config/source"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/internal/jsonschema"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+// schemaPrefix is prefixed to the key of the schemas of a namespace
+const schemaPrefix = "schema/"
+
+// Schema manages the schemas config is validated against
+type Schema struct {
+	Config *Config
+}
+
+// schemaKey is the key the schema for a path in the namespace is written to
+func schemaKey(namespace, path string) string {
+	return schemaPrefix + namespace + "/" + path
+}
+
+// validate the data of a namespace against the schemas attached to it. Schemas attached to a path
+// are only validated if there's a value at the path. A layer is validated against the schemas of its
+// namespace, merged over it along with the other layers of the namespace.
+func (c *Config) validate(namespace, data string) ([]*jsonschema.Error, error) {
+	if isLayer(namespace) {
+		parts := strings.SplitN(namespace, LayerSplitter, 2)
+		order, err := c.layerOrder(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		// a layer which isn't merged yet is validated as if it was merged last
+		var ordered bool
+		for _, name := range order {
+			ordered = ordered || name == parts[1]
+		}
+		if !ordered {
+			order = append(order, parts[1])
+		}
+		return c.validateLayers(parts[0], order, parts[1], data)
+	}
+
+	recs, err := c.Store.Read(schemaPrefix+namespace+"/", store.ReadPrefix())
+	if err != nil || len(recs) == 0 {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		data = "{}"
+	}
+
+	// secrets are validated using their decrypted values
+	if data, err = c.revealData(namespace, data); err != nil {
+		return nil, err
+	}
+
+	return validateSchemas(namespace, recs, data)
+}
+
+// validateLayers validates the namespace with the layers merged over it in order against the schemas
+// of the namespace. The data of the layer is used rather than the data written for it.
+func (c *Config) validateLayers(namespace string, order []string, layer, data string) ([]*jsonschema.Error, error) {
+	recs, err := c.Store.Read(schemaPrefix+namespace+"/", store.ReadPrefix())
+	if err != nil || len(recs) == 0 {
+		return nil, err
+	}
+
+	changes := make([]*source.ChangeSet, 0, len(order)+1)
+	for _, name := range append([]string{BaseLayer}, order...) {
+		key := namespace
+		if name != BaseLayer {
+			key = namespace + LayerSplitter + name
+		}
+
+		d := data
+		if name != layer {
+			if d, err = c.readData(key); err != nil {
+				return nil, err
+			}
+		}
+		if len(d) == 0 {
+			continue
+		}
+
+		// each layer has its own data keys so the secrets are decrypted before the layers are merged
+		if d, err = c.revealData(key, d); err != nil {
+			return nil, err
+		}
+		changes = append(changes, &source.ChangeSet{Data: []byte(d), Format: "json"})
+	}
+
+	merged := "{}"
+	if len(changes) > 0 {
+		ch, err := merge(changes...)
+		if err != nil {
+			return nil, err
+		}
+		merged = string(ch.Data)
+	}
+
+	return validateSchemas(namespace, recs, merged)
+}
+
+// validateSchemas validates the data against the schema records of the namespace
+func validateSchemas(namespace string, recs []*store.Record, data string) ([]*jsonschema.Error, error) {
+	prefix := schemaPrefix + namespace + "/"
+
+	var errs []*jsonschema.Error
+	for _, rec := range recs {
+		path := strings.TrimPrefix(rec.Key, prefix)
+
+		schema, err := jsonschema.Compile(rec.Value)
+		if err != nil {
+			return nil, err
+		}
+		val, err := valueAt(data, path)
+		if err != nil {
+			return nil, err
+		}
+		if len(val) == 0 {
+			continue
+		}
+
+		verrs, err := schema.Validate([]byte(val))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range verrs {
+			if len(path) > 0 {
+				e.Path = strings.TrimSuffix(path+PathSplitter+e.Path, PathSplitter)
+			}
+			errs = append(errs, e)
+		}
+	}
+
+	return errs, nil
+}
+
+// checkSchema returns a BadRequest error detailing the values which fail validation
+func (c *Config) checkSchema(id, namespace, data string) error {
+	errs, err := c.validate(namespace, data)
+	return schemaError(id, errs, err)
+}
+
+// schemaError returns the error of the result of a validation
+func schemaError(id string, errs []*jsonschema.Error, err error) error {
+	if err != nil {
+		return errors.InternalServerError(id, "schema validation error: %v", err)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msgs = append(msgs, e.Error())
+	}
+	return errors.BadRequest(id, "config failed schema validation: %v", strings.Join(msgs, "; "))
+}
+
+// Set the schema for a path in the namespace
+func (s *Schema) Set(ctx context.Context, req *hpb.SetSchemaRequest, rsp *hpb.SetSchemaResponse) error {
+	if len(req.Namespace) == 0 {
+		return errors.BadRequest("go.micro.config.Schema.Set", "invalid id")
+	}
+
+	namespace := setNamespace(ctx, req.Namespace)
+
+	// a schema restricts the values which can be written at its path
+	if err := s.Config.checkWrite(ctx, "go.micro.config.Schema.Set", namespace, req.Path, "", ""); err != nil {
+		return err
+	}
+
+	key := schemaKey(namespace, req.Path)
+
+	if len(req.Schema) == 0 {
+		if err := s.Config.Store.Delete(key); err != nil && err != store.ErrNotFound {
+			return errors.InternalServerError("go.micro.config.Schema.Set", "delete from db error: %v", err)
+		}
+		return nil
+	}
+
+	if _, err := jsonschema.Compile([]byte(req.Schema)); err != nil {
+		return errors.BadRequest("go.micro.config.Schema.Set", err.Error())
+	}
+
+	if err := s.Config.Store.Write(&store.Record{Key: key, Value: []byte(req.Schema)}); err != nil {
+		return errors.InternalServerError("go.micro.config.Schema.Set", "write into db error: %v", err)
+	}
+
+	return nil
+}
+
+// Get the schema for a path in the namespace
+func (s *Schema) Get(ctx context.Context, req *hpb.GetSchemaRequest, rsp *hpb.GetSchemaResponse) error {
+	if len(req.Namespace) == 0 {
+		return errors.BadRequest("go.micro.config.Schema.Get", "invalid id")
+	}
+
+	namespace := setNamespace(ctx, req.Namespace)
+
+	if err := s.Config.checkRead(ctx, "go.micro.config.Schema.Get", namespace, req.Path); err != nil {
+		return err
+	}
+
+	recs, err := s.Config.Store.Read(schemaKey(namespace, req.Path))
+	if err == store.ErrNotFound {
+		return errors.NotFound("go.micro.config.Schema.Get", "Not found")
+	} else if err != nil {
+		return errors.InternalServerError("go.micro.config.Schema.Get", "read error: %v", err)
+	}
+
+	rsp.Schema = string(recs[0].Value)
+	return nil
+}
+
+// Validate the data, or the current config of the namespace, against the schemas of the namespace
+func (s *Schema) Validate(ctx context.Context, req *hpb.ValidateRequest, rsp *hpb.ValidateResponse) error {
+	if len(req.Namespace) == 0 {
+		return errors.BadRequest("go.micro.config.Schema.Validate", "invalid id")
+	}
+
+	namespace := setNamespace(ctx, req.Namespace)
+
+	if err := s.Config.checkRead(ctx, "go.micro.config.Schema.Validate", namespace, ""); err != nil {
+		return err
+	}
+
+	// the errors of the current config detail its values, so the caller must be able to read them all
+	data := req.Data
+	if len(data) == 0 {
+		var err error
+		if data, err = s.Config.readData(namespace); err != nil {
+			return errors.InternalServerError("go.micro.config.Schema.Validate", "read error: %v", err)
+		}
+		if err := s.Config.checkReadAll(ctx, "go.micro.config.Schema.Validate", namespace, data); err != nil {
+			return err
+		}
+	} else if !json.Valid([]byte(data)) {
+		return errors.BadRequest("go.micro.config.Schema.Validate", "data is not valid json")
+	}
+
+	errs, err := s.Config.validate(namespace, data)
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.Schema.Validate", "schema validation error: %v", err)
+	}
+	for _, e := range errs {
+		rsp.Errors = append(rsp.Errors, &hpb.ValidationError{Path: e.Path, Message: e.Message})
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+	"github.com/micro/micro/v2/internal/namespace"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+func TestSchema(t *testing.T) {
+	c := &Config{Store: memory.NewStore()}
+	s := &Schema{Config: c}
+	ctx := namespace.ContextWithNamespace(context.Background(), "micro")
+
+	if err := s.Set(ctx, &hpb.SetSchemaRequest{Namespace: "global", Schema: `{"type":`}, &hpb.SetSchemaResponse{}); err == nil {
+		t.Errorf("Expected an invalid schema to be rejected")
+	}
+
+	schema := `{"type":"object","required":["host"],"properties":{"host":{"type":"string"},"port":{"type":"integer"}}}`
+	if err := s.Set(ctx, &hpb.SetSchemaRequest{Namespace: "global", Path: "db", Schema: schema}, &hpb.SetSchemaResponse{}); err != nil {
+		t.Fatalf("Unexpected error setting schema: %v", err)
+	}
+
+	// values outside the path aren't validated
+	if err := c.checkSchema("test", "micro:global", `{"foo":"bar"}`); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+	if err := c.checkSchema("test", "micro:global", `{"db":{"host":"localhost","port":5432}}`); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+
+	rsp := &hpb.ValidateResponse{}
+	if err := s.Validate(ctx, &hpb.ValidateRequest{Namespace: "global", Data: `{"db":{"port":"5432"}}`}, rsp); err != nil {
+		t.Fatalf("Unexpected error validating: %v", err)
+	}
+	if len(rsp.Errors) != 2 || rsp.Errors[0].Path != "db.host" || rsp.Errors[1].Path != "db.port" {
+		t.Errorf("Expected db.host and db.port to be invalid but got %v", rsp.Errors)
+	}
+
+	// the schemas of other namespaces don't apply
+	if err := c.checkSchema("test", "micro:other", `{"db":{"port":"5432"}}`); err != nil {
+		t.Errorf("Unexpected validation error: %v", err)
+	}
+}
+
+func TestLayerSchema(t *testing.T) {
+	c := &Config{Store: memory.NewStore()}
+	s := &Schema{Config: c}
+	l := &Layers{Config: c}
+	ctx := namespace.ContextWithNamespace(context.Background(), "micro")
+
+	update := func(ns, data string) error {
+		req := &pb.UpdateRequest{Change: &pb.Change{Namespace: ns, ChangeSet: &pb.ChangeSet{Data: data, Format: "json"}}}
+		return c.Update(ctx, req, &pb.UpdateResponse{})
+	}
+
+	schema := `{"type":"object","required":["host"],"properties":{"host":{"type":"string"},"port":{"type":"integer"}}}`
+	if err := s.Set(ctx, &hpb.SetSchemaRequest{Namespace: "global", Path: "db", Schema: schema}, &hpb.SetSchemaResponse{}); err != nil {
+		t.Fatalf("Unexpected error setting schema: %v", err)
+	}
+	if err := update("global", `{"db":{"host":"localhost"}}`); err != nil {
+		t.Fatalf("Unexpected error updating config: %v", err)
+	}
+	if err := l.Set(ctx, &hpb.SetLayersRequest{Namespace: "global", Layers: []string{"prod"}}, &hpb.SetLayersResponse{}); err != nil {
+		t.Fatalf("Unexpected error setting layers: %v", err)
+	}
+
+	// the layers are validated against the schemas of the namespace once they're merged over it, so
+	// a layer doesn't need the values the namespace already has
+	if err := update("global@prod", `{"db":{"port":"5432"}}`); err == nil {
+		t.Errorf("Expected an invalid layer to be rejected")
+	}
+	if err := update("global@prod", `{"db":{"port":5432}}`); err != nil {
+		t.Errorf("Unexpected error updating a valid layer: %v", err)
+	}
+
+	// layers which aren't merged yet are validated as if they were merged last
+	if err := update("global@staging", `{"db":{"host":5}}`); err == nil {
+		t.Errorf("Expected an invalid layer which isn't merged to be rejected")
+	}
+
+	// a layer written before the schema can't be merged if it's invalid
+	if err := c.Store.Write(&store.Record{Key: "micro:global@broken", Value: []byte(`{"changeSet":{"data":"{\"db\":{\"host\":5}}"}}`)}); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Set(ctx, &hpb.SetLayersRequest{Namespace: "global", Layers: []string{"prod", "broken"}}, &hpb.SetLayersResponse{}); err == nil {
+		t.Errorf("Expected layers which merge into invalid config to be rejected")
+	}
+}
@@ -0,0 +1,374 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/micro/go-micro/v2/auth"
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/internal/secret"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+const (
+	// dataKeyPrefix is prefixed to the key of the data keys of a namespace
+	dataKeyPrefix = "datakey/"
+	// SecretPrefix prefixes the encrypted values in config, e.g. "enc:1:<base64 ciphertext>" where
+	// 1 is the version of the data key used to encrypt the value
+	SecretPrefix = "enc:"
+	// RedactedValue is returned in place of secrets to callers which can't decrypt them
+	RedactedValue = "[secret]"
+)
+
+var (
+	// DefaultSecretScopes are the scopes of the accounts which can read secrets
+	DefaultSecretScopes = []string{"admin", "service"}
+
+	// dataKeyMtx serialises the creation and rotation of data keys
+	dataKeyMtx sync.Mutex
+)
+
+// dataKeys are the keys used to encrypt the secrets of a namespace. The keys are encrypted with
+// the secret key of the config service and old versions are kept so values encrypted before a
+// rotation can still be decrypted.
+type dataKeys struct {
+	Current int               `json:"current"`
+	Keys    map[string][]byte `json:"keys"`
+}
+
+// Secrets sets encrypted config values and rotates the keys used to encrypt them
+type Secrets struct {
+	Config *Config
+}
+
+// canReadSecrets returns true if the account making the request has a scope which can read secrets
+func (c *Config) canReadSecrets(ctx context.Context) bool {
+	acc, ok := auth.AccountFromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	scopes := c.SecretScopes
+	if scopes == nil {
+		scopes = DefaultSecretScopes
+	}
+	for _, s := range acc.Scopes {
+		for _, allowed := range scopes {
+			if s == allowed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readDataKeys returns the data keys of the namespace, nil if there are none
+func (c *Config) readDataKeys(namespace string) (*dataKeys, error) {
+	recs, err := c.Store.Read(dataKeyPrefix + namespace)
+	if err == store.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var keys *dataKeys
+	if err := json.Unmarshal(recs[0].Value, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// newDataKey generates a data key for the namespace and makes it the current version
+func (c *Config) newDataKey(namespace string, keys *dataKeys) (*dataKeys, error) {
+	if keys == nil {
+		keys = &dataKeys{Keys: make(map[string][]byte)}
+	}
+
+	key, err := secret.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := secret.Encrypt(c.SecretKey, key)
+	if err != nil {
+		return nil, err
+	}
+
+	keys.Current++
+	keys.Keys[strconv.Itoa(keys.Current)] = encrypted
+
+	bytes, err := json.Marshal(keys)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Store.Write(&store.Record{Key: dataKeyPrefix + namespace, Value: bytes}); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// dataKey returns the decrypted data key of the version provided
+func (c *Config) dataKey(keys *dataKeys, version string) ([]byte, error) {
+	encrypted, ok := keys.Keys[version]
+	if !ok {
+		return nil, fmt.Errorf("Data key %v not found", version)
+	}
+	return secret.Decrypt(c.SecretKey, encrypted)
+}
+
+// encryptValue encrypts the json value with the current data key of the namespace, generating a
+// data key if the namespace doesn't have one yet
+func (c *Config) encryptValue(namespace string, value []byte) (string, error) {
+	if len(c.SecretKey) == 0 {
+		return "", fmt.Errorf("Secrets are not enabled, the config service has no secret key")
+	}
+
+	dataKeyMtx.Lock()
+	keys, err := c.readDataKeys(namespace)
+	if err == nil && keys == nil {
+		keys, err = c.newDataKey(namespace, nil)
+	}
+	dataKeyMtx.Unlock()
+	if err != nil {
+		return "", err
+	}
+
+	version := strconv.Itoa(keys.Current)
+	key, err := c.dataKey(keys, version)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := secret.Encrypt(key, value)
+	if err != nil {
+		return "", err
+	}
+
+	return SecretPrefix + version + ":" + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// decryptValue decrypts an encrypted value, returning the json value
+func (c *Config) decryptValue(keys *dataKeys, value string) ([]byte, error) {
+	parts := strings.SplitN(strings.TrimPrefix(value, SecretPrefix), ":", 2)
+	if len(parts) != 2 || keys == nil {
+		return nil, secret.ErrInvalidCiphertext
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, secret.ErrInvalidCiphertext
+	}
+	key, err := c.dataKey(keys, parts[0])
+	if err != nil {
+		return nil, err
+	}
+	return secret.Decrypt(key, ciphertext)
+}
+
+// mapSecrets replaces every encrypted value in the json data with the value returned by fn
+func mapSecrets(data string, fn func(string) (interface{}, error)) (string, error) {
+	if !strings.Contains(data, SecretPrefix) {
+		return data, nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		return "", err
+	}
+
+	var walk func(v interface{}) (interface{}, error)
+	walk = func(v interface{}) (interface{}, error) {
+		var err error
+		switch val := v.(type) {
+		case string:
+			if strings.HasPrefix(val, SecretPrefix) {
+				return fn(val)
+			}
+		case map[string]interface{}:
+			for k, child := range val {
+				if val[k], err = walk(child); err != nil {
+					return nil, err
+				}
+			}
+		case []interface{}:
+			for i, child := range val {
+				if val[i], err = walk(child); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return v, nil
+	}
+
+	v, err := walk(v)
+	if err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// redact replaces the encrypted values in the json data with a placeholder
+func redact(data string) string {
+	redacted, err := mapSecrets(data, func(string) (interface{}, error) { return RedactedValue, nil })
+	if err != nil {
+		return data
+	}
+	return redacted
+}
+
+// revealData decrypts the encrypted values in the json data, values which can't be decrypted are
+// redacted
+func (c *Config) revealData(namespace, data string) (string, error) {
+	if !strings.Contains(data, SecretPrefix) {
+		return data, nil
+	}
+
+	keys, err := c.readDataKeys(namespace)
+	if err != nil {
+		return "", err
+	}
+
+	return mapSecrets(data, func(v string) (interface{}, error) {
+		plaintext, err := c.decryptValue(keys, v)
+		if err != nil {
+			return RedactedValue, nil
+		}
+		var val interface{}
+		if err := json.Unmarshal(plaintext, &val); err != nil {
+			return RedactedValue, nil
+		}
+		return val, nil
+	})
+}
+
+// secretData returns the json data as the caller should see it, secrets are decrypted if the caller
+// is allowed to read them and redacted otherwise
+func (c *Config) secretData(ctx context.Context, namespace, data string) (string, error) {
+	if !c.canReadSecrets(ctx) {
+		return redact(data), nil
+	}
+	return c.revealData(namespace, data)
+}
+
+// Set a secret value at a path in the namespace
+func (s *Secrets) Set(ctx context.Context, req *hpb.SetSecretRequest, rsp *hpb.SetSecretResponse) error {
+	if len(req.Namespace) == 0 {
+		return errors.BadRequest("go.micro.config.Secrets.Set", "invalid id")
+	}
+	if len(req.Path) == 0 {
+		return errors.BadRequest("go.micro.config.Secrets.Set", "invalid path")
+	}
+
+	// values which aren't json are set as strings
+	value := []byte(req.Value)
+	if !json.Valid(value) {
+		value, _ = json.Marshal(req.Value)
+	}
+
+	encrypted, err := s.Config.encryptValue(setNamespace(ctx, req.Namespace), value)
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.Secrets.Set", "encrypt error: %v", err)
+	}
+
+	return s.Config.Update(ctx, &pb.UpdateRequest{
+		Change: &pb.Change{
+			Namespace: req.Namespace,
+			Path:      req.Path,
+			ChangeSet: &pb.ChangeSet{
+				Data:   encrypted,
+				Format: "json",
+				Source: "secret",
+			},
+		},
+	}, &pb.UpdateResponse{})
+}
+
+// Rotate generates a new data key for the namespace and re-encrypts the secrets with it. Old keys
+// are kept so the secrets in the history can still be decrypted.
+func (s *Secrets) Rotate(ctx context.Context, req *hpb.RotateKeyRequest, rsp *hpb.RotateKeyResponse) error {
+	if len(req.Namespace) == 0 {
+		return errors.BadRequest("go.micro.config.Secrets.Rotate", "invalid id")
+	}
+	if len(s.Config.SecretKey) == 0 {
+		return errors.InternalServerError("go.micro.config.Secrets.Rotate", "secrets are not enabled")
+	}
+
+	namespace := setNamespace(ctx, req.Namespace)
+
+	if err := s.Config.checkWrite(ctx, "go.micro.config.Secrets.Rotate", namespace, "", "", ""); err != nil {
+		return err
+	}
+
+	dataKeyMtx.Lock()
+	defer dataKeyMtx.Unlock()
+
+	keys, err := s.Config.readDataKeys(namespace)
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.Secrets.Rotate", "read data keys error: %v", err)
+	}
+	if keys, err = s.Config.newDataKey(namespace, keys); err != nil {
+		return errors.InternalServerError("go.micro.config.Secrets.Rotate", "generate data key error: %v", err)
+	}
+	rsp.Version = int64(keys.Current)
+
+	recs, err := s.Config.Store.Read(namespace)
+	if err == store.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return errors.InternalServerError("go.micro.config.Secrets.Rotate", "read error: %v", err)
+	}
+	ch := &pb.Change{}
+	if err := json.Unmarshal(recs[0].Value, ch); err != nil {
+		return errors.InternalServerError("go.micro.config.Secrets.Rotate", "unmarshal value error: %v", err)
+	}
+	if ch.ChangeSet == nil {
+		return nil
+	}
+
+	version := strconv.Itoa(keys.Current)
+	key, err := s.Config.dataKey(keys, version)
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.Secrets.Rotate", "data key error: %v", err)
+	}
+
+	oldData := ch.ChangeSet.Data
+	ch.ChangeSet.Data, err = mapSecrets(oldData, func(v string) (interface{}, error) {
+		plaintext, err := s.Config.decryptValue(keys, v)
+		if err != nil {
+			return nil, err
+		}
+		ciphertext, err := secret.Encrypt(key, plaintext)
+		if err != nil {
+			return nil, err
+		}
+		rsp.Reencrypted++
+		return SecretPrefix + version + ":" + base64.StdEncoding.EncodeToString(ciphertext), nil
+	})
+	if err != nil {
+		return errors.InternalServerError("go.micro.config.Secrets.Rotate", "re-encrypt error: %v", err)
+	}
+	if rsp.Reencrypted == 0 {
+		return nil
+	}
+
+	if recs[0].Value, err = json.Marshal(ch); err != nil {
+		return errors.InternalServerError("go.micro.config.Secrets.Rotate", "marshal error: %v", err)
+	}
+	if err := s.Config.Store.Write(recs[0]); err != nil {
+		return errors.InternalServerError("go.micro.config.Secrets.Rotate", "update into db error: %v", err)
+	}
+	if _, err := s.Config.recordRevision(ctx, namespace, "", actionRotate, oldData, ch.ChangeSet.Data); err != nil {
+		return errors.InternalServerError("go.micro.config.Secrets.Rotate", "record revision error: %v", err)
+	}
+
+	return nil
+}
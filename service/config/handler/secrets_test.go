@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/v2/auth"
+	pb "github.com/micro/go-micro/v2/config/source/service/proto"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/internal/secret"
+	hpb "github.com/micro/micro/v2/service/config/proto"
+)
+
+func TestSecrets(t *testing.T) {
+	key, err := secret.GenerateKey()
+	if err != nil {
+		t.Fatalf("Unexpected error generating key: %v", err)
+	}
+	c := &Config{Store: memory.NewStore(), SecretKey: key}
+
+	encrypted, err := c.encryptValue("micro:global", []byte(`"hunter2"`))
+	if err != nil {
+		t.Fatalf("Unexpected error encrypting value: %v", err)
+	}
+	if !strings.HasPrefix(encrypted, SecretPrefix) || strings.Contains(encrypted, "hunter2") {
+		t.Fatalf("Expected the value to be encrypted but got %v", encrypted)
+	}
+
+	data := `{"db":{"host":"localhost","password":"` + encrypted + `"}}`
+	bytes, _ := json.Marshal(&pb.Change{Namespace: "global", ChangeSet: &pb.ChangeSet{Data: data}})
+	if err := c.Store.Write(&store.Record{Key: "micro:global", Value: bytes}); err != nil {
+		t.Fatalf("Unexpected error writing config: %v", err)
+	}
+
+	admin := auth.ContextWithAccount(context.Background(), &auth.Account{ID: "admin", Scopes: []string{"admin"}})
+	user := auth.ContextWithAccount(context.Background(), &auth.Account{ID: "user", Scopes: []string{"namespace.micro"}})
+
+	revealed, err := c.secretData(admin, "micro:global", data)
+	if err != nil {
+		t.Fatalf("Unexpected error decrypting data: %v", err)
+	}
+	if revealed != `{"db":{"host":"localhost","password":"hunter2"}}` {
+		t.Errorf("Expected the secret to be decrypted but got %v", revealed)
+	}
+
+	redacted, err := c.secretData(user, "micro:global", data)
+	if err != nil {
+		t.Fatalf("Unexpected error redacting data: %v", err)
+	}
+	if redacted != `{"db":{"host":"localhost","password":"`+RedactedValue+`"}}` {
+		t.Errorf("Expected the secret to be redacted but got %v", redacted)
+	}
+
+	// rotating the key should re-encrypt the secret with the new key
+	s := &Secrets{Config: c}
+	ctx := namespace.ContextWithNamespace(admin, "micro")
+	rsp := &hpb.RotateKeyResponse{}
+	if err := s.Rotate(ctx, &hpb.RotateKeyRequest{Namespace: "global"}, rsp); err != nil {
+		t.Fatalf("Unexpected error rotating key: %v", err)
+	}
+	if rsp.Version != 2 || rsp.Reencrypted != 1 {
+		t.Errorf("Expected 1 secret to be re-encrypted with key 2 but got %+v", rsp)
+	}
+
+	rotated, err := c.readData("micro:global")
+	if err != nil {
+		t.Fatalf("Unexpected error reading config: %v", err)
+	}
+	if !strings.Contains(rotated, SecretPrefix+"2:") {
+		t.Errorf("Expected the secret to be encrypted with key 2 but got %v", rotated)
+	}
+	if revealed, _ := c.secretData(admin, "micro:global", rotated); !strings.Contains(revealed, "hunter2") {
+		t.Errorf("Expected the rotated secret to be decrypted but got %v", revealed)
+	}
+
+	// values encrypted with the old key can still be decrypted, e.g. from the history
+	if revealed, _ := c.secretData(admin, "micro:global", data); !strings.Contains(revealed, "hunter2") {
+		t.Errorf("Expected the old secret to be decrypted but got %v", revealed)
+	}
+}
@@ -21,6 +21,26 @@ func (w *watcher) Next() (*proto.WatchResponse, error) {
 	}
 }
 
+// send the change to the watcher without blocking. A change which the watcher hasn't read yet is
+// replaced, changes carry the whole config of the namespace so the watcher still gets the latest
+// config rather than the change being dropped.
+func (w *watcher) send(ch *proto.WatchResponse) {
+	for {
+		select {
+		case w.next <- ch:
+			return
+		case <-w.exit:
+			return
+		default:
+		}
+
+		select {
+		case <-w.next:
+		default:
+		}
+	}
+}
+
 func (w *watcher) Stop() error {
 	select {
 	case <-w.exit:
@@ -50,7 +70,8 @@ func Watch(id string) (*watcher, error) {
 	w := &watcher{
 		id:   id,
 		exit: make(chan bool),
-		next: make(chan *proto.WatchResponse),
+		// changes are buffered so publishing never blocks on a slow watcher
+		next: make(chan *proto.WatchResponse, 1),
 	}
 	watchers[id] = append(watchers[id], w)
 	mtx.Unlock()
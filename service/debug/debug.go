@@ -98,6 +98,7 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 	if err != nil {
 		ulog.Fatal(err)
 	}
+	statsHandler.Auth = service.Options().Auth
 
 	// stats handler
 	traceHandler, err := tracehandler.New(done, ctx.Int("window"), c.services)
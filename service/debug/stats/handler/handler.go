@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/micro/go-micro/v2/auth"
 	"github.com/micro/go-micro/v2/client"
 	"github.com/micro/go-micro/v2/config/cmd"
 	debug "github.com/micro/go-micro/v2/debug/service/proto"
@@ -21,6 +22,7 @@ func New(done <-chan bool, windowSize int, services func() []*registry.Service)
 		client:    *cmd.DefaultOptions().Client,
 		snapshots: ring.New(windowSize),
 		services:  services,
+		written:   make(map[string]*written),
 	}
 
 	s.Start(done)
@@ -30,12 +32,37 @@ func New(done <-chan bool, windowSize int, services func() []*registry.Service)
 // Stats is the Debug.Stats handler
 type Stats struct {
 	client client.Client
+	// Auth verifies the accounts which write stats, they aren't verified if it's nil or noop
+	Auth auth.Auth
 
 	sync.RWMutex
 	// historical snapshots from the start
 	snapshots *ring.Buffer
 	// returns list of services
 	services func() []*registry.Service
+	// snapshots written by the services keyed by service and node
+	written map[string]*written
+}
+
+// written is a snapshot written by a service
+type written struct {
+	snapshot *stats.Snapshot
+	updated  time.Time
+}
+
+var (
+	// WrittenExpiry is how long a written snapshot is kept after it was last written
+	WrittenExpiry = time.Second * 30
+	// WriteScopes are the scopes of the accounts which can write stats, i.e. those of the services
+	WriteScopes = []string{"admin", "service"}
+)
+
+// writtenKey returns the key of a snapshot written by a service
+func writtenKey(service *stats.Service) string {
+	if service.Node == nil {
+		return service.Name
+	}
+	return service.Name + "/" + service.Node.Id
 }
 
 // Read returns gets a snapshot of all current stats
@@ -83,8 +110,69 @@ func (s *Stats) Read(ctx context.Context, req *stats.ReadRequest, rsp *stats.Rea
 	return nil
 }
 
+// Write stores the stats a service reports itself, e.g. the circuit breakers of the api gateway. The
+// stats are merged into the snapshot scraped from the node of the service until they expire.
 func (s *Stats) Write(ctx context.Context, req *stats.WriteRequest, rsp *stats.WriteResponse) error {
-	return errors.BadRequest("go.micro.debug.stats", "not implemented")
+	if req.Stats == nil {
+		return errors.BadRequest("go.micro.debug.stats", "missing stats")
+	}
+	if req.Service == nil {
+		req.Service = req.Stats.Service
+	}
+	if req.Service == nil || len(req.Service.Name) == 0 {
+		return errors.BadRequest("go.micro.debug.stats", "missing service")
+	}
+	req.Stats.Service = req.Service
+
+	if req.Service.Node == nil {
+		return errors.BadRequest("go.micro.debug.stats", "missing node")
+	}
+	if !s.canWrite(ctx) {
+		return errors.Forbidden("go.micro.debug.stats", "write access denied")
+	}
+	if !s.registered(req.Service) {
+		return errors.BadRequest("go.micro.debug.stats", "%v isn't a registered node of %v", req.Service.Node.Id, req.Service.Name)
+	}
+
+	s.Lock()
+	s.written[writtenKey(req.Service)] = &written{snapshot: req.Stats, updated: time.Now()}
+	s.Unlock()
+	return nil
+}
+
+// canWrite returns true if the account making the request has one of the WriteScopes
+func (s *Stats) canWrite(ctx context.Context) bool {
+	if s.Auth == nil || s.Auth.String() == "noop" {
+		return true
+	}
+	acc, ok := auth.AccountFromContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, scope := range acc.Scopes {
+		for _, allowed := range WriteScopes {
+			if scope == allowed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// registered returns true if the node of the service is in the registry, the stats written for
+// nodes which don't exist would otherwise be shown until they expire
+func (s *Stats) registered(service *stats.Service) bool {
+	for _, svc := range s.services() {
+		if svc.Name != service.Name {
+			continue
+		}
+		for _, node := range svc.Nodes {
+			if node.Id == service.Node.Id {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // Stream starts streaming stats
@@ -183,6 +271,35 @@ func (s *Stats) scrape() {
 
 	// Swap in the snapshots
 	s.Lock()
-	s.snapshots.Put(next)
+	s.snapshots.Put(s.merge(next))
 	s.Unlock()
 }
+
+// merge the written snapshots which haven't expired into the scraped snapshots, those of nodes
+// which weren't scraped are added. It's called with the lock held.
+func (s *Stats) merge(scraped []*stats.Snapshot) []*stats.Snapshot {
+	merged := make(map[string]bool)
+	for _, snap := range scraped {
+		w, ok := s.written[writtenKey(snap.Service)]
+		if !ok {
+			continue
+		}
+		snap.Breakers = w.snapshot.Breakers
+		merged[writtenKey(snap.Service)] = true
+	}
+
+	timestamp := uint64(time.Now().Unix())
+	for key, w := range s.written {
+		if time.Since(w.updated) > WrittenExpiry {
+			delete(s.written, key)
+			continue
+		}
+		if merged[key] {
+			continue
+		}
+		snap := *w.snapshot
+		snap.Timestamp = timestamp
+		scraped = append(scraped, &snap)
+	}
+	return scraped
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/micro/go-micro/v2/auth"
+	"github.com/micro/go-micro/v2/registry"
+	"github.com/micro/go-micro/v2/util/ring"
+	stats "github.com/micro/micro/v2/service/debug/stats/proto"
+)
+
+// testAuth is an auth which isn't noop
+type testAuth struct {
+	auth.Auth
+}
+
+func (a *testAuth) String() string {
+	return "test"
+}
+
+func TestWrite(t *testing.T) {
+	s := &Stats{
+		Auth:      &testAuth{},
+		snapshots: ring.New(1),
+		written:   make(map[string]*written),
+		services: func() []*registry.Service {
+			return []*registry.Service{{Name: "go.micro.api", Nodes: []*registry.Node{{Id: "go.micro.api-1"}}}}
+		},
+	}
+	write := func(ctx context.Context, node string) error {
+		svc := &stats.Service{Name: "go.micro.api", Node: &stats.Node{Id: node}}
+		return s.Write(ctx, &stats.WriteRequest{Service: svc, Stats: &stats.Snapshot{}}, &stats.WriteResponse{})
+	}
+
+	svc := auth.ContextWithAccount(context.Background(), &auth.Account{ID: "api", Scopes: []string{"service"}})
+	user := auth.ContextWithAccount(context.Background(), &auth.Account{ID: "john", Scopes: []string{"namespace.micro"}})
+
+	if err := write(context.Background(), "go.micro.api-1"); err == nil {
+		t.Error("Expected a write without an account to be denied")
+	}
+	if err := write(user, "go.micro.api-1"); err == nil {
+		t.Error("Expected a write by a user to be denied")
+	}
+	if err := write(svc, "go.micro.api-2"); err == nil {
+		t.Error("Expected a write for a node which isn't registered to be rejected")
+	}
+	if err := write(svc, "go.micro.api-1"); err != nil {
+		t.Errorf("Unexpected error writing stats: %v", err)
+	}
+	if len(s.written) != 1 {
+		t.Errorf("Expected the stats to be written, got %v", len(s.written))
+	}
+}
@@ -1,5 +1,5 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
-// source: crufter/micro/debug/stats/proto/debug.proto
+// source: github.com/micro/micro/service/debug/stats/proto/debug.proto
 
 package go_micro_debug_stats
 
@@ -35,7 +35,7 @@ func (m *Service) Reset()         { *m = Service{} }
 func (m *Service) String() string { return proto.CompactTextString(m) }
 func (*Service) ProtoMessage()    {}
 func (*Service) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{0}
+	return fileDescriptor_35afed55eef15c68, []int{0}
 }
 
 func (m *Service) XXX_Unmarshal(b []byte) error {
@@ -90,7 +90,7 @@ func (m *Node) Reset()         { *m = Node{} }
 func (m *Node) String() string { return proto.CompactTextString(m) }
 func (*Node) ProtoMessage()    {}
 func (*Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{1}
+	return fileDescriptor_35afed55eef15c68, []int{1}
 }
 
 func (m *Node) XXX_Unmarshal(b []byte) error {
@@ -143,17 +143,19 @@ type Snapshot struct {
 	// Total number of errors
 	Errors uint64 `protobuf:"varint,8,opt,name=errors,proto3" json:"errors,omitempty"`
 	// Timestamp at the time of the taking of the snapshot, seconds since unix epoch
-	Timestamp            uint64   `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Timestamp uint64 `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Circuit breakers of the service, e.g. those of the api gateway
+	Breakers             []*Breaker `protobuf:"bytes,10,rep,name=breakers,proto3" json:"breakers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *Snapshot) Reset()         { *m = Snapshot{} }
 func (m *Snapshot) String() string { return proto.CompactTextString(m) }
 func (*Snapshot) ProtoMessage()    {}
 func (*Snapshot) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{2}
+	return fileDescriptor_35afed55eef15c68, []int{2}
 }
 
 func (m *Snapshot) XXX_Unmarshal(b []byte) error {
@@ -237,6 +239,105 @@ func (m *Snapshot) GetTimestamp() uint64 {
 	return 0
 }
 
+func (m *Snapshot) GetBreakers() []*Breaker {
+	if m != nil {
+		return m.Breakers
+	}
+	return nil
+}
+
+// Breaker is the state of a circuit breaker of an endpoint
+type Breaker struct {
+	// Service and endpoint the breaker guards, e.g. go.micro.api.users and Users.Read
+	Service  string `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Endpoint string `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// State of the breaker: closed, open or half-open
+	State string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// Number of times the breaker has opened
+	Trips uint64 `protobuf:"varint,4,opt,name=trips,proto3" json:"trips,omitempty"`
+	// Number of requests made, failed and rejected by the open breaker
+	Requests             uint64   `protobuf:"varint,5,opt,name=requests,proto3" json:"requests,omitempty"`
+	Failures             uint64   `protobuf:"varint,6,opt,name=failures,proto3" json:"failures,omitempty"`
+	Rejected             uint64   `protobuf:"varint,7,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Breaker) Reset()         { *m = Breaker{} }
+func (m *Breaker) String() string { return proto.CompactTextString(m) }
+func (*Breaker) ProtoMessage()    {}
+func (*Breaker) Descriptor() ([]byte, []int) {
+	return fileDescriptor_35afed55eef15c68, []int{3}
+}
+
+func (m *Breaker) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Breaker.Unmarshal(m, b)
+}
+func (m *Breaker) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Breaker.Marshal(b, m, deterministic)
+}
+func (m *Breaker) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Breaker.Merge(m, src)
+}
+func (m *Breaker) XXX_Size() int {
+	return xxx_messageInfo_Breaker.Size(m)
+}
+func (m *Breaker) XXX_DiscardUnknown() {
+	xxx_messageInfo_Breaker.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Breaker proto.InternalMessageInfo
+
+func (m *Breaker) GetService() string {
+	if m != nil {
+		return m.Service
+	}
+	return ""
+}
+
+func (m *Breaker) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *Breaker) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *Breaker) GetTrips() uint64 {
+	if m != nil {
+		return m.Trips
+	}
+	return 0
+}
+
+func (m *Breaker) GetRequests() uint64 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+func (m *Breaker) GetFailures() uint64 {
+	if m != nil {
+		return m.Failures
+	}
+	return 0
+}
+
+func (m *Breaker) GetRejected() uint64 {
+	if m != nil {
+		return m.Rejected
+	}
+	return 0
+}
+
 type ReadRequest struct {
 	// If set, only return services matching the filter
 	Service *Service `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
@@ -252,7 +353,7 @@ func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
 func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadRequest) ProtoMessage()    {}
 func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{3}
+	return fileDescriptor_35afed55eef15c68, []int{4}
 }
 
 func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
@@ -298,7 +399,7 @@ func (m *ReadResponse) Reset()         { *m = ReadResponse{} }
 func (m *ReadResponse) String() string { return proto.CompactTextString(m) }
 func (*ReadResponse) ProtoMessage()    {}
 func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{4}
+	return fileDescriptor_35afed55eef15c68, []int{5}
 }
 
 func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
@@ -340,7 +441,7 @@ func (m *WriteRequest) Reset()         { *m = WriteRequest{} }
 func (m *WriteRequest) String() string { return proto.CompactTextString(m) }
 func (*WriteRequest) ProtoMessage()    {}
 func (*WriteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{5}
+	return fileDescriptor_35afed55eef15c68, []int{6}
 }
 
 func (m *WriteRequest) XXX_Unmarshal(b []byte) error {
@@ -385,7 +486,7 @@ func (m *WriteResponse) Reset()         { *m = WriteResponse{} }
 func (m *WriteResponse) String() string { return proto.CompactTextString(m) }
 func (*WriteResponse) ProtoMessage()    {}
 func (*WriteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{6}
+	return fileDescriptor_35afed55eef15c68, []int{7}
 }
 
 func (m *WriteResponse) XXX_Unmarshal(b []byte) error {
@@ -420,7 +521,7 @@ func (m *StreamRequest) Reset()         { *m = StreamRequest{} }
 func (m *StreamRequest) String() string { return proto.CompactTextString(m) }
 func (*StreamRequest) ProtoMessage()    {}
 func (*StreamRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{7}
+	return fileDescriptor_35afed55eef15c68, []int{8}
 }
 
 func (m *StreamRequest) XXX_Unmarshal(b []byte) error {
@@ -466,7 +567,7 @@ func (m *StreamResponse) Reset()         { *m = StreamResponse{} }
 func (m *StreamResponse) String() string { return proto.CompactTextString(m) }
 func (*StreamResponse) ProtoMessage()    {}
 func (*StreamResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_8f2de2571cb9c61f, []int{8}
+	return fileDescriptor_35afed55eef15c68, []int{9}
 }
 
 func (m *StreamResponse) XXX_Unmarshal(b []byte) error {
@@ -498,6 +599,7 @@ func init() {
 	proto.RegisterType((*Service)(nil), "go.micro.debug.stats.Service")
 	proto.RegisterType((*Node)(nil), "go.micro.debug.stats.Node")
 	proto.RegisterType((*Snapshot)(nil), "go.micro.debug.stats.Snapshot")
+	proto.RegisterType((*Breaker)(nil), "go.micro.debug.stats.Breaker")
 	proto.RegisterType((*ReadRequest)(nil), "go.micro.debug.stats.ReadRequest")
 	proto.RegisterType((*ReadResponse)(nil), "go.micro.debug.stats.ReadResponse")
 	proto.RegisterType((*WriteRequest)(nil), "go.micro.debug.stats.WriteRequest")
@@ -507,39 +609,44 @@ func init() {
 }
 
 func init() {
-	proto.RegisterFile("crufter/micro/debug/stats/proto/debug.proto", fileDescriptor_8f2de2571cb9c61f)
-}
-
-var fileDescriptor_8f2de2571cb9c61f = []byte{
-	// 465 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xa4, 0x54, 0x5d, 0x8b, 0xd3, 0x40,
-	0x14, 0xa5, 0x6d, 0xfa, 0x75, 0xbb, 0xbb, 0xc2, 0x20, 0x32, 0x04, 0x15, 0x1d, 0x7d, 0x10, 0x84,
-	0x74, 0xa9, 0x82, 0x7f, 0x40, 0x7c, 0x53, 0x64, 0x8a, 0x08, 0xbe, 0x65, 0x93, 0xbb, 0xd9, 0x3c,
-	0x24, 0x13, 0x67, 0x26, 0x0b, 0x3e, 0xf8, 0x03, 0xfc, 0xd5, 0x3a, 0x73, 0x67, 0xb2, 0x5b, 0xa1,
-	0x2d, 0x62, 0xdf, 0xee, 0x3d, 0x73, 0xee, 0x39, 0xf7, 0x23, 0x2d, 0xbc, 0x2e, 0x74, 0x7f, 0x6d,
-	0x51, 0xaf, 0x9b, 0xba, 0xd0, 0x6a, 0x5d, 0xe2, 0x55, 0x5f, 0xad, 0x8d, 0xcd, 0xad, 0x59, 0x77,
-	0x5a, 0xd9, 0x88, 0x64, 0x14, 0xb3, 0x87, 0x95, 0xca, 0x88, 0x97, 0x05, 0x94, 0x78, 0xa2, 0x82,
-	0xf9, 0x16, 0xf5, 0x6d, 0x5d, 0x20, 0x63, 0x90, 0xb4, 0x79, 0x83, 0x7c, 0xf4, 0x6c, 0xf4, 0x6a,
-	0x29, 0x29, 0x66, 0x1c, 0xe6, 0xb7, 0xa8, 0x4d, 0xad, 0x5a, 0x3e, 0x26, 0x78, 0x48, 0x59, 0xe6,
-	0xd8, 0xaa, 0x44, 0x3e, 0x71, 0xf0, 0x6a, 0x93, 0x66, 0xfb, 0xd4, 0xb3, 0x4f, 0x8e, 0x21, 0x89,
-	0x27, 0x2e, 0x21, 0xf1, 0x19, 0xbb, 0x80, 0x71, 0x5d, 0x46, 0x0f, 0x17, 0x79, 0x87, 0xbc, 0x2c,
-	0x35, 0x1a, 0x33, 0x38, 0xc4, 0x54, 0xfc, 0x1a, 0xc3, 0x62, 0xdb, 0xe6, 0x9d, 0xb9, 0x51, 0x96,
-	0xbd, 0x83, 0xb9, 0x09, 0x7d, 0x52, 0xed, 0x6a, 0xf3, 0x64, 0xbf, 0x63, 0x1c, 0x46, 0x0e, 0x6c,
-	0xaf, 0xef, 0x5e, 0xb4, 0xc5, 0x92, 0xf4, 0x27, 0x72, 0x48, 0xd9, 0x23, 0x98, 0xf5, 0x9d, 0xad,
-	0x9b, 0x30, 0x43, 0x22, 0x63, 0xe6, 0xf1, 0x06, 0x1b, 0xa5, 0x7f, 0xf0, 0x24, 0xe0, 0x21, 0xf3,
-	0x4a, 0xf6, 0x46, 0x63, 0x5e, 0x1a, 0x3e, 0xa5, 0x87, 0x21, 0xf5, 0x33, 0x55, 0x05, 0x9f, 0x11,
-	0xe8, 0x22, 0x96, 0xc2, 0x42, 0xe3, 0xf7, 0x1e, 0x8d, 0x35, 0x7c, 0x4e, 0xe8, 0x5d, 0xee, 0xd5,
-	0x51, 0x6b, 0xa5, 0x0d, 0x5f, 0x04, 0xf5, 0x90, 0xb1, 0xc7, 0xb0, 0xf4, 0xee, 0xae, 0xb9, 0xa6,
-	0xe3, 0x4b, 0x7a, 0xba, 0x07, 0xc4, 0x37, 0x58, 0x49, 0x67, 0x25, 0x83, 0xca, 0xff, 0x6f, 0xc3,
-	0xdd, 0xb8, 0xcb, 0x8d, 0xa5, 0x55, 0x2c, 0x24, 0xc5, 0xe2, 0x3d, 0x9c, 0x05, 0x6d, 0xd3, 0xa9,
-	0xd6, 0x20, 0x7b, 0x0b, 0x53, 0xaa, 0x76, 0xd2, 0x13, 0x27, 0xfd, 0xf4, 0x80, 0x74, 0xbc, 0x8c,
-	0x0c, 0x64, 0xf1, 0x13, 0xce, 0xbe, 0xea, 0xda, 0xe2, 0xc9, 0x2d, 0xde, 0xd9, 0x8f, 0xa9, 0xec,
-	0x1f, 0xed, 0x1f, 0xc0, 0x79, 0xb4, 0x0f, 0x53, 0x88, 0x6b, 0x38, 0xdf, 0x5a, 0x77, 0x9e, 0xe6,
-	0xe4, 0x86, 0xdc, 0x65, 0xfc, 0x6f, 0xc1, 0x74, 0xb9, 0x2b, 0x0d, 0xdf, 0xe8, 0x3d, 0x20, 0x3e,
-	0xc0, 0xc5, 0xe0, 0x73, 0xca, 0xfe, 0x36, 0xbf, 0x47, 0x30, 0xdd, 0xfa, 0x88, 0x7d, 0x84, 0xc4,
-	0xdf, 0x83, 0x3d, 0xdf, 0x5f, 0xb8, 0xf3, 0x1d, 0xa4, 0xe2, 0x18, 0x25, 0xb6, 0xf3, 0x19, 0xa6,
-	0xb4, 0x19, 0x76, 0x80, 0xbc, 0x7b, 0xb5, 0xf4, 0xc5, 0x51, 0x4e, 0x54, 0xfc, 0x02, 0xb3, 0x30,
-	0x32, 0x3b, 0x40, 0xff, 0x6b, 0xf1, 0xe9, 0xcb, 0xe3, 0xa4, 0x20, 0x7a, 0x39, 0xba, 0x9a, 0xd1,
-	0xff, 0xd4, 0x9b, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x8b, 0xbf, 0x54, 0x1e, 0xd6, 0x04, 0x00,
-	0x00,
+	proto.RegisterFile("github.com/micro/micro/service/debug/stats/proto/debug.proto", fileDescriptor_35afed55eef15c68)
+}
+
+var fileDescriptor_35afed55eef15c68 = []byte{
+	// 558 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa5, 0x54, 0x4d, 0x8b, 0xd4, 0x40,
+	0x10, 0x65, 0x3e, 0x32, 0x49, 0x6a, 0x76, 0x57, 0x68, 0x16, 0x69, 0x82, 0xca, 0x1a, 0x3d, 0x78,
+	0xca, 0x2c, 0xa3, 0xb0, 0x08, 0x9e, 0x44, 0xbc, 0x29, 0x92, 0x41, 0x04, 0x6f, 0x99, 0x49, 0x6f,
+	0x26, 0x6a, 0xd2, 0xb1, 0xbb, 0xb3, 0xe0, 0x61, 0x7f, 0x9b, 0x17, 0xff, 0x97, 0x76, 0x77, 0x75,
+	0x32, 0xb3, 0x32, 0x33, 0x88, 0x73, 0x09, 0xf5, 0xaa, 0x5f, 0xbf, 0xea, 0x7a, 0x55, 0x04, 0x5e,
+	0x15, 0xa5, 0x5a, 0xb7, 0xcb, 0x64, 0xc5, 0xab, 0x59, 0x55, 0xae, 0x04, 0x77, 0x5f, 0xc9, 0xc4,
+	0x4d, 0xb9, 0x62, 0xb3, 0x9c, 0x2d, 0xdb, 0x62, 0x26, 0x55, 0xa6, 0xe4, 0xac, 0x11, 0x5c, 0x71,
+	0xcc, 0x24, 0x36, 0x26, 0xe7, 0x05, 0x4f, 0x2c, 0x3f, 0xc1, 0xac, 0xe5, 0xc5, 0x05, 0xf8, 0x0b,
+	0xbc, 0x4e, 0x08, 0x8c, 0xeb, 0xac, 0x62, 0x74, 0x70, 0x31, 0x78, 0x16, 0xa6, 0x36, 0x26, 0x14,
+	0xfc, 0x1b, 0x26, 0x64, 0xc9, 0x6b, 0x3a, 0xb4, 0xe9, 0x0e, 0x92, 0x44, 0xb3, 0x79, 0xce, 0xe8,
+	0x48, 0xa7, 0xa7, 0xf3, 0x28, 0xd9, 0xa5, 0x9e, 0xbc, 0xd7, 0x8c, 0xd4, 0xf2, 0xe2, 0x4b, 0x18,
+	0x1b, 0x44, 0xce, 0x60, 0x58, 0xe6, 0xae, 0x86, 0x8e, 0x4c, 0x85, 0x2c, 0xcf, 0x05, 0x93, 0xb2,
+	0xab, 0xe0, 0x60, 0xfc, 0x6b, 0x08, 0xc1, 0xa2, 0xce, 0x1a, 0xb9, 0xe6, 0x8a, 0x5c, 0x81, 0xef,
+	0xda, 0xb4, 0x77, 0xa7, 0xf3, 0x87, 0xbb, 0x2b, 0xba, 0x66, 0xd2, 0x8e, 0x6d, 0xf4, 0xf5, 0x89,
+	0x50, 0x2c, 0xb7, 0xfa, 0xa3, 0xb4, 0x83, 0xe4, 0x3e, 0x4c, 0xda, 0x46, 0x95, 0x15, 0xf6, 0x30,
+	0x4e, 0x1d, 0x32, 0xf9, 0x8a, 0x55, 0x5c, 0xfc, 0xa0, 0x63, 0xcc, 0x23, 0x32, 0x4a, 0x6a, 0x2d,
+	0x58, 0x96, 0x4b, 0xea, 0xd9, 0x83, 0x0e, 0x9a, 0x9e, 0x8a, 0x15, 0x9d, 0xd8, 0xa4, 0x8e, 0x48,
+	0x04, 0x81, 0x60, 0xdf, 0x5b, 0x26, 0x95, 0xa4, 0xbe, 0xcd, 0xf6, 0xd8, 0xa8, 0x33, 0x21, 0xb8,
+	0x90, 0x34, 0x40, 0x75, 0x44, 0xe4, 0x01, 0x84, 0xa6, 0xba, 0x7e, 0x5c, 0xd5, 0xd0, 0xd0, 0x1e,
+	0x6d, 0x12, 0xe4, 0x25, 0x04, 0x4b, 0x5d, 0xeb, 0xab, 0x36, 0x9f, 0xc2, 0xc5, 0x68, 0x7f, 0xff,
+	0xaf, 0x91, 0x95, 0xf6, 0xf4, 0xf8, 0xe7, 0x00, 0x7c, 0x97, 0xb5, 0x66, 0x6c, 0xb9, 0x18, 0x6e,
+	0x6c, 0xd2, 0x4f, 0x66, 0x75, 0xde, 0xf0, 0xb2, 0x56, 0x6e, 0x0e, 0x3d, 0x26, 0xe7, 0xe0, 0x19,
+	0x71, 0xf4, 0x29, 0x4c, 0x11, 0x98, 0xac, 0x12, 0x65, 0x23, 0x9d, 0x4b, 0x08, 0xee, 0xb4, 0xee,
+	0xfd, 0xd5, 0xba, 0x3e, 0xbb, 0xce, 0xca, 0x6f, 0xad, 0x9e, 0xae, 0x33, 0xab, 0xc7, 0x78, 0xef,
+	0x0b, 0x5b, 0x99, 0x39, 0xf5, 0x96, 0x21, 0x8e, 0x3f, 0xc3, 0x34, 0xd5, 0x3e, 0xa7, 0xa8, 0xf3,
+	0xff, 0xab, 0xa0, 0x17, 0xbc, 0xc9, 0x24, 0xf6, 0x17, 0xa4, 0x36, 0x8e, 0xdf, 0xc0, 0x09, 0x6a,
+	0xcb, 0x86, 0xd7, 0x92, 0x91, 0x17, 0xd8, 0xab, 0xd4, 0xd2, 0xc6, 0xe5, 0x47, 0x7b, 0xa4, 0xdd,
+	0x5a, 0xa2, 0x17, 0x32, 0xbe, 0x85, 0x93, 0x4f, 0xa2, 0x54, 0xec, 0xe8, 0x27, 0xf6, 0xe5, 0x87,
+	0xf6, 0xda, 0x3f, 0x96, 0xbf, 0x07, 0xa7, 0xae, 0x3c, 0x76, 0x11, 0x5f, 0xc3, 0xe9, 0x42, 0xe9,
+	0x99, 0x57, 0x47, 0x3f, 0x48, 0xaf, 0xa5, 0xf9, 0x11, 0xc8, 0x26, 0xd3, 0x57, 0x71, 0x31, 0x36,
+	0x89, 0xf8, 0x2d, 0x9c, 0x75, 0x75, 0x8e, 0xf1, 0x6f, 0xfe, 0x7b, 0x00, 0xde, 0xc2, 0x44, 0xe4,
+	0x1d, 0x8c, 0xcd, 0x3c, 0xc8, 0xe3, 0xdd, 0x17, 0xb7, 0xf6, 0x20, 0x8a, 0x0f, 0x51, 0xdc, 0x73,
+	0x3e, 0x80, 0x67, 0x9d, 0x21, 0x7b, 0xc8, 0xdb, 0x53, 0x8b, 0x9e, 0x1c, 0xe4, 0x38, 0xc5, 0x8f,
+	0x30, 0xc1, 0x96, 0xc9, 0x1e, 0xfa, 0x1d, 0xe3, 0xa3, 0xa7, 0x87, 0x49, 0x28, 0x7a, 0x39, 0x58,
+	0x4e, 0xec, 0x4f, 0xfa, 0xf9, 0x1f, 0x5c, 0xff, 0x4b, 0xf3, 0xe4, 0x05, 0x00, 0x00,
 }
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/micro/v2/service/runtime/manager"
+	pb "github.com/micro/micro/v2/service/runtime/proto"
+)
+
+// Jobs returns the jobs run by the runtime and the history of their runs
+type Jobs struct {
+	// The manager running the jobs
+	Manager manager.Jobs
+}
+
+// List the jobs in the namespace
+func (j *Jobs) List(ctx context.Context, req *pb.ListJobsRequest, rsp *pb.ListJobsResponse) error {
+	jobs, err := j.Manager.ListJobs(getNamespace(ctx))
+	if err != nil {
+		return errors.InternalServerError("go.micro.runtime", err.Error())
+	}
+	rsp.Jobs = jobs
+	return nil
+}
+
+// History returns the most recent runs of a job
+func (j *Jobs) History(ctx context.Context, req *pb.JobHistoryRequest, rsp *pb.JobHistoryResponse) error {
+	if len(req.Service) == 0 {
+		return errors.BadRequest("go.micro.runtime", "missing service")
+	}
+
+	runs, err := j.Manager.JobHistory(getNamespace(ctx), &runtime.Service{
+		Name:    req.Service,
+		Version: req.Version,
+	})
+	if err != nil {
+		return errors.InternalServerError("go.micro.runtime", err.Error())
+	}
+
+	if req.Limit > 0 && int64(len(runs)) > req.Limit {
+		runs = runs[int64(len(runs))-req.Limit:]
+	}
+	rsp.Runs = runs
+
+	return nil
+}
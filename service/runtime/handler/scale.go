@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/micro/v2/service/runtime/manager"
+	pb "github.com/micro/micro/v2/service/runtime/proto"
+	"github.com/micro/micro/v2/service/runtime/scale"
+)
+
+// Scale sets the replicas of services
+type Scale struct {
+	// The manager running the replicas
+	Manager manager.Scaler
+}
+
+// Set the replicas of a service
+func (s *Scale) Set(ctx context.Context, req *pb.ScaleRequest, rsp *pb.ScaleResponse) error {
+	if len(req.Service) == 0 {
+		return errors.BadRequest("go.micro.runtime", "missing service")
+	}
+	if req.Replicas < 0 || req.MinReplicas < 0 || req.MaxReplicas < 0 || req.TargetRate < 0 {
+		return errors.BadRequest("go.micro.runtime", "invalid scale")
+	}
+
+	sc := &scale.Scale{
+		Replicas:   int(req.Replicas),
+		Min:        int(req.MinReplicas),
+		Max:        int(req.MaxReplicas),
+		TargetRate: req.TargetRate,
+	}
+	if sc.Min == 0 {
+		sc.Min = 1
+	}
+
+	srv := &runtime.Service{Name: req.Service, Version: req.Version}
+	if err := s.Manager.Scale(getNamespace(ctx), srv, sc); err != nil {
+		return errors.InternalServerError("go.micro.runtime", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/micro/v2/service/runtime/manager"
+	pb "github.com/micro/micro/v2/service/runtime/proto"
+)
+
+// Secrets stores the secrets referenced by services
+type Secrets struct {
+	// The manager storing the secrets
+	Manager manager.Secrets
+}
+
+// Set a secret
+func (s *Secrets) Set(ctx context.Context, req *pb.SetSecretRequest, rsp *pb.SetSecretResponse) error {
+	if len(req.Name) == 0 {
+		return errors.BadRequest("go.micro.runtime", "missing name")
+	}
+
+	if err := s.Manager.SetSecret(getNamespace(ctx), req.Name, req.Value); err != nil {
+		return errors.InternalServerError("go.micro.runtime", err.Error())
+	}
+	return nil
+}
+
+// Delete a secret
+func (s *Secrets) Delete(ctx context.Context, req *pb.DeleteSecretRequest, rsp *pb.DeleteSecretResponse) error {
+	if len(req.Name) == 0 {
+		return errors.BadRequest("go.micro.runtime", "missing name")
+	}
+
+	if err := s.Manager.DeleteSecret(getNamespace(ctx), req.Name); err != nil {
+		return errors.InternalServerError("go.micro.runtime", err.Error())
+	}
+	return nil
+}
+
+// List the names of the secrets
+func (s *Secrets) List(ctx context.Context, req *pb.ListSecretsRequest, rsp *pb.ListSecretsResponse) error {
+	names, err := s.Manager.ListSecrets(getNamespace(ctx))
+	if err != nil {
+		return errors.InternalServerError("go.micro.runtime", err.Error())
+	}
+	rsp.Names = names
+	return nil
+}
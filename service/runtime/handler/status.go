@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/micro/go-micro/v2/errors"
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/micro/v2/service/runtime/manager"
+	pb "github.com/micro/micro/v2/service/runtime/proto"
+)
+
+// Status returns the status timeline of the services managed by the runtime
+type Status struct {
+	// The manager recording the timeline
+	Manager manager.Timeline
+}
+
+// Timeline returns the most recent status events for a service
+func (s *Status) Timeline(ctx context.Context, req *pb.TimelineRequest, rsp *pb.TimelineResponse) error {
+	events, err := s.Manager.Timeline(getNamespace(ctx), &runtime.Service{
+		Name:    req.Service,
+		Version: req.Version,
+	})
+	if err != nil {
+		return errors.InternalServerError("go.micro.runtime", err.Error())
+	}
+
+	if req.Limit > 0 && int64(len(events)) > req.Limit {
+		events = events[int64(len(events))-req.Limit:]
+	}
+	rsp.Events = events
+
+	return nil
+}
+
+// Watch streams status events as they're recorded
+func (s *Status) Watch(ctx context.Context, req *pb.WatchRequest, stream pb.Status_WatchStream) error {
+	w, err := s.Manager.WatchTimeline(getNamespace(ctx))
+	if err != nil {
+		return errors.InternalServerError("go.micro.runtime", err.Error())
+	}
+	defer w.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.Chan():
+			if !ok {
+				return nil
+			}
+			if len(req.Service) > 0 && ev.Service != req.Service {
+				continue
+			}
+			if len(req.Version) > 0 && ev.Version != req.Version {
+				continue
+			}
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		}
+	}
+}
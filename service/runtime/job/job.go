@@ -0,0 +1,80 @@
+// Package job defines the services which are run to completion by the runtime, either once or on
+// a cron schedule
+package job
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	// Type is the runtime type of a job, e.g. micro run --type job
+	Type = "job"
+	// ScheduleKey is the service metadata key for the cron schedule, e.g. */5 * * * *
+	ScheduleKey = "schedule"
+	// ConcurrencyKey is the service metadata key for the concurrency policy
+	ConcurrencyKey = "concurrency"
+	// RetriesKey is the service metadata key for the number of times a failed run is retried
+	RetriesKey = "max_retries"
+)
+
+// Concurrency policies determine what happens when a scheduled run is due and the previous run
+// of the job has not yet completed
+const (
+	// Allow runs to happen concurrently
+	Allow = "allow"
+	// Forbid a new run, the scheduled run is skipped
+	Forbid = "forbid"
+	// Replace the existing run with the new one
+	Replace = "replace"
+)
+
+// The status of a run
+const (
+	Running   = "running"
+	Succeeded = "succeeded"
+	Failed    = "failed"
+	Replaced  = "replaced"
+	Stopped   = "stopped"
+)
+
+// Job is the configuration of a job parsed from the service metadata
+type Job struct {
+	// Schedule is nil if the job is only run once
+	Schedule *Schedule
+	// Concurrency policy, defaults to Allow
+	Concurrency string
+	// Retries of a failed run
+	Retries int
+}
+
+// FromMetadata parses the job configuration from the service metadata
+func FromMetadata(md map[string]string) (*Job, error) {
+	j := &Job{Concurrency: Allow}
+
+	if v := md[ScheduleKey]; len(v) > 0 {
+		s, err := Parse(v)
+		if err != nil {
+			return nil, err
+		}
+		j.Schedule = s
+	}
+
+	switch v := md[ConcurrencyKey]; v {
+	case "":
+	case Allow, Forbid, Replace:
+		j.Concurrency = v
+	default:
+		return nil, fmt.Errorf("Invalid concurrency policy %v, expected allow, forbid or replace", v)
+	}
+
+	if v := md[RetriesKey]; len(v) > 0 {
+		r, err := strconv.Atoi(v)
+		if err != nil || r < 0 {
+			return nil, fmt.Errorf("Invalid retries: %v", v)
+		}
+		j.Retries = r
+	}
+
+	return j, nil
+}
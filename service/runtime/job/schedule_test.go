@@ -0,0 +1,53 @@
+package job
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	valid := []string{"* * * * *", "*/5 * * * *", "0 2 * * 1-5", "0,30 9-17 * * *", "@daily", "0 0 1 1 7"}
+	for _, expr := range valid {
+		if _, err := Parse(expr); err != nil {
+			t.Errorf("Expected %q to be valid but got: %v", expr, err)
+		}
+	}
+
+	invalid := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "a * * * *", "5-1 * * * *", "@never"}
+	for _, expr := range invalid {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Expected %q to be invalid", expr)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	// Wednesday 1st July 2020 10:02:30
+	now := time.Date(2020, 7, 1, 10, 2, 30, 0, time.UTC)
+
+	tt := []struct {
+		Expr string
+		Next time.Time
+	}{
+		{"* * * * *", time.Date(2020, 7, 1, 10, 3, 0, 0, time.UTC)},
+		{"*/5 * * * *", time.Date(2020, 7, 1, 10, 5, 0, 0, time.UTC)},
+		{"0 2 * * *", time.Date(2020, 7, 2, 2, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2020, 7, 1, 11, 0, 0, 0, time.UTC)},
+		{"30 9 * * 0", time.Date(2020, 7, 5, 9, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// either the day of month or week matches when both are set
+		{"0 0 15 * 5", time.Date(2020, 7, 3, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tc := range tt {
+		s, err := Parse(tc.Expr)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %v", tc.Expr, err)
+		}
+		if next := s.Next(now); !next.Equal(tc.Next) {
+			t.Errorf("Expected the next run of %q to be %v but got %v", tc.Expr, tc.Next, next)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/micro/go-micro/v2/runtime"
 	"github.com/micro/go-micro/v2/store"
 	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/service/runtime/resource"
 )
 
 var (
@@ -103,29 +104,34 @@ func (m *manager) processEvent(key string) {
 	logger.Infof("Processing %v event for service %v:%v in namespace %v", ev.Type, ev.Service.Name, ev.Service.Version, ns)
 
 	// apply the event to the managed runtime
-	switch ev.Type {
-	case runtime.Delete:
-		err = m.Runtime.Delete(ev.Service, runtime.DeleteNamespace(ns))
-	case runtime.Update:
-		err = m.Runtime.Update(ev.Service, runtime.UpdateNamespace(ns))
-	case runtime.Create:
-		err = m.Runtime.Create(ev.Service,
-			runtime.CreateImage(ev.Options.Image),
-			runtime.CreateType(ev.Options.Type),
-			runtime.CreateNamespace(ns),
-			runtime.WithArgs(ev.Options.Args...),
-			runtime.WithCommand(ev.Options.Command...),
-			runtime.WithEnv(m.runtimeEnv(ev.Options)),
-		)
-	}
-
-	// if there was an error update the status in the cache
+	switch {
+	case isJob(ev.Options):
+		// jobs are run by the manager rather than being kept running by the runtime, the next run
+		// of a job will use the updated service
+		if ev.Type == runtime.Create {
+			err = m.createJob(ns, ev.Service, ev.Options)
+		} else if ev.Type == runtime.Delete {
+			err = m.deleteJob(ns, ev.Service)
+		}
+	case ev.Type == runtime.Delete:
+		err = m.deleteReplicas(ns, ev.Service)
+	case ev.Type == runtime.Update:
+		err = m.updateReplicas(ns, ev.Service)
+	case ev.Type == runtime.Create:
+		err = m.createReplicas(ns, ev.Service, ev.Options)
+	}
+
+	// if there was an error update the status in the cache. Jobs don't have a status of their own
+	// since the status of each run is recorded instead.
 	if err != nil {
 		logger.Warnf("Error processing %v event for service %v:%v in namespace %v: %v", ev.Type, ev.Service.Name, ev.Service.Version, ns, err)
 		ev.Service.Metadata = map[string]string{"status": "error", "error": err.Error()}
-		m.cacheStatus(ns, ev.Service)
-	} else if ev.Type != runtime.Delete {
-		m.cacheStatus(ns, ev.Service)
+		m.updateStatus(ns, ev.Service)
+	} else if ev.Type == runtime.Delete {
+		m.deleteStatus(ns, ev.Service)
+		m.deleteCgroup(ns, ev.Service)
+	} else if !isJob(ev.Options) {
+		m.updateStatus(ns, ev.Service)
 	}
 
 	// write to the store indicating the event has been consumed. We double the ttl to safely know the
@@ -133,8 +139,31 @@ func (m *manager) processEvent(key string) {
 	m.cache.Write(&store.Record{Key: key, Expiry: eventTTL * 2})
 }
 
+// createInRuntime creates the service in the managed runtime using the options it was created with
+func (m *manager) createInRuntime(ns string, srv *runtime.Service, opts *runtime.CreateOptions) error {
+	if opts == nil {
+		opts = &runtime.CreateOptions{}
+	}
+
+	// secrets are resolved just before the service is created so they're never persisted
+	env, err := m.runtimeEnv(ns, srv, opts)
+	if err != nil {
+		return err
+	}
+
+	return m.Runtime.Create(srv,
+		runtime.CreateImage(opts.Image),
+		runtime.CreateType(opts.Type),
+		runtime.CreateNamespace(ns),
+		runtime.WithArgs(opts.Args...),
+		runtime.WithCommand(opts.Command...),
+		runtime.WithEnv(env),
+	)
+}
+
 // runtimeEnv returns the environment variables which should  be used when creating a service.
-func (m *manager) runtimeEnv(options *runtime.CreateOptions) []string {
+// Any env vars referencing a secret are set to the value of the secret.
+func (m *manager) runtimeEnv(ns string, srv *runtime.Service, options *runtime.CreateOptions) ([]string, error) {
 	setEnv := func(p []string, env map[string]string) {
 		for _, v := range p {
 			parts := strings.Split(v, "=")
@@ -159,6 +188,16 @@ func (m *manager) runtimeEnv(options *runtime.CreateOptions) []string {
 		env["MICRO_NAMESPACE"] = options.Namespace
 	}
 
+	// mark the processes of services with resource limits so they can be moved into their cgroup
+	if res, err := resource.FromMetadata(srv.Metadata); err == nil && res != nil {
+		env[cgroupEnv] = cgroupName(ns, srv)
+	}
+
+	// resolve the secrets
+	if err := m.resolveSecrets(ns, env); err != nil {
+		return nil, err
+	}
+
 	// create a new env
 	var vars []string
 	for k, v := range env {
@@ -166,5 +205,5 @@ func (m *manager) runtimeEnv(options *runtime.CreateOptions) []string {
 	}
 
 	// setup the runtime env
-	return vars
+	return vars, nil
 }
@@ -0,0 +1,485 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/service/runtime/job"
+	pb "github.com/micro/micro/v2/service/runtime/proto"
+)
+
+const (
+	// runPrefix is prefixed to the key for job run records
+	runPrefix = "run:"
+	// schedulePrefix is prefixed to the key of the time a job was last scheduled, it's written to the
+	// store so the schedule carries on from the last run when the manager restarts
+	schedulePrefix = "schedule:"
+)
+
+var (
+	// jobPollFrequency is the frequency the manager checks for scheduled runs and completed runs
+	jobPollFrequency = time.Second * 10
+	// jobHistoryLimit is the max number of runs kept for each job
+	jobHistoryLimit = 20
+	// jobHistoryTTL is the duration runs will persist in the store before expiring
+	jobHistoryTTL = time.Hour * 24 * 7
+	// jobLogLines is the number of log lines kept for each run
+	jobLogLines int64 = 50
+)
+
+// Jobs is implemented by the manager to return the jobs it runs and the history of their runs
+type Jobs interface {
+	// ListJobs returns the jobs in a namespace
+	ListJobs(ns string) ([]*pb.Job, error)
+	// JobHistory returns the runs of a job, oldest first
+	JobHistory(ns string, srv *runtime.Service) ([]*pb.JobRun, error)
+}
+
+// isJob returns true if the service should be run to completion rather than kept running
+func isJob(opts *runtime.CreateOptions) bool {
+	return opts != nil && opts.Type == job.Type
+}
+
+// runVersion is the version used in the runtime for a run of a job, e.g. latest..9b2a8c1f
+func runVersion(version, id string) string {
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	return version + versionSeparator + id
+}
+
+// runKey is the key to write a run to the store under, runs are ordered by the time they started
+func runKey(ns string, run *pb.JobRun) string {
+	return fmt.Sprintf("%v%v:%v:%v:%020d:%v", runPrefix, ns, run.Service, run.Version, run.Started, run.Id)
+}
+
+// scheduleKey is the key the time a job was last scheduled is written to the store under
+func scheduleKey(ns string, srv *runtime.Service) string {
+	return fmt.Sprintf("%v%v:%v:%v", schedulePrefix, ns, srv.Name, srv.Version)
+}
+
+// readSchedule returns the time a job was last scheduled, store.ErrNotFound if it hasn't been
+func (m *manager) readSchedule(ns string, srv *runtime.Service) (time.Time, error) {
+	recs, err := m.options.Store.Read(scheduleKey(ns, srv))
+	if err != nil {
+		return time.Time{}, err
+	}
+	last, err := strconv.ParseInt(string(recs[0].Value), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(last, 0), nil
+}
+
+// writeSchedule records the time a job was last scheduled
+func (m *manager) writeSchedule(ns string, srv *runtime.Service, t time.Time) error {
+	return m.options.Store.Write(&store.Record{Key: scheduleKey(ns, srv), Value: []byte(strconv.FormatInt(t.Unix(), 10))})
+}
+
+// createJob runs a job which isn't scheduled, scheduled jobs are run by syncJobs
+func (m *manager) createJob(ns string, srv *runtime.Service, opts *runtime.CreateOptions) error {
+	j, err := job.FromMetadata(srv.Metadata)
+	if err != nil {
+		return err
+	}
+	if j.Schedule != nil {
+		return nil
+	}
+
+	_, err = m.startRun(ns, &service{Service: srv, Options: opts})
+	return err
+}
+
+// deleteJob stops any runs of the job
+func (m *manager) deleteJob(ns string, srv *runtime.Service) error {
+	runs, err := m.readRuns(ns, srv)
+	if err != nil {
+		return err
+	}
+	for _, run := range runs {
+		if run.Status == job.Running {
+			m.stopRun(ns, run, job.Stopped)
+		}
+	}
+
+	if err := m.options.Store.Delete(scheduleKey(ns, srv)); err != nil && err != store.ErrNotFound {
+		return err
+	}
+	return nil
+}
+
+// startRun creates a run of the job in the runtime and records it
+func (m *manager) startRun(ns string, s *service) (*pb.JobRun, error) {
+	j, err := job.FromMetadata(s.Service.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &pb.JobRun{
+		Id:      uuid.New().String(),
+		Service: s.Service.Name,
+		Version: s.Service.Version,
+		Status:  job.Running,
+		Started: time.Now().Unix(),
+	}
+
+	// each run is created as its own version of the service so runs can happen concurrently
+	md := make(map[string]string, len(s.Service.Metadata))
+	for k, v := range s.Service.Metadata {
+		md[k] = v
+	}
+	srv := &runtime.Service{
+		Name:     s.Service.Name,
+		Version:  runVersion(s.Service.Version, run.Id),
+		Source:   s.Service.Source,
+		Metadata: md,
+	}
+
+	opts := s.Options
+	if opts == nil {
+		opts = &runtime.CreateOptions{}
+	}
+
+	logger.Infof("Starting run %v of job %v:%v in namespace %v", run.Id, run.Service, run.Version, ns)
+
+	env, err := m.runtimeEnv(ns, srv, opts)
+	if err == nil {
+		err = m.Runtime.Create(srv,
+			runtime.CreateImage(opts.Image),
+			runtime.CreateType(job.Type),
+			runtime.CreateNamespace(ns),
+			runtime.WithArgs(opts.Args...),
+			runtime.WithCommand(opts.Command...),
+			runtime.WithEnv(env),
+			runtime.WithRetries(j.Retries),
+		)
+	}
+	if err != nil {
+		run.Status = job.Failed
+		run.Error = err.Error()
+		run.Finished = time.Now().Unix()
+	}
+
+	if err := m.writeRun(ns, run); err != nil {
+		return nil, err
+	}
+	m.trimRuns(ns, s.Service)
+
+	return run, nil
+}
+
+// stopRun deletes the run from the runtime and records it as finished with the status provided
+func (m *manager) stopRun(ns string, run *pb.JobRun, status string) {
+	srv := &runtime.Service{Name: run.Service, Version: runVersion(run.Version, run.Id)}
+	if err := m.Runtime.Delete(srv, runtime.DeleteNamespace(ns)); err != nil {
+		logger.Warnf("Error deleting run %v of job %v:%v: %v", run.Id, run.Service, run.Version, err)
+	}
+	m.cache.Delete(fmt.Sprintf("%v%v:%v:%v", statusPrefix, ns, srv.Name, srv.Version))
+	m.deleteCgroup(ns, srv)
+
+	run.Status = status
+	run.Finished = time.Now().Unix()
+	if err := m.writeRun(ns, run); err != nil {
+		logger.Warnf("Error writing run %v: %v", run.Id, err)
+	}
+}
+
+// watchJobs calls syncJobs periodically and should be run in a seperate go routine
+func (m *manager) watchJobs() {
+	ticker := time.NewTicker(jobPollFrequency)
+
+	for {
+		<-ticker.C
+		m.syncJobs(time.Now())
+	}
+}
+
+// syncJobs records the result of any runs which have completed and starts the scheduled runs
+// which are due
+func (m *manager) syncJobs(now time.Time) {
+	namespaces, err := m.listNamespaces()
+	if err != nil {
+		logger.Warnf("Error listing namespaces: %v", err)
+		return
+	}
+
+	for _, ns := range namespaces {
+		objs, err := m.readObjects(ns, &runtime.Service{})
+		if err != nil {
+			logger.Warnf("Error reading namespace %v: %v", ns, err)
+			return
+		}
+
+		for _, s := range objs {
+			if !isJob(s.Options) {
+				continue
+			}
+			if err := m.checkRuns(ns, s.Service); err != nil {
+				logger.Warnf("Error checking runs of job %v:%v: %v", s.Service.Name, s.Service.Version, err)
+			}
+			if err := m.scheduleRun(ns, s, now); err != nil {
+				logger.Warnf("Error scheduling job %v:%v: %v", s.Service.Name, s.Service.Version, err)
+			}
+		}
+	}
+}
+
+// checkRuns reads the status of the running runs of a job from the runtime and records the runs
+// which have completed, along with their logs
+func (m *manager) checkRuns(ns string, srv *runtime.Service) error {
+	j, err := job.FromMetadata(srv.Metadata)
+	if err != nil {
+		return err
+	}
+	runs, err := m.readRuns(ns, srv)
+	if err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		if run.Status != job.Running {
+			continue
+		}
+
+		version := runVersion(run.Version, run.Id)
+		srvs, err := m.Runtime.Read(
+			runtime.ReadNamespace(ns),
+			runtime.ReadService(run.Service),
+			runtime.ReadVersion(version),
+		)
+		if err != nil {
+			return err
+		}
+		if len(srvs) == 0 {
+			run.Error = "Run not found in the runtime"
+			m.stopRun(ns, run, job.Failed)
+			continue
+		}
+
+		md := srvs[0].Metadata
+		retries, _ := strconv.ParseInt(md["retries"], 10, 64)
+
+		switch md["status"] {
+		case "done":
+			run.Status = job.Succeeded
+		case "error":
+			// the runtime restarts the run until it runs out of retries
+			if retries <= int64(j.Retries) {
+				continue
+			}
+			run.Status = job.Failed
+			run.Error = md["error"]
+			if match := exitStatus.FindStringSubmatch(run.Error); len(match) == 2 {
+				run.ExitCode, _ = strconv.ParseInt(match[1], 10, 64)
+			}
+		default:
+			continue
+		}
+
+		run.Retries = retries
+		run.Logs = m.runLogs(ns, &runtime.Service{Name: run.Service, Version: version})
+		m.stopRun(ns, run, run.Status)
+	}
+
+	return nil
+}
+
+// runLogs returns the last lines logged by a run
+func (m *manager) runLogs(ns string, srv *runtime.Service) []string {
+	stream, err := m.Runtime.Logs(srv, runtime.LogsCount(jobLogLines), runtime.LogsNamespace(ns))
+	if err != nil {
+		logger.Warnf("Error reading logs of %v:%v: %v", srv.Name, srv.Version, err)
+		return nil
+	}
+	defer stream.Stop()
+
+	var lines []string
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case rec, ok := <-stream.Chan():
+			if !ok {
+				return lines
+			}
+			lines = append(lines, rec.Message)
+		case <-timeout:
+			return lines
+		}
+	}
+}
+
+// scheduleRun starts a run of the job if one is due, applying the concurrency policy of the job
+func (m *manager) scheduleRun(ns string, s *service, now time.Time) error {
+	j, err := job.FromMetadata(s.Service.Metadata)
+	if err != nil || j.Schedule == nil {
+		return err
+	}
+
+	// the first time the job is seen the schedule starts from now, runs which were missed before
+	// then are not started
+	last, err := m.readSchedule(ns, s.Service)
+	if err == store.ErrNotFound {
+		return m.writeSchedule(ns, s.Service, now)
+	} else if err != nil {
+		return err
+	}
+
+	next := j.Schedule.Next(last)
+	if next.IsZero() || next.After(now) {
+		return nil
+	}
+	if err := m.writeSchedule(ns, s.Service, now); err != nil {
+		return err
+	}
+
+	runs, err := m.readRuns(ns, s.Service)
+	if err != nil {
+		return err
+	}
+	var active []*pb.JobRun
+	for _, run := range runs {
+		if run.Status == job.Running {
+			active = append(active, run)
+		}
+	}
+
+	switch {
+	case len(active) == 0:
+	case j.Concurrency == job.Forbid:
+		logger.Infof("Skipping run of job %v:%v in namespace %v, the previous run is still running", s.Service.Name, s.Service.Version, ns)
+		return nil
+	case j.Concurrency == job.Replace:
+		for _, run := range active {
+			m.stopRun(ns, run, job.Replaced)
+		}
+	}
+
+	_, err = m.startRun(ns, s)
+	return err
+}
+
+// writeRun writes the run to the store
+func (m *manager) writeRun(ns string, run *pb.JobRun) error {
+	bytes, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+	return m.options.Store.Write(&store.Record{Key: runKey(ns, run), Value: bytes, Expiry: jobHistoryTTL})
+}
+
+// readRuns returns the runs of a job, oldest first. If the version of the service is blank the
+// runs of every version are returned.
+func (m *manager) readRuns(ns string, srv *runtime.Service) ([]*pb.JobRun, error) {
+	prefix := runPrefix + ns + ":" + srv.Name + ":"
+	if len(srv.Version) > 0 {
+		prefix += srv.Version + ":"
+	}
+
+	recs, err := m.options.Store.Read(prefix, store.ReadPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("Error reading runs from the store for namespace %v: %v", ns, err)
+	}
+
+	runs := make([]*pb.JobRun, 0, len(recs))
+	for _, rec := range recs {
+		var run *pb.JobRun
+		if err := json.Unmarshal(rec.Value, &run); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].Started < runs[j].Started })
+	return runs, nil
+}
+
+// trimRuns deletes the oldest runs of a job which have finished once there are more than the
+// jobHistoryLimit
+func (m *manager) trimRuns(ns string, srv *runtime.Service) {
+	runs, err := m.readRuns(ns, srv)
+	if err != nil {
+		logger.Warnf("Error reading runs of job %v:%v: %v", srv.Name, srv.Version, err)
+		return
+	}
+
+	for i := 0; i < len(runs) && len(runs)-i > jobHistoryLimit; i++ {
+		if runs[i].Status == job.Running {
+			continue
+		}
+		if err := m.options.Store.Delete(runKey(ns, runs[i])); err != nil {
+			logger.Warnf("Error deleting run %v: %v", runs[i].Id, err)
+		}
+	}
+}
+
+// ListJobs returns the jobs in a namespace
+func (m *manager) ListJobs(ns string) ([]*pb.Job, error) {
+	if len(ns) == 0 {
+		ns = namespace.DefaultNamespace
+	}
+
+	objs, err := m.readObjects(ns, &runtime.Service{})
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []*pb.Job
+	for _, s := range objs {
+		if !isJob(s.Options) {
+			continue
+		}
+
+		j, err := job.FromMetadata(s.Service.Metadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := &pb.Job{
+			Service:     s.Service.Name,
+			Version:     s.Service.Version,
+			Source:      s.Service.Source,
+			Concurrency: j.Concurrency,
+			Retries:     int64(j.Retries),
+		}
+
+		if j.Schedule != nil {
+			res.Schedule = j.Schedule.String()
+
+			from := time.Now()
+			if last, err := m.readSchedule(ns, s.Service); err == nil {
+				from = last
+			}
+			if next := j.Schedule.Next(from); !next.IsZero() {
+				res.NextRun = next.Unix()
+			}
+		}
+
+		runs, err := m.readRuns(ns, s.Service)
+		if err != nil {
+			return nil, err
+		}
+		if len(runs) > 0 {
+			res.LastRun = runs[len(runs)-1]
+		}
+
+		jobs = append(jobs, res)
+	}
+
+	return jobs, nil
+}
+
+// JobHistory returns the runs of a job, oldest first
+func (m *manager) JobHistory(ns string, srv *runtime.Service) ([]*pb.JobRun, error) {
+	if len(ns) == 0 {
+		ns = namespace.DefaultNamespace
+	}
+	return m.readRuns(ns, srv)
+}
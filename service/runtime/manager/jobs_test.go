@@ -0,0 +1,116 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/go-micro/v2/store/memory"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/service/runtime/job"
+)
+
+type testLogStream struct {
+	records chan runtime.LogRecord
+}
+
+func (s *testLogStream) Error() error                 { return nil }
+func (s *testLogStream) Chan() chan runtime.LogRecord { return s.records }
+func (s *testLogStream) Stop() error                  { return nil }
+
+func (r *testRuntime) Logs(srv *runtime.Service, opts ...runtime.LogsOption) (runtime.LogStream, error) {
+	records := make(chan runtime.LogRecord, 1)
+	records <- runtime.LogRecord{Message: "migrated"}
+	close(records)
+	return &testLogStream{records: records}, nil
+}
+
+func TestJobs(t *testing.T) {
+	rt := &testRuntime{}
+	st := memory.NewStore()
+	m := New(rt, Store(st)).(*manager)
+
+	testSrv := &runtime.Service{
+		Name:    "go.micro.service.migrate",
+		Version: "latest",
+		Metadata: map[string]string{
+			job.ScheduleKey:    "* * * * *",
+			job.ConcurrencyKey: job.Forbid,
+		},
+	}
+	opts := &runtime.CreateOptions{Namespace: namespace.DefaultNamespace, Type: job.Type}
+	if err := m.createService(testSrv, opts); err != nil {
+		t.Fatalf("Unexpected error when creating service: %v", err)
+	}
+
+	now := time.Date(2020, 7, 1, 10, 0, 30, 0, time.UTC)
+
+	// the schedule starts when the job is first seen
+	m.syncJobs(now)
+	if rt.createCount != 0 {
+		t.Fatalf("Expected no runs to be created but runtime create was called %v times", rt.createCount)
+	}
+
+	// the next minute a run should be started
+	m.syncJobs(now.Add(time.Minute))
+	if rt.createCount != 1 {
+		t.Fatalf("Expected a run to be created but runtime create was called %v times", rt.createCount)
+	}
+	runs, err := m.JobHistory(namespace.DefaultNamespace, testSrv)
+	if err != nil {
+		t.Fatalf("Unexpected error when reading job history: %v", err)
+	}
+	if len(runs) != 1 || runs[0].Status != job.Running {
+		t.Fatalf("Expected one running run but got %v", runs)
+	}
+	runSrv := &runtime.Service{
+		Name:     testSrv.Name,
+		Version:  runVersion(testSrv.Version, runs[0].Id),
+		Metadata: map[string]string{"status": "running"},
+	}
+	rt.readServices = []*runtime.Service{runSrv}
+
+	// the run is still running so the concurrency policy should forbid another run
+	m.syncJobs(now.Add(time.Minute * 2))
+	if rt.createCount != 1 {
+		t.Errorf("Expected the run to be skipped but runtime create was called %v times", rt.createCount)
+	}
+
+	// once the run is done it should be recorded as succeeded and deleted from the runtime
+	runSrv.Metadata["status"] = "done"
+	m.syncJobs(now.Add(time.Minute*2 + time.Second*10))
+	if rt.deleteCount != 1 {
+		t.Errorf("Expected the run to be deleted but runtime delete was called %v times", rt.deleteCount)
+	}
+
+	jobs, err := m.ListJobs(namespace.DefaultNamespace)
+	if err != nil {
+		t.Fatalf("Unexpected error when listing jobs: %v", err)
+	}
+	if len(jobs) != 1 {
+		t.Fatalf("Expected 1 job but got %v", len(jobs))
+	}
+	if jobs[0].Schedule != "* * * * *" {
+		t.Errorf("Expected the job schedule to be returned but got %v", jobs[0].Schedule)
+	}
+	if jobs[0].LastRun == nil || jobs[0].LastRun.Status != job.Succeeded {
+		t.Fatalf("Expected the last run to have succeeded but got %v", jobs[0].LastRun)
+	}
+	if len(jobs[0].LastRun.Logs) != 1 || jobs[0].LastRun.Logs[0] != "migrated" {
+		t.Errorf("Expected the logs of the run to be recorded but got %v", jobs[0].LastRun.Logs)
+	}
+
+	// the schedule is kept in the store so a restarted manager carries on from the last run
+	m = New(rt, Store(st)).(*manager)
+	jobs, err = m.ListJobs(namespace.DefaultNamespace)
+	if err != nil {
+		t.Fatalf("Unexpected error when listing jobs: %v", err)
+	}
+	if next := now.Add(time.Minute * 2).Truncate(time.Minute).Add(time.Minute); jobs[0].NextRun != next.Unix() {
+		t.Errorf("Expected the next run at %v but got %v", next, time.Unix(jobs[0].NextRun, 0).UTC())
+	}
+	m.syncJobs(now.Add(time.Minute * 3))
+	if rt.createCount != 2 {
+		t.Errorf("Expected a run to be created but runtime create was called %v times", rt.createCount)
+	}
+}
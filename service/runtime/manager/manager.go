@@ -1,11 +1,17 @@
 package manager
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/micro/go-micro/v2/config/cmd"
 	"github.com/micro/go-micro/v2/runtime"
 	"github.com/micro/go-micro/v2/store"
 	"github.com/micro/go-micro/v2/store/memory"
 	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/service/runtime/job"
+	"github.com/micro/micro/v2/service/runtime/resource"
+	"github.com/micro/micro/v2/service/runtime/scale"
 )
 
 // Init initializes the runtime
@@ -31,6 +37,51 @@ func (m *manager) Create(srv *runtime.Service, opts ...runtime.CreateOption) err
 	if len(srv.Version) == 0 {
 		srv.Version = "latest"
 	}
+	if strings.Contains(srv.Version, versionSeparator) {
+		return fmt.Errorf("Invalid version %v, versions can't contain %v", srv.Version, versionSeparator)
+	}
+
+	// validate any resource limits before accepting the service. They're enforced using cgroups so
+	// only the local runtime supports them.
+	if res, err := resource.FromMetadata(srv.Metadata); err != nil {
+		return err
+	} else if res != nil && m.Runtime.String() != "local" {
+		return fmt.Errorf("Resource limits aren't supported by the %v runtime, only by the local runtime", m.Runtime.String())
+	}
+
+	// a service with a schedule is a job, validate the schedule and policies of jobs
+	if len(srv.Metadata[job.ScheduleKey]) > 0 {
+		options.Type = job.Type
+	}
+	if isJob(&options) {
+		if _, err := job.FromMetadata(srv.Metadata); err != nil {
+			return err
+		}
+	}
+
+	// validate the replicas, jobs are run by the manager so they can't be scaled
+	sc, err := scale.FromMetadata(srv.Metadata)
+	if err != nil {
+		return err
+	}
+	if isJob(&options) && len(srv.Metadata[scale.ReplicasKey]) > 0 {
+		return fmt.Errorf("Job %v:%v can't have replicas", srv.Name, srv.Version)
+	}
+	if !isJob(&options) {
+		// autoscaled services start with the min replicas unless a count within the bounds is set
+		if sc.Autoscale() && (sc.Replicas < sc.Min || sc.Replicas > sc.Max) {
+			sc.Replicas = sc.Min
+		}
+		if err := m.checkScale(options.Namespace, sc); err != nil {
+			return err
+		}
+		sc.Metadata(srv.Metadata)
+	}
+
+	// the secrets referenced must exist, they're resolved when the service is run
+	if err := m.checkSecrets(options.Namespace, options.Env); err != nil {
+		return err
+	}
 
 	// write the object to the store
 	if err := m.createService(srv, &options); err != nil {
@@ -66,7 +117,15 @@ func (m *manager) Read(opts ...runtime.ReadOption) ([]*runtime.Service, error) {
 	if err != nil {
 		return nil, err
 	}
+	usage, err := m.listUsage(options.Namespace)
+	if err != nil {
+		return nil, err
+	}
 	for _, srv := range srvs {
+		if u, ok := usage[srv.Name+":"+srv.Version]; ok {
+			srv.Metadata[resource.UsageKey] = u
+		}
+
 		md, ok := statuses[srv.Name+":"+srv.Version]
 		if !ok {
 			continue
@@ -95,7 +154,10 @@ func (m *manager) Update(srv *runtime.Service, opts ...runtime.UpdateOption) err
 	}
 
 	// publish the update event which will trigger an update in the runtime
-	return m.publishEvent(runtime.Update, srv, &runtime.CreateOptions{Namespace: options.Namespace})
+	return m.publishEvent(runtime.Update, srv, &runtime.CreateOptions{
+		Namespace: options.Namespace,
+		Type:      m.serviceType(options.Namespace, srv),
+	})
 }
 
 // Remove a service
@@ -114,13 +176,16 @@ func (m *manager) Delete(srv *runtime.Service, opts ...runtime.DeleteOption) err
 		srv.Version = "latest"
 	}
 
+	// the type is needed to know how to delete the service from the runtime
+	typ := m.serviceType(options.Namespace, srv)
+
 	// delete from the store
 	if err := m.deleteService(options.Namespace, srv); err != nil {
 		return err
 	}
 
 	// publish the event which will trigger a delete in the runtime
-	return m.publishEvent(runtime.Delete, srv, &runtime.CreateOptions{Namespace: options.Namespace})
+	return m.publishEvent(runtime.Delete, srv, &runtime.CreateOptions{Namespace: options.Namespace, Type: typ})
 }
 
 // Starts the manager
@@ -138,9 +203,20 @@ func (m *manager) Start() error {
 	// watch events written to the store
 	go m.watchEvents()
 
-	// periodically load the status of services from the runtime
+	// load the status of services from the runtime as it changes
 	go m.watchStatus()
 
+	// start the scheduled runs of jobs and record the runs which complete
+	go m.watchJobs()
+
+	// autoscale services and keep the desired number of replicas running
+	go m.watchReplicas()
+
+	// the local runtime has no concept of resource limits so we enforce them using cgroups
+	if m.Runtime.String() == "local" {
+		go m.watchResources()
+	}
+
 	// todo: compare the store to the runtime incase we missed any events
 
 	return nil
@@ -172,6 +248,10 @@ type manager struct {
 	// global store, e.g. events consumed, service status / errors (these will change depending on the
 	// managed runtime and hence won't be the same globally).
 	cache store.Store
+	// cgroups used to enforce resource limits on the local runtime
+	cgroups cgroups
+	// timeline watchers subscribed to status events
+	timeline timelineWatchers
 }
 
 // New returns a manager for the runtime
@@ -1,6 +1,10 @@
 package manager
 
-import "github.com/micro/go-micro/v2/store"
+import (
+	"github.com/micro/go-micro/v2"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/service/runtime/scale"
+)
 
 // Options for the runtime manager
 type Options struct {
@@ -9,6 +13,12 @@ type Options struct {
 	Profile []string
 	// Store to persist state
 	Store store.Store
+	// Publisher for status events, e.g. a service crashing
+	Publisher micro.Publisher
+	// SecretKey used to encrypt secrets at rest
+	SecretKey []byte
+	// Metrics used to autoscale services, autoscaling is disabled if not set
+	Metrics scale.Metrics
 }
 
 // Option sets an option
@@ -27,3 +37,24 @@ func Store(s store.Store) Option {
 		o.Store = s
 	}
 }
+
+// Publisher to publish status events to
+func Publisher(p micro.Publisher) Option {
+	return func(o *Options) {
+		o.Publisher = p
+	}
+}
+
+// SecretKey to encrypt secrets with, secrets are disabled if no key is provided
+func SecretKey(key []byte) Option {
+	return func(o *Options) {
+		o.SecretKey = key
+	}
+}
+
+// Metrics used to autoscale services
+func Metrics(m scale.Metrics) Option {
+	return func(o *Options) {
+		o.Metrics = m
+	}
+}
@@ -0,0 +1,327 @@
+package manager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/service/runtime/scale"
+)
+
+// replicaPollFrequency is the frequency the manager autoscales services and reconciles the
+// replicas running with the desired count
+var replicaPollFrequency = time.Second * 30
+
+// Scaler is implemented by the manager to change the number of replicas of a service
+type Scaler interface {
+	// Scale sets the replicas of a service, or the bounds it's autoscaled between
+	Scale(ns string, srv *runtime.Service, sc *scale.Scale) error
+}
+
+// versionSeparator separates the version of a service from the suffix of the versions of its
+// replicas and job runs in the runtime, e.g. latest..r2. Versions can't contain it, so the
+// version of a replica or run can't be the version of another service, e.g. latest-r2.
+const versionSeparator = ".."
+
+// replicaVersion is the version used in the runtime for a replica of a service. The first replica
+// uses the version of the service and the others are suffixed with their index, e.g. latest..r2.
+func replicaVersion(version string, i int) string {
+	if i == 0 {
+		return version
+	}
+	return fmt.Sprintf("%v%vr%d", version, versionSeparator, i)
+}
+
+// replicaIndex returns the index of the replica from the version used in the runtime, or false if
+// the version isn't a replica of the service version
+func replicaIndex(version, runtimeVersion string) (int, bool) {
+	if runtimeVersion == version {
+		return 0, true
+	}
+	prefix := version + versionSeparator + "r"
+	if !strings.HasPrefix(runtimeVersion, prefix) {
+		return 0, false
+	}
+	i, err := strconv.Atoi(strings.TrimPrefix(runtimeVersion, prefix))
+	if err != nil || i < 1 {
+		return 0, false
+	}
+	return i, true
+}
+
+// replicaCount returns the number of replicas the manager should run in the runtime
+func (m *manager) replicaCount(srv *runtime.Service) (int, error) {
+	sc, err := scale.FromMetadata(srv.Metadata)
+	if err != nil {
+		return 0, err
+	}
+	return sc.Replicas, nil
+}
+
+// checkScale returns an error if the service can't be scaled as requested. The manager runs the
+// replicas itself which only the local runtime supports, and the load of services is only known in
+// the default namespace so they can't be autoscaled in any other.
+func (m *manager) checkScale(ns string, sc *scale.Scale) error {
+	if m.Runtime.String() != "local" && (sc.Replicas > 1 || sc.Autoscale()) {
+		return fmt.Errorf("Replicas aren't supported by the %v runtime, only by the local runtime", m.Runtime.String())
+	}
+	if sc.Autoscale() && ns != namespace.DefaultNamespace {
+		return fmt.Errorf("Services can only be autoscaled in the %v namespace", namespace.DefaultNamespace)
+	}
+	return nil
+}
+
+// replica returns a copy of the service to create in the runtime as the i'th replica
+func replica(srv *runtime.Service, i int) *runtime.Service {
+	md := make(map[string]string, len(srv.Metadata))
+	for k, v := range srv.Metadata {
+		md[k] = v
+	}
+	return &runtime.Service{
+		Name:     srv.Name,
+		Version:  replicaVersion(srv.Version, i),
+		Source:   srv.Source,
+		Metadata: md,
+	}
+}
+
+// createReplicas creates every replica of the service in the runtime
+func (m *manager) createReplicas(ns string, srv *runtime.Service, opts *runtime.CreateOptions) error {
+	n, err := m.replicaCount(srv)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if err := m.createInRuntime(ns, replica(srv, i), opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runtimeReplicas returns the versions of the replicas of a service found in the runtime, by index
+func (m *manager) runtimeReplicas(ns string, srv *runtime.Service) (map[int]*runtime.Service, error) {
+	srvs, err := m.Runtime.Read(runtime.ReadNamespace(ns), runtime.ReadService(srv.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	replicas := make(map[int]*runtime.Service)
+	for _, s := range srvs {
+		if s.Name != srv.Name {
+			continue
+		}
+		if i, ok := replicaIndex(srv.Version, s.Version); ok {
+			replicas[i] = s
+		}
+	}
+	return replicas, nil
+}
+
+// updateReplicas updates the service and the other replicas running in the runtime
+func (m *manager) updateReplicas(ns string, srv *runtime.Service) error {
+	if err := m.Runtime.Update(srv, runtime.UpdateNamespace(ns)); err != nil {
+		return err
+	}
+
+	replicas, err := m.runtimeReplicas(ns, srv)
+	if err != nil {
+		return err
+	}
+	for i := range replicas {
+		if i == 0 {
+			continue
+		}
+		if err := m.Runtime.Update(replica(srv, i), runtime.UpdateNamespace(ns)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteReplicas deletes the service and the other replicas running in the runtime
+func (m *manager) deleteReplicas(ns string, srv *runtime.Service) error {
+	if err := m.Runtime.Delete(srv, runtime.DeleteNamespace(ns)); err != nil {
+		return err
+	}
+
+	replicas, err := m.runtimeReplicas(ns, srv)
+	if err != nil {
+		return err
+	}
+	for i, r := range replicas {
+		if i == 0 {
+			continue
+		}
+		if err := m.Runtime.Delete(r, runtime.DeleteNamespace(ns)); err != nil {
+			return err
+		}
+		m.deleteStatus(ns, r)
+		m.deleteCgroup(ns, r)
+	}
+	return nil
+}
+
+// reconcileReplicas creates any replicas missing from the runtime and deletes any which are no
+// longer needed so the desired number of replicas are running
+func (m *manager) reconcileReplicas(ns string, s *service) error {
+	n, err := m.replicaCount(s.Service)
+	if err != nil {
+		return err
+	}
+	replicas, err := m.runtimeReplicas(ns, s.Service)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < n; i++ {
+		if _, ok := replicas[i]; ok {
+			continue
+		}
+		r := replica(s.Service, i)
+		if err := m.createInRuntime(ns, r, s.Options); err != nil {
+			return err
+		}
+		m.updateStatus(ns, r)
+	}
+
+	for i, r := range replicas {
+		if i < n {
+			continue
+		}
+		if err := m.Runtime.Delete(r, runtime.DeleteNamespace(ns)); err != nil {
+			return err
+		}
+		m.deleteStatus(ns, r)
+		m.deleteCgroup(ns, r)
+	}
+
+	return nil
+}
+
+// Scale sets the replicas of a service and persists them in the store. The replicas are
+// reconciled immediately rather than waiting for syncReplicas.
+func (m *manager) Scale(ns string, srv *runtime.Service, sc *scale.Scale) error {
+	if len(ns) == 0 {
+		ns = namespace.DefaultNamespace
+	}
+	if len(srv.Version) == 0 {
+		srv.Version = "latest"
+	}
+
+	objs, err := m.readObjects(ns, srv)
+	if err != nil {
+		return err
+	}
+	var s *service
+	for _, o := range objs {
+		if o.Service.Version == srv.Version {
+			s = o
+		}
+	}
+	if s == nil {
+		return fmt.Errorf("Service %v:%v not found in namespace %v", srv.Name, srv.Version, ns)
+	}
+	if isJob(s.Options) {
+		return fmt.Errorf("Service %v:%v is a job and can't be scaled", srv.Name, srv.Version)
+	}
+
+	// validate the scale using the metadata it will be persisted as
+	md := make(map[string]string)
+	sc.Metadata(md)
+	if sc, err = scale.FromMetadata(md); err != nil {
+		return err
+	}
+	if sc.Autoscale() && (sc.Replicas < sc.Min || sc.Replicas > sc.Max) {
+		sc.Replicas = sc.Min
+	}
+	if err := m.checkScale(ns, sc); err != nil {
+		return err
+	}
+
+	if s.Service.Metadata == nil {
+		s.Service.Metadata = make(map[string]string)
+	}
+	sc.Metadata(s.Service.Metadata)
+	if err := m.createService(s.Service, s.Options); err != nil {
+		return err
+	}
+
+	logger.Infof("Scaling service %v:%v in namespace %v to %v replicas", srv.Name, srv.Version, ns, sc.Replicas)
+	return m.reconcileReplicas(ns, s)
+}
+
+// watchReplicas calls syncReplicas periodically and should be run in a seperate go routine
+func (m *manager) watchReplicas() {
+	ticker := time.NewTicker(replicaPollFrequency)
+
+	for {
+		<-ticker.C
+		m.syncReplicas()
+	}
+}
+
+// syncReplicas autoscales the services using the metrics, persisting the desired number of
+// replicas, and reconciles the replicas running in the runtime
+func (m *manager) syncReplicas() {
+	namespaces, err := m.listNamespaces()
+	if err != nil {
+		logger.Warnf("Error listing namespaces: %v", err)
+		return
+	}
+
+	for _, ns := range namespaces {
+		objs, err := m.readObjects(ns, &runtime.Service{})
+		if err != nil {
+			logger.Warnf("Error reading namespace %v: %v", ns, err)
+			return
+		}
+
+		for _, s := range objs {
+			if isJob(s.Options) {
+				continue
+			}
+			if err := m.autoscale(ns, s); err != nil {
+				logger.Warnf("Error autoscaling service %v:%v: %v", s.Service.Name, s.Service.Version, err)
+			}
+			if err := m.reconcileReplicas(ns, s); err != nil {
+				logger.Warnf("Error reconciling replicas of service %v:%v: %v", s.Service.Name, s.Service.Version, err)
+			}
+		}
+	}
+}
+
+// autoscale updates the desired replicas of the service using its request and error rates
+func (m *manager) autoscale(ns string, s *service) error {
+	if m.options.Metrics == nil {
+		return nil
+	}
+	sc, err := scale.FromMetadata(s.Service.Metadata)
+	if err != nil || !sc.Autoscale() {
+		return err
+	}
+
+	requests, errors, err := m.options.Metrics.Rate(ns, s.Service.Name)
+	if err == scale.ErrNoMetrics {
+		logger.Debugf("No metrics for service %v:%v in namespace %v, keeping %v replicas", s.Service.Name, s.Service.Version, ns, sc.Replicas)
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	desired := sc.Desired(sc.Replicas, requests, errors)
+	if desired == sc.Replicas {
+		return nil
+	}
+
+	logger.Infof("Autoscaling service %v:%v in namespace %v from %v to %v replicas (%.2f req/s, %.2f err/s)",
+		s.Service.Name, s.Service.Version, ns, sc.Replicas, desired, requests, errors)
+
+	sc.Replicas = desired
+	sc.Metadata(s.Service.Metadata)
+	return m.createService(s.Service, s.Options)
+}
@@ -0,0 +1,154 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/go-micro/v2/store/memory"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/service/runtime/scale"
+)
+
+// testMetrics returns the request rates of the services, keyed by namespace and name
+type testMetrics struct {
+	requests map[string]float64
+}
+
+func (m *testMetrics) Rate(ns, name string) (float64, float64, error) {
+	r, ok := m.requests[ns+"/"+name]
+	if !ok {
+		return 0, 0, scale.ErrNoMetrics
+	}
+	return r, 0, nil
+}
+
+func TestReplicas(t *testing.T) {
+	rt := &testRuntime{}
+	metrics := &testMetrics{requests: map[string]float64{}}
+	m := New(rt, Store(memory.NewStore()), Metrics(metrics)).(*manager)
+
+	testSrv := &runtime.Service{
+		Name:     "go.micro.service.foo",
+		Version:  "latest",
+		Metadata: map[string]string{scale.ReplicasKey: "3"},
+	}
+	opts := &runtime.CreateOptions{Namespace: namespace.DefaultNamespace}
+	if err := m.createService(testSrv, opts); err != nil {
+		t.Fatalf("Unexpected error when creating service: %v", err)
+	}
+
+	// only the first replica is running so the others should be created
+	rt.readServices = []*runtime.Service{
+		{Name: testSrv.Name, Version: "latest", Metadata: map[string]string{}},
+		{Name: testSrv.Name, Version: "v2", Metadata: map[string]string{}},
+	}
+	m.syncReplicas()
+	if rt.createCount != 2 {
+		t.Errorf("Expected 2 replicas to be created but runtime create was called %v times", rt.createCount)
+	}
+
+	// scaling down should delete the extra replicas but not other versions of the service, even
+	// those which look like the suffixes of replicas
+	rt.Reset()
+	rt.readServices = []*runtime.Service{
+		{Name: testSrv.Name, Version: "latest", Metadata: map[string]string{}},
+		{Name: testSrv.Name, Version: replicaVersion("latest", 1), Metadata: map[string]string{}},
+		{Name: testSrv.Name, Version: replicaVersion("latest", 2), Metadata: map[string]string{}},
+		{Name: testSrv.Name, Version: "latest-r2", Metadata: map[string]string{}},
+		{Name: testSrv.Name, Version: "v2", Metadata: map[string]string{}},
+	}
+	if err := m.Scale(namespace.DefaultNamespace, testSrv, &scale.Scale{Replicas: 1, Min: 1}); err != nil {
+		t.Fatalf("Unexpected error when scaling service: %v", err)
+	}
+	if rt.deleteCount != 2 || rt.createCount != 0 {
+		t.Errorf("Expected 2 replicas to be deleted but runtime delete was called %v times and create %v times", rt.deleteCount, rt.createCount)
+	}
+
+	// the desired count should be persisted
+	srvs, err := m.readServices(namespace.DefaultNamespace, testSrv)
+	if err != nil {
+		t.Fatalf("Unexpected error when reading services: %v", err)
+	}
+	if len(srvs) != 1 || srvs[0].Metadata[scale.ReplicasKey] != "1" {
+		t.Fatalf("Expected the replicas to be persisted but got %v", srvs[0].Metadata)
+	}
+
+	// once autoscaled the replicas should follow the request rate
+	rt.Reset()
+	rt.readServices = []*runtime.Service{
+		{Name: testSrv.Name, Version: "latest", Metadata: map[string]string{}},
+	}
+	if err := m.Scale(namespace.DefaultNamespace, testSrv, &scale.Scale{Replicas: 1, Min: 1, Max: 4, TargetRate: 10}); err != nil {
+		t.Fatalf("Unexpected error when scaling service: %v", err)
+	}
+	// the load on the service of the same name in another namespace doesn't scale it
+	metrics.requests["foo/"+testSrv.Name] = 100
+	m.syncReplicas()
+	if rt.createCount != 0 {
+		t.Errorf("Expected the service not to be scaled by the load in another namespace, runtime create was called %v times", rt.createCount)
+	}
+
+	metrics.requests[namespace.DefaultNamespace+"/"+testSrv.Name] = 25
+	m.syncReplicas()
+	if rt.createCount != 2 {
+		t.Errorf("Expected 2 replicas to be created but runtime create was called %v times", rt.createCount)
+	}
+	srvs, err = m.readServices(namespace.DefaultNamespace, testSrv)
+	if err != nil {
+		t.Fatalf("Unexpected error when reading services: %v", err)
+	}
+	if srvs[0].Metadata[scale.ReplicasKey] != "3" {
+		t.Errorf("Expected the service to be autoscaled to 3 replicas but got %v", srvs[0].Metadata[scale.ReplicasKey])
+	}
+}
+
+func TestReplicaVersion(t *testing.T) {
+	m := New(&testRuntime{}, Store(memory.NewStore())).(*manager)
+
+	// the versions of the replicas and runs are reserved
+	err := m.Create(&runtime.Service{Name: "go.micro.service.foo", Version: replicaVersion("latest", 2)})
+	if err == nil {
+		t.Errorf("Expected the version of a replica to be rejected")
+	}
+
+	if i, ok := replicaIndex("latest", replicaVersion("latest", 2)); !ok || i != 2 {
+		t.Errorf("Expected the version to be replica 2 but got %v %v", i, ok)
+	}
+	for _, v := range []string{"latest-r2", "latest..r0", "latest..rx", "v2"} {
+		if _, ok := replicaIndex("latest", v); ok {
+			t.Errorf("Expected %v not to be a replica of latest", v)
+		}
+	}
+}
+
+// testKubernetes is a test runtime which isn't local
+type testKubernetes struct {
+	*testRuntime
+}
+
+func (r *testKubernetes) String() string {
+	return "kubernetes"
+}
+
+func TestCheckScale(t *testing.T) {
+	m := New(&testRuntime{}, Store(memory.NewStore())).(*manager)
+	if err := m.checkScale(namespace.DefaultNamespace, &scale.Scale{Replicas: 1, Min: 1, Max: 4, TargetRate: 10}); err != nil {
+		t.Errorf("Unexpected error autoscaling in the default namespace: %v", err)
+	}
+	if err := m.checkScale("foo", &scale.Scale{Replicas: 1, Min: 1, Max: 4, TargetRate: 10}); err == nil {
+		t.Errorf("Expected an error autoscaling in another namespace")
+	}
+
+	// the replicas are only run by the manager on the local runtime
+	m = New(&testKubernetes{&testRuntime{}}, Store(memory.NewStore())).(*manager)
+	if err := m.checkScale(namespace.DefaultNamespace, &scale.Scale{Replicas: 1}); err != nil {
+		t.Errorf("Unexpected error running a single replica: %v", err)
+	}
+	if err := m.checkScale(namespace.DefaultNamespace, &scale.Scale{Replicas: 3}); err == nil {
+		t.Errorf("Expected an error running replicas on kubernetes")
+	}
+	err := m.Create(&runtime.Service{Name: "go.micro.service.foo", Metadata: map[string]string{scale.ReplicasKey: "3"}})
+	if err == nil {
+		t.Errorf("Expected the replicas to be rejected on kubernetes")
+	}
+}
@@ -0,0 +1,210 @@
+package manager
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/service/runtime/resource"
+)
+
+const (
+	// usagePrefix is prefixed to every usage key written to the memory store
+	usagePrefix = "usage:"
+	// cgroupEnv is set on services with resource limits so the manager can find their processes
+	cgroupEnv = "MICRO_RUNTIME_CGROUP"
+)
+
+// resourcePollFrequency is the frequency the manager applies resource limits and samples usage
+var resourcePollFrequency = time.Second * 10
+
+// cgroups contains the cgroup for each service with resource limits, keyed by cgroupName
+type cgroups struct {
+	sync.Mutex
+	groups map[string]*resource.Cgroup
+}
+
+// cgroupEscaper escapes the separator of the parts of a cgroup name along with the characters which
+// can't be used in the name of a cgroup
+var cgroupEscaper = strings.NewReplacer("%", "%25", "_", "%5F", "/", "%2F", ":", "%3A")
+
+// cgroupName is the name of the cgroup for a service, e.g. micro_foo_latest. The parts are escaped
+// so the names of different services can't collide, e.g. a-b:c and a:b-c.
+func cgroupName(ns string, srv *runtime.Service) string {
+	return cgroupEscaper.Replace(ns) + "_" + cgroupEscaper.Replace(srv.Name) + "_" + cgroupEscaper.Replace(srv.Version)
+}
+
+// watchResources calls syncResources periodically and should be run in a seperate go routine
+func (m *manager) watchResources() {
+	ticker := time.NewTicker(resourcePollFrequency)
+
+	for {
+		if err := m.syncResources(); err == resource.ErrNotSupported {
+			logger.Warnf("Resource limits will not be enforced: %v", err)
+			ticker.Stop()
+			return
+		}
+		<-ticker.C
+	}
+}
+
+// syncResources applies the resource limits for every service in the store, moves any new
+// processes into the services cgroup and caches the current usage.
+func (m *manager) syncResources() error {
+	namespaces, err := m.listNamespaces()
+	if err != nil {
+		logger.Warnf("Error listing namespaces: %v", err)
+		return nil
+	}
+
+	// the cgroups of the services which are no longer in the store are removed
+	current := make(map[string]bool)
+
+	for _, ns := range namespaces {
+		srvs, err := m.readServices(ns, &runtime.Service{})
+		if err != nil {
+			logger.Warnf("Error reading namespace %v: %v", ns, err)
+			return nil
+		}
+
+		for _, srv := range srvs {
+			res, err := resource.FromMetadata(srv.Metadata)
+			if err != nil || res == nil {
+				continue
+			}
+
+			name := cgroupName(ns, srv)
+			current[name] = true
+			cg := m.cgroup(name)
+			if err := cg.Apply(res); err == resource.ErrNotSupported {
+				return err
+			} else if err != nil {
+				logger.Warnf("Error applying resource limits to %v: %v", name, err)
+				continue
+			}
+
+			// processes are started by the managed runtime so we find them by the env var which was
+			// set when the service was created and move them into the cgroup
+			pids, err := resource.FindProcesses(cgroupEnv + "=" + name)
+			if err != nil {
+				logger.Warnf("Error finding processes for %v: %v", name, err)
+				continue
+			}
+			for _, pid := range pids {
+				if err := cg.Attach(pid); err != nil {
+					logger.Debugf("Error attaching process %v to %v: %v", pid, name, err)
+				}
+			}
+
+			usage, err := cg.Usage()
+			if err != nil {
+				logger.Warnf("Error reading resource usage for %v: %v", name, err)
+				continue
+			}
+			if err := m.cacheUsage(ns, srv, usage.String(res)); err != nil {
+				logger.Warnf("Error caching usage: %v", err)
+			}
+		}
+	}
+
+	m.pruneCgroups(current)
+	return nil
+}
+
+// pruneCgroups removes the cgroups which aren't current, including those left by a previous run of
+// the manager. The processes of a deleted service can take a while to exit and a cgroup can't be
+// removed until they have, so the cgroups which can't be removed yet are retried on the next sync.
+func (m *manager) pruneCgroups(current map[string]bool) {
+	names, err := resource.ListCgroups()
+	if err != nil {
+		logger.Warnf("Error listing cgroups: %v", err)
+	}
+
+	m.cgroups.Lock()
+	for name := range m.cgroups.groups {
+		names = append(names, name)
+	}
+	m.cgroups.Unlock()
+
+	for _, name := range names {
+		if current[name] {
+			continue
+		}
+		if err := m.cgroup(name).Delete(); err != nil {
+			logger.Debugf("Error deleting cgroup %v, it will be retried: %v", name, err)
+			continue
+		}
+
+		m.cgroups.Lock()
+		delete(m.cgroups.groups, name)
+		m.cgroups.Unlock()
+	}
+}
+
+// cgroup returns the cgroup for the name provided, creating it if it's not already loaded
+func (m *manager) cgroup(name string) *resource.Cgroup {
+	m.cgroups.Lock()
+	defer m.cgroups.Unlock()
+
+	if m.cgroups.groups == nil {
+		m.cgroups.groups = make(map[string]*resource.Cgroup)
+	}
+	cg, ok := m.cgroups.groups[name]
+	if !ok {
+		cg = resource.NewCgroup(name)
+		m.cgroups.groups[name] = cg
+	}
+	return cg
+}
+
+// deleteCgroup removes the cgroup for a service once it's been deleted from the runtime. If its
+// processes haven't exited yet the cgroup is removed by syncResources instead.
+func (m *manager) deleteCgroup(ns string, srv *runtime.Service) {
+	name := cgroupName(ns, srv)
+
+	m.cgroups.Lock()
+	cg, ok := m.cgroups.groups[name]
+	m.cgroups.Unlock()
+
+	if ok {
+		if err := cg.Delete(); err != nil {
+			logger.Debugf("Error deleting cgroup %v, it will be retried: %v", name, err)
+		} else {
+			m.cgroups.Lock()
+			delete(m.cgroups.groups, name)
+			m.cgroups.Unlock()
+		}
+	}
+
+	m.cache.Delete(fmt.Sprintf("%v%v:%v:%v", usagePrefix, ns, srv.Name, srv.Version))
+}
+
+// cacheUsage writes the formatted usage of a service to the memory store which is then later
+// returned in service metadata on Runtime.Read
+func (m *manager) cacheUsage(ns string, srv *runtime.Service, usage string) error {
+	key := fmt.Sprintf("%v%v:%v:%v", usagePrefix, ns, srv.Name, srv.Version)
+	return m.cache.Write(&store.Record{Key: key, Value: []byte(usage)})
+}
+
+// listUsage returns the usage for the services in a given namespace with 'name:version' as the
+// format used for the keys in the map.
+func (m *manager) listUsage(ns string) (map[string]string, error) {
+	recs, err := m.cache.Read(usagePrefix+ns+":", store.ReadPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("Error listing usage from the store for namespace %v: %v", ns, err)
+	}
+
+	usage := make(map[string]string, len(recs))
+	for _, rec := range recs {
+		// record keys are formatted: 'prefix:namespace:name:version'
+		if comps := strings.Split(rec.Key, ":"); len(comps) == 4 {
+			usage[comps[2]+":"+comps[3]] = string(rec.Value)
+		}
+	}
+
+	return usage, nil
+}
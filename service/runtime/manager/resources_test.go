@@ -0,0 +1,62 @@
+// +build linux
+
+package manager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/micro/v2/service/runtime/resource"
+)
+
+func TestCgroupName(t *testing.T) {
+	a := cgroupName("a-b", &runtime.Service{Name: "c", Version: "latest"})
+	b := cgroupName("a", &runtime.Service{Name: "b-c", Version: "latest"})
+	c := cgroupName("a_b", &runtime.Service{Name: "c", Version: "latest"})
+	d := cgroupName("a", &runtime.Service{Name: "b_c", Version: "latest"})
+	if a == b || c == d {
+		t.Errorf("Expected the names of different services to differ, got %v, %v, %v and %v", a, b, c, d)
+	}
+}
+
+func TestPruneCgroups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroups")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	root := resource.CgroupRoot
+	resource.CgroupRoot = dir
+	defer func() { resource.CgroupRoot = root }()
+
+	m := &manager{}
+	current := cgroupName("micro", &runtime.Service{Name: "foo", Version: "latest"})
+	deleted := cgroupName("micro", &runtime.Service{Name: "bar", Version: "latest"})
+	exiting := cgroupName("micro", &runtime.Service{Name: "baz", Version: "latest"})
+	for _, name := range []string{current, deleted, exiting} {
+		if err := os.MkdirAll(filepath.Join(dir, resource.CgroupParent, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the processes of a service which haven't exited yet keep its cgroup from being removed
+	procs := filepath.Join(dir, resource.CgroupParent, exiting, "cgroup.procs")
+	if err := ioutil.WriteFile(procs, []byte("1"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m.pruneCgroups(map[string]bool{current: true})
+	if names, _ := resource.ListCgroups(); len(names) != 2 {
+		t.Fatalf("Expected the cgroup of the deleted service to be removed, got %v", names)
+	}
+
+	// the cgroup is removed by a later sync once the processes have exited
+	os.Remove(procs)
+	m.pruneCgroups(map[string]bool{current: true})
+	if names, _ := resource.ListCgroups(); len(names) != 1 || names[0] != current {
+		t.Fatalf("Expected only the cgroup of the current service, got %v", names)
+	}
+}
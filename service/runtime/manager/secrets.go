@@ -0,0 +1,148 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/internal/secret"
+)
+
+const (
+	// secretPrefix is prefixed to the key for secret records
+	secretPrefix = "secret:"
+	// SecretRef prefixes the value of an env var which references a secret, e.g. DB_PASSWORD=secret:db
+	SecretRef = "secret:"
+)
+
+var (
+	// ErrNoSecretKey is returned when secrets are used but the manager has no key to encrypt them
+	ErrNoSecretKey = errors.New("Secrets are not enabled, the runtime has no secret key")
+	// secretName is the format of a valid secret name
+	secretName = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+)
+
+// Secrets is implemented by the manager to store the secrets referenced by services. The values
+// are encrypted at rest and are never returned, only resolved when the service is run.
+type Secrets interface {
+	// SetSecret creates or updates a secret in the namespace
+	SetSecret(ns, name, value string) error
+	// DeleteSecret removes a secret from the namespace
+	DeleteSecret(ns, name string) error
+	// ListSecrets returns the names of the secrets in the namespace
+	ListSecrets(ns string) ([]string, error)
+}
+
+// SetSecret encrypts the value and writes it to the store
+func (m *manager) SetSecret(ns, name, value string) error {
+	if len(ns) == 0 {
+		ns = namespace.DefaultNamespace
+	}
+
+	if len(m.options.SecretKey) == 0 {
+		return ErrNoSecretKey
+	}
+	if !secretName.MatchString(name) {
+		return fmt.Errorf("Invalid secret name %v, only letters, numbers, '_', '.' and '-' are allowed", name)
+	}
+
+	ciphertext, err := secret.Encrypt(m.options.SecretKey, []byte(value))
+	if err != nil {
+		return err
+	}
+
+	return m.options.Store.Write(&store.Record{Key: secretPrefix + ns + ":" + name, Value: ciphertext})
+}
+
+// DeleteSecret removes the secret from the store
+func (m *manager) DeleteSecret(ns, name string) error {
+	if len(ns) == 0 {
+		ns = namespace.DefaultNamespace
+	}
+
+	return m.options.Store.Delete(secretPrefix + ns + ":" + name)
+}
+
+// ListSecrets returns the names of the secrets in a namespace
+func (m *manager) ListSecrets(ns string) ([]string, error) {
+	if len(ns) == 0 {
+		ns = namespace.DefaultNamespace
+	}
+
+	prefix := secretPrefix + ns + ":"
+	recs, err := m.options.Store.Read(prefix, store.ReadPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("Error listing secrets from the store for namespace %v: %v", ns, err)
+	}
+
+	names := make([]string, 0, len(recs))
+	for _, rec := range recs {
+		names = append(names, strings.TrimPrefix(rec.Key, prefix))
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// readSecret returns the decrypted value of a secret
+func (m *manager) readSecret(ns, name string) (string, error) {
+	if len(m.options.SecretKey) == 0 {
+		return "", ErrNoSecretKey
+	}
+
+	recs, err := m.options.Store.Read(secretPrefix + ns + ":" + name)
+	if err == store.ErrNotFound {
+		return "", fmt.Errorf("Secret %v not found in namespace %v", name, ns)
+	} else if err != nil {
+		return "", err
+	}
+
+	plaintext, err := secret.Decrypt(m.options.SecretKey, recs[0].Value)
+	if err != nil {
+		return "", fmt.Errorf("Error decrypting secret %v: %v", name, err)
+	}
+	return string(plaintext), nil
+}
+
+// checkSecrets verifies the secrets referenced by the env vars exist, without decrypting them
+func (m *manager) checkSecrets(ns string, env []string) error {
+	for _, v := range env {
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[1], SecretRef) {
+			continue
+		}
+		if len(m.options.SecretKey) == 0 {
+			return ErrNoSecretKey
+		}
+
+		name := strings.TrimPrefix(parts[1], SecretRef)
+		if _, err := m.options.Store.Read(secretPrefix + ns + ":" + name); err == store.ErrNotFound {
+			return fmt.Errorf("Secret %v not found in namespace %v", name, ns)
+		} else if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveSecrets replaces the env vars which reference a secret with the value of the secret
+func (m *manager) resolveSecrets(ns string, env map[string]string) error {
+	for k, v := range env {
+		if !strings.HasPrefix(v, SecretRef) {
+			continue
+		}
+
+		val, err := m.readSecret(ns, strings.TrimPrefix(v, SecretRef))
+		if err != nil {
+			return err
+		}
+		env[k] = val
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package manager
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/go-micro/v2/store/memory"
+	"github.com/micro/micro/v2/internal/namespace"
+	"github.com/micro/micro/v2/internal/secret"
+)
+
+func TestSecrets(t *testing.T) {
+	key, err := secret.GenerateKey()
+	if err != nil {
+		t.Fatalf("Unexpected error generating key: %v", err)
+	}
+
+	st := memory.NewStore()
+	m := New(&testRuntime{}, Store(st), SecretKey(key)).(*manager)
+	ns := namespace.DefaultNamespace
+
+	if err := m.SetSecret(ns, "db_password", "hunter2"); err != nil {
+		t.Fatalf("Unexpected error setting secret: %v", err)
+	}
+	if err := m.SetSecret(ns, "invalid:name", "foo"); err == nil {
+		t.Errorf("Expected an error setting a secret with an invalid name")
+	}
+
+	names, err := m.ListSecrets(ns)
+	if err != nil {
+		t.Fatalf("Unexpected error listing secrets: %v", err)
+	}
+	if len(names) != 1 || names[0] != "db_password" {
+		t.Errorf("Expected secret db_password to be listed but got %v", names)
+	}
+
+	// the service references the secret by name, the value should only be resolved in the env
+	// passed to the runtime
+	srv := &runtime.Service{Name: "go.micro.service.foo", Version: "latest"}
+	opts := &runtime.CreateOptions{Namespace: ns, Env: []string{"DB_PASSWORD=" + SecretRef + "db_password"}}
+
+	if err := m.Create(srv, runtime.CreateNamespace(ns), runtime.WithEnv(opts.Env)); err != nil {
+		t.Fatalf("Unexpected error creating service: %v", err)
+	}
+	recs, err := st.Read("", store.ReadPrefix())
+	if err != nil {
+		t.Fatalf("Unexpected error reading the store: %v", err)
+	}
+	for _, rec := range recs {
+		if strings.Contains(string(rec.Value), "hunter2") {
+			t.Errorf("Secret value was written to the store in plaintext under %v", rec.Key)
+		}
+	}
+
+	env, err := m.runtimeEnv(ns, srv, opts)
+	if err != nil {
+		t.Fatalf("Unexpected error resolving the env: %v", err)
+	}
+	if len(env) != 2 || !contains(env, "DB_PASSWORD=hunter2") {
+		t.Errorf("Expected the secret to be resolved in the env but got %v", env)
+	}
+
+	// services can't reference secrets which don't exist
+	missing := runtime.WithEnv([]string{"API_KEY=" + SecretRef + "api_key"})
+	if err := m.Create(&runtime.Service{Name: "go.micro.service.bar"}, missing); err == nil {
+		t.Errorf("Expected an error creating a service referencing a missing secret")
+	}
+
+	if err := m.DeleteSecret(ns, "db_password"); err != nil {
+		t.Fatalf("Unexpected error deleting secret: %v", err)
+	}
+	if _, err := m.runtimeEnv(ns, srv, opts); err == nil {
+		t.Errorf("Expected an error resolving a deleted secret")
+	}
+
+	// without a key secrets can't be used
+	m = New(&testRuntime{}, Store(memory.NewStore())).(*manager)
+	if err := m.SetSecret(ns, "db_password", "hunter2"); err != ErrNoSecretKey {
+		t.Errorf("Expected ErrNoSecretKey but got %v", err)
+	}
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
@@ -42,6 +42,22 @@ func (m *manager) createService(srv *runtime.Service, opts *runtime.CreateOption
 // readServices returns all the services in a given namespace. If a service name and
 // version are provided it will filter using these as well
 func (m *manager) readServices(namespace string, srv *runtime.Service) ([]*runtime.Service, error) {
+	objs, err := m.readObjects(namespace, srv)
+	if err != nil {
+		return nil, err
+	}
+
+	srvs := make([]*runtime.Service, 0, len(objs))
+	for _, s := range objs {
+		srvs = append(srvs, s.Service)
+	}
+
+	return srvs, nil
+}
+
+// readObjects returns the objects persisted in the store for a given namespace, which include the
+// options the services were created with. It filters using the service name and version provided.
+func (m *manager) readObjects(namespace string, srv *runtime.Service) ([]*service, error) {
 	prefix := servicePrefix + namespace + ":"
 	if len(srv.Name) > 0 {
 		prefix += srv.Name + ":"
@@ -53,20 +69,32 @@ func (m *manager) readServices(namespace string, srv *runtime.Service) ([]*runti
 	recs, err := m.options.Store.Read(prefix, store.ReadPrefix())
 	if err != nil {
 		return nil, err
-	} else if len(recs) == 0 {
-		return make([]*runtime.Service, 0), nil
 	}
 
-	srvs := make([]*runtime.Service, 0, len(recs))
+	objs := make([]*service, 0, len(recs))
 	for _, r := range recs {
 		var s *service
 		if err := json.Unmarshal(r.Value, &s); err != nil {
 			return nil, err
 		}
-		srvs = append(srvs, s.Service)
+		objs = append(objs, s)
 	}
 
-	return srvs, nil
+	return objs, nil
+}
+
+// serviceType returns the type the service was created with, blank if it's not in the store
+func (m *manager) serviceType(namespace string, srv *runtime.Service) string {
+	objs, err := m.readObjects(namespace, srv)
+	if err != nil {
+		return ""
+	}
+	for _, s := range objs {
+		if s.Service.Version == srv.Version && s.Options != nil {
+			return s.Options.Type
+		}
+	}
+	return ""
 }
 
 // deleteSevice from the store
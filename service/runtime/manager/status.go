@@ -3,12 +3,14 @@ package manager
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/micro/go-micro/v2/logger"
 	"github.com/micro/go-micro/v2/runtime"
 	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/internal/namespace"
 )
 
 // statusPrefix is prefixed to every status key written to the memory store
@@ -16,25 +18,58 @@ const statusPrefix = "status:"
 
 // serviceStatus contains the runtime specific information for a service
 type serviceStatus struct {
-	Status string
-	Error  string
+	Status   string
+	Error    string
+	Restarts int64
 }
 
-// statusPollFrequency is the max frequency the manager will check for new statuses in the runtime
-var statusPollFrequency = time.Second * 30
+var (
+	// statusPollFrequency is the max frequency the manager will check for new statuses in runtimes
+	// which don't notify the manager of changes
+	statusPollFrequency = time.Second * 10
+	// statusResyncFrequency is how often the manager reads the status of every service from runtimes
+	// which notify it of changes, so the changes of any notifications which were missed are loaded
+	statusResyncFrequency = time.Minute
+)
 
-// watchStatus calls syncStatus periodically and should be run in a seperate go routine
+// watchStatus consumes the status changes from the runtime and calls syncStatus periodically in case
+// a notification was missed, falling back to polling if the runtime doesn't support notifications.
+// It should be run in a seperate go routine.
 func (m *manager) watchStatus() {
-	ticker := time.NewTicker(statusPollFrequency)
+	// load the current status of all the services before consuming changes
+	m.syncStatus()
+
+	var events <-chan runtime.Event
+	frequency := statusPollFrequency
+	if n, ok := m.Runtime.(StatusNotifier); ok {
+		if ch, err := n.NotifyStatus(); err != nil {
+			logger.Warnf("Error watching status, falling back to polling: %v", err)
+		} else {
+			events = ch
+			frequency = statusResyncFrequency
+		}
+	}
 
+	ticker := time.NewTicker(frequency)
 	for {
-		m.syncStatus()
-		<-ticker.C
+		select {
+		case ev, ok := <-events:
+			if ok {
+				m.notifyStatus(ev)
+				continue
+			}
+			logger.Warnf("Status notifications stopped, falling back to polling")
+			events = nil
+			ticker.Stop()
+			ticker = time.NewTicker(statusPollFrequency)
+		case <-ticker.C:
+			m.syncStatus()
+		}
 	}
 }
 
 // syncStatus calls the managed runtime, gets the serviceStatus for all services listed in the
-// store and writes it to the memory store
+// store and writes it to the memory store, recording any changes in the timeline
 func (m *manager) syncStatus() {
 	namespaces, err := m.listNamespaces()
 	if err != nil {
@@ -43,19 +78,54 @@ func (m *manager) syncStatus() {
 	}
 
 	for _, ns := range namespaces {
-		srvs, err := m.Runtime.Read(runtime.ReadNamespace(ns))
-		if err != nil {
-			logger.Warnf("Error reading namespace %v: %v", ns, err)
+		if err := m.syncNamespace(ns); err != nil {
+			logger.Warnf("Error syncing status of namespace %v: %v", ns, err)
 			return
 		}
+	}
+}
 
-		for _, srv := range srvs {
-			if err := m.cacheStatus(ns, srv); err != nil {
-				logger.Warnf("Error caching status: %v", err)
-				return
-			}
+// syncNamespace calls the managed runtime and updates the status of the services in a namespace
+func (m *manager) syncNamespace(ns string) error {
+	srvs, err := m.Runtime.Read(runtime.ReadNamespace(ns))
+	if err != nil {
+		return err
+	}
+
+	for _, srv := range srvs {
+		if err := m.updateStatus(ns, srv); err != nil {
+			return err
 		}
 	}
+	return nil
+}
+
+// notifyStatus updates the status of the service of an event from the runtime. Runtimes which know
+// something changed but not which service send an event without a service, the status of every
+// service in the namespace of the event, or every namespace if it's not set, is synced instead.
+func (m *manager) notifyStatus(ev runtime.Event) {
+	var ns string
+	if ev.Options != nil {
+		ns = ev.Options.Namespace
+	}
+
+	if ev.Service == nil && len(ns) == 0 {
+		m.syncStatus()
+		return
+	}
+	if len(ns) == 0 {
+		ns = namespace.DefaultNamespace
+	}
+
+	var err error
+	if ev.Service == nil {
+		err = m.syncNamespace(ns)
+	} else {
+		err = m.updateStatus(ns, ev.Service)
+	}
+	if err != nil {
+		logger.Warnf("Error updating status: %v", err)
+	}
 }
 
 // cacheStatus writes a services status to the memory store which is then later returned in service
@@ -69,6 +139,7 @@ func (m *manager) cacheStatus(ns string, srv *runtime.Service) error {
 
 	key := fmt.Sprintf("%v%v:%v:%v", statusPrefix, ns, srv.Name, srv.Version)
 	val := &serviceStatus{Status: srv.Metadata["status"], Error: srv.Metadata["error"]}
+	val.Restarts, _ = strconv.ParseInt(srv.Metadata["retries"], 10, 64)
 
 	bytes, err := json.Marshal(val)
 	if err != nil {
@@ -78,6 +149,23 @@ func (m *manager) cacheStatus(ns string, srv *runtime.Service) error {
 	return m.cache.Write(&store.Record{Key: key, Value: bytes})
 }
 
+// readStatus returns the cached status of a service, nil is returned if the status isn't known
+func (m *manager) readStatus(ns string, srv *runtime.Service) (*serviceStatus, error) {
+	key := fmt.Sprintf("%v%v:%v:%v", statusPrefix, ns, srv.Name, srv.Version)
+	recs, err := m.cache.Read(key)
+	if err == store.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var status *serviceStatus
+	if err := json.Unmarshal(recs[0].Value, &status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
 // listStautuses returns all the statuses for the services in a given namespace with 'name:version'
 // as the format used for the keys in the map.
 func (m *manager) listStatuses(ns string) (map[string]*serviceStatus, error) {
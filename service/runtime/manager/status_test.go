@@ -52,6 +52,10 @@ func (r *testRuntime) Read(opts ...runtime.ReadOption) ([]*runtime.Service, erro
 	return r.readServices, nil
 }
 
+func (r *testRuntime) String() string {
+	return "local"
+}
+
 func TestStatus(t *testing.T) {
 	testServices := []*runtime.Service{
 		&runtime.Service{
@@ -0,0 +1,273 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/go-micro/v2/store"
+	"github.com/micro/micro/v2/internal/namespace"
+	pb "github.com/micro/micro/v2/service/runtime/proto"
+)
+
+const (
+	// timelinePrefix is prefixed to the key for status event records
+	timelinePrefix = "timeline/"
+	// StatusTopic is the broker topic status events are published to
+	StatusTopic = "go.micro.runtime.status"
+)
+
+// the types of status event recorded in the timeline
+const (
+	eventStarting  = "starting"
+	eventRunning   = "running"
+	eventCrashed   = "crashed"
+	eventRestarted = "restarted"
+	eventStopped   = "stopped"
+)
+
+var (
+	// timelineTTL is the duration status events will persist in the store before expiring
+	timelineTTL = time.Hour * 24
+	// timelineBuffer is the number of events buffered for each watcher before they're dropped
+	timelineBuffer = 64
+	// exitStatus matches the exit code in the error returned when a process exits
+	exitStatus = regexp.MustCompile(`exit status (\d+)`)
+)
+
+// Timeline is implemented by the manager to return the status history of services
+type Timeline interface {
+	// Timeline returns the status events for the services in a namespace, oldest first. The
+	// name and version of the service are optional filters.
+	Timeline(ns string, srv *runtime.Service) ([]*pb.StatusEvent, error)
+	// WatchTimeline returns status events for a namespace as they are recorded
+	WatchTimeline(ns string) (TimelineWatcher, error)
+}
+
+// TimelineWatcher streams status events
+type TimelineWatcher interface {
+	Chan() <-chan *pb.StatusEvent
+	Stop()
+}
+
+// StatusNotifier is implemented by runtimes which notify the manager when the status of a service
+// changes, removing the need to poll the runtime. The status is set in the service metadata and
+// the namespace in the event options. Events without a service make the manager read the status of
+// the services in the namespace, or every namespace if it's not set.
+type StatusNotifier interface {
+	NotifyStatus() (<-chan runtime.Event, error)
+}
+
+// timelineWatchers are subscribed to the events recorded by the manager
+type timelineWatchers struct {
+	sync.RWMutex
+	watchers map[string]*timelineWatcher
+}
+
+type timelineWatcher struct {
+	id     string
+	ns     string
+	events chan *pb.StatusEvent
+	stop   func()
+}
+
+func (w *timelineWatcher) Chan() <-chan *pb.StatusEvent {
+	return w.events
+}
+
+func (w *timelineWatcher) Stop() {
+	w.stop()
+}
+
+// timelineKey is the prefix for the keys of events recorded for a service
+func timelineKey(ns, name, version string) string {
+	return fmt.Sprintf("%v%v:%v:%v/", timelinePrefix, ns, name, version)
+}
+
+// statusEvent returns the event which transitioned the service from the previous status, or nil if
+// the status hasn't changed
+func statusEvent(ns string, prev *serviceStatus, srv *runtime.Service) *pb.StatusEvent {
+	status := srv.Metadata["status"]
+	restarts, _ := strconv.ParseInt(srv.Metadata["retries"], 10, 64)
+	if prev != nil && prev.Status == status && prev.Restarts >= restarts {
+		return nil
+	}
+
+	ev := &pb.StatusEvent{
+		Id:        uuid.New().String(),
+		Namespace: ns,
+		Service:   srv.Name,
+		Version:   srv.Version,
+		Status:    status,
+		Error:     srv.Metadata["error"],
+		Restarts:  restarts,
+		Timestamp: time.Now().UnixNano(),
+	}
+
+	switch status {
+	case "error":
+		ev.Type = eventCrashed
+		if m := exitStatus.FindStringSubmatch(ev.Error); len(m) == 2 {
+			ev.ExitCode, _ = strconv.ParseInt(m[1], 10, 64)
+		}
+		return ev
+	case "stopped":
+		ev.Type = eventStopped
+		return ev
+	case "running":
+		ev.Type = eventRunning
+	default:
+		ev.Type = eventStarting
+	}
+
+	// a service which is starting again after crashing or has been retried by the runtime has
+	// been restarted
+	if prev != nil && (prev.Status == "error" || restarts > prev.Restarts) {
+		ev.Type = eventRestarted
+	}
+
+	return ev
+}
+
+// updateStatus caches the status of a service and records an event in the timeline if it changed
+func (m *manager) updateStatus(ns string, srv *runtime.Service) error {
+	prev, err := m.readStatus(ns, srv)
+	if err != nil {
+		return err
+	}
+	if err := m.cacheStatus(ns, srv); err != nil {
+		return err
+	}
+	if ev := statusEvent(ns, prev, srv); ev != nil {
+		return m.recordEvent(ev)
+	}
+	return nil
+}
+
+// deleteStatus removes the cached status of a service once it's been deleted from the runtime and
+// records it as stopped
+func (m *manager) deleteStatus(ns string, srv *runtime.Service) error {
+	key := fmt.Sprintf("%v%v:%v:%v", statusPrefix, ns, srv.Name, srv.Version)
+	if err := m.cache.Delete(key); err != nil && err != store.ErrNotFound {
+		return err
+	}
+
+	return m.recordEvent(&pb.StatusEvent{
+		Id:        uuid.New().String(),
+		Namespace: ns,
+		Service:   srv.Name,
+		Version:   srv.Version,
+		Type:      eventStopped,
+		Status:    "stopped",
+		Timestamp: time.Now().UnixNano(),
+	})
+}
+
+// recordEvent writes the event to the timeline in the global store, sends it to any watchers and
+// publishes it to the broker
+func (m *manager) recordEvent(ev *pb.StatusEvent) error {
+	logger.Infof("Service %v:%v in namespace %v %v", ev.Service, ev.Version, ev.Namespace, ev.Type)
+
+	bytes, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	// timestamps are zero padded so the events for a service are ordered by key
+	key := fmt.Sprintf("%v%020d", timelineKey(ev.Namespace, ev.Service, ev.Version), ev.Timestamp)
+	if err := m.options.Store.Write(&store.Record{Key: key, Value: bytes, Expiry: timelineTTL}); err != nil {
+		return err
+	}
+
+	m.timeline.RLock()
+	for _, w := range m.timeline.watchers {
+		if w.ns != ev.Namespace {
+			continue
+		}
+		select {
+		case w.events <- ev:
+		default:
+			logger.Warnf("Timeline watcher %v is blocked, dropping event %v", w.id, ev.Id)
+		}
+	}
+	m.timeline.RUnlock()
+
+	if m.options.Publisher == nil {
+		return nil
+	}
+	if err := m.options.Publisher.Publish(context.Background(), ev); err != nil {
+		logger.Warnf("Error publishing status event %v: %v", ev.Id, err)
+	}
+	return nil
+}
+
+// Timeline returns the status events for the services in a namespace, oldest first
+func (m *manager) Timeline(ns string, srv *runtime.Service) ([]*pb.StatusEvent, error) {
+	if len(ns) == 0 {
+		ns = namespace.DefaultNamespace
+	}
+
+	prefix := timelinePrefix + ns + ":"
+	if len(srv.Name) > 0 && len(srv.Version) > 0 {
+		prefix = timelineKey(ns, srv.Name, srv.Version)
+	} else if len(srv.Name) > 0 {
+		prefix += srv.Name + ":"
+	}
+
+	recs, err := m.options.Store.Read(prefix, store.ReadPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("Error reading timeline from the store for namespace %v: %v", ns, err)
+	}
+
+	events := make([]*pb.StatusEvent, 0, len(recs))
+	for _, rec := range recs {
+		var ev *pb.StatusEvent
+		if err := json.Unmarshal(rec.Value, &ev); err != nil {
+			return nil, err
+		}
+		events = append(events, ev)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Timestamp < events[j].Timestamp })
+	return events, nil
+}
+
+// WatchTimeline returns the status events for a namespace as they are recorded
+func (m *manager) WatchTimeline(ns string) (TimelineWatcher, error) {
+	if len(ns) == 0 {
+		ns = namespace.DefaultNamespace
+	}
+
+	w := &timelineWatcher{
+		id:     uuid.New().String(),
+		ns:     ns,
+		events: make(chan *pb.StatusEvent, timelineBuffer),
+	}
+
+	var once sync.Once
+	w.stop = func() {
+		once.Do(func() {
+			m.timeline.Lock()
+			delete(m.timeline.watchers, w.id)
+			m.timeline.Unlock()
+			close(w.events)
+		})
+	}
+
+	m.timeline.Lock()
+	if m.timeline.watchers == nil {
+		m.timeline.watchers = make(map[string]*timelineWatcher)
+	}
+	m.timeline.watchers[w.id] = w
+	m.timeline.Unlock()
+
+	return w, nil
+}
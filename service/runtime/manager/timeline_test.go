@@ -0,0 +1,189 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/go-micro/v2/store/memory"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+func TestTimeline(t *testing.T) {
+	rt := &testRuntime{}
+	m := New(rt, Store(memory.NewStore())).(*manager)
+
+	w, err := m.WatchTimeline(namespace.DefaultNamespace)
+	if err != nil {
+		t.Fatalf("Unexpected error when watching the timeline: %v", err)
+	}
+	defer w.Stop()
+
+	// the service transitions through each of these statuses, a status which doesn't change
+	// shouldn't result in an event
+	srv := &runtime.Service{Name: "go.micro.service.foo", Version: "latest"}
+	transitions := []struct {
+		Metadata map[string]string
+		Type     string
+		ExitCode int64
+	}{
+		{map[string]string{"status": "starting"}, eventStarting, 0},
+		{map[string]string{"status": "running"}, eventRunning, 0},
+		{map[string]string{"status": "running"}, "", 0},
+		{map[string]string{"status": "error", "error": "exit status 2"}, eventCrashed, 2},
+		{map[string]string{"status": "starting", "retries": "1"}, eventRestarted, 0},
+		{map[string]string{"status": "running", "retries": "1"}, eventRunning, 0},
+	}
+
+	var expected []string
+	for _, tr := range transitions {
+		srv.Metadata = tr.Metadata
+		if err := m.updateStatus(namespace.DefaultNamespace, srv); err != nil {
+			t.Fatalf("Unexpected error when updating status: %v", err)
+		}
+		if len(tr.Type) == 0 {
+			continue
+		}
+		expected = append(expected, tr.Type)
+
+		select {
+		case ev := <-w.Chan():
+			if ev.Type != tr.Type {
+				t.Errorf("Expected %v event but got %v", tr.Type, ev.Type)
+			}
+			if ev.ExitCode != tr.ExitCode {
+				t.Errorf("Expected exit code %v but got %v", tr.ExitCode, ev.ExitCode)
+			}
+		case <-time.After(time.Millisecond * 100):
+			t.Fatalf("Expected %v event to be sent to the watcher", tr.Type)
+		}
+	}
+
+	// deleting the service should record it as stopped
+	if err := m.deleteStatus(namespace.DefaultNamespace, srv); err != nil {
+		t.Fatalf("Unexpected error when deleting status: %v", err)
+	}
+	expected = append(expected, eventStopped)
+
+	// the persisted timeline should contain every event in order
+	events, err := m.Timeline(namespace.DefaultNamespace, &runtime.Service{Name: srv.Name})
+	if err != nil {
+		t.Fatalf("Unexpected error when reading the timeline: %v", err)
+	}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %v events but got %v", len(expected), len(events))
+	}
+	for i, ev := range events {
+		if ev.Type != expected[i] {
+			t.Errorf("Expected event %v to be %v but got %v", i, expected[i], ev.Type)
+		}
+	}
+
+	// events in other namespaces shouldn't be returned
+	events, err = m.Timeline("foo", &runtime.Service{})
+	if err != nil {
+		t.Fatalf("Unexpected error when reading the timeline: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("Expected no events in namespace foo but got %v", len(events))
+	}
+}
+
+// notifyingRuntime notifies the manager of the status changes of the services
+type notifyingRuntime struct {
+	*testRuntime
+	notifications chan runtime.Event
+}
+
+func (r *notifyingRuntime) NotifyStatus() (<-chan runtime.Event, error) {
+	return r.notifications, nil
+}
+
+func TestStatusNotifier(t *testing.T) {
+	// the status shouldn't reach the timeline by polling
+	statusPollFrequency = time.Hour
+	statusResyncFrequency = time.Hour
+	defer func() {
+		statusPollFrequency = time.Second * 10
+		statusResyncFrequency = time.Minute
+	}()
+
+	srv := &runtime.Service{
+		Name:     "go.micro.service.foo",
+		Version:  "latest",
+		Metadata: map[string]string{"status": "running"},
+	}
+	rt := &notifyingRuntime{
+		testRuntime:   &testRuntime{readServices: []*runtime.Service{srv}},
+		notifications: make(chan runtime.Event),
+	}
+	m := New(rt, Store(memory.NewStore())).(*manager)
+
+	w, err := m.WatchTimeline("foo")
+	if err != nil {
+		t.Fatalf("Unexpected error when watching the timeline: %v", err)
+	}
+	defer w.Stop()
+	go m.watchStatus()
+
+	expect := func(typ string) {
+		select {
+		case ev := <-w.Chan():
+			if ev.Type != typ || ev.Service != srv.Name {
+				t.Errorf("Expected %v event for %v but got %v for %v", typ, srv.Name, ev.Type, ev.Service)
+			}
+		case <-time.After(time.Millisecond * 100):
+			t.Fatalf("Expected %v event to be sent to the watcher", typ)
+		}
+	}
+
+	// the runtime notifies the manager of the status of the service
+	rt.notifications <- runtime.Event{
+		Type:    runtime.Update,
+		Service: &runtime.Service{Name: srv.Name, Version: srv.Version, Metadata: map[string]string{"status": "running"}},
+		Options: &runtime.CreateOptions{Namespace: "foo"},
+	}
+	expect(eventRunning)
+
+	// the runtime notifies the manager the status of the services of the namespace changed, the
+	// manager reads them from the runtime
+	srv.Metadata = map[string]string{"status": "error", "error": "exit status 1"}
+	rt.notifications <- runtime.Event{Type: runtime.Update, Options: &runtime.CreateOptions{Namespace: "foo"}}
+	expect(eventCrashed)
+}
+
+func TestStatusResync(t *testing.T) {
+	statusResyncFrequency = time.Millisecond * 10
+	defer func() { statusResyncFrequency = time.Minute }()
+
+	srv := &runtime.Service{
+		Name:     "go.micro.service.foo",
+		Version:  "latest",
+		Metadata: map[string]string{"status": "running"},
+	}
+	rt := &notifyingRuntime{
+		testRuntime:   &testRuntime{readServices: []*runtime.Service{srv}},
+		notifications: make(chan runtime.Event),
+	}
+	m := New(rt, Store(memory.NewStore())).(*manager)
+	if err := m.createService(srv, &runtime.CreateOptions{Namespace: "foo"}); err != nil {
+		t.Fatalf("Unexpected error when creating service: %v", err)
+	}
+
+	w, err := m.WatchTimeline("foo")
+	if err != nil {
+		t.Fatalf("Unexpected error when watching the timeline: %v", err)
+	}
+	defer w.Stop()
+	go m.watchStatus()
+
+	// the status is loaded by the resync without a notification from the runtime
+	select {
+	case ev := <-w.Chan():
+		if ev.Type != eventRunning || ev.Service != srv.Name {
+			t.Errorf("Expected %v event for %v but got %v for %v", eventRunning, srv.Name, ev.Type, ev.Service)
+		}
+	case <-time.After(time.Millisecond * 200):
+		t.Fatalf("Expected the status to be resynced")
+	}
+}
@@ -0,0 +1,23 @@
+// +build !windows
+
+package notifier
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchExits calls the func when child processes exit. The signals of exits which happen while the
+// func is running are merged into one call.
+func watchExits(fn func()) error {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGCHLD)
+
+	go func() {
+		for range ch {
+			fn()
+		}
+	}()
+	return nil
+}
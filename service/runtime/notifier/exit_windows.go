@@ -0,0 +1,10 @@
+// +build windows
+
+package notifier
+
+import "errors"
+
+// watchExits is not supported on windows, the manager polls the status of the services instead
+func watchExits(fn func()) error {
+	return errors.New("process exits can't be watched on windows")
+}
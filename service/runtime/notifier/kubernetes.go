@@ -0,0 +1,107 @@
+package notifier
+
+import (
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/logger"
+	"github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/go-micro/v2/util/kubernetes/client"
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// watchRetry is how long to wait before watching the pods of a namespace again after it failed
+var watchRetry = time.Second * 5
+
+type kubernetes struct {
+	*notifier
+	client client.Client
+
+	sync.Mutex
+	// watching is the namespaces whose pods are watched
+	watching map[string]bool
+}
+
+// Kubernetes returns the kubernetes runtime which notifies the manager when the pods of the
+// services of a namespace change. The pods of a namespace are watched once the manager has read or
+// created services in it.
+func Kubernetes(rt runtime.Runtime, c client.Client) runtime.Runtime {
+	return &kubernetes{
+		notifier: newNotifier(rt),
+		client:   c,
+		watching: make(map[string]bool),
+	}
+}
+
+// Create watches the pods of the namespace and creates the service
+func (k *kubernetes) Create(srv *runtime.Service, opts ...runtime.CreateOption) error {
+	var options runtime.CreateOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	k.watch(options.Namespace)
+
+	return k.notifier.Create(srv, opts...)
+}
+
+// Read watches the pods of the namespace and reads its services
+func (k *kubernetes) Read(opts ...runtime.ReadOption) ([]*runtime.Service, error) {
+	var options runtime.ReadOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	k.watch(options.Namespace)
+
+	return k.Runtime.Read(opts...)
+}
+
+// watch starts watching the pods of the namespace unless they're already watched
+func (k *kubernetes) watch(ns string) {
+	if len(ns) == 0 {
+		ns = namespace.DefaultNamespace
+	}
+
+	k.Lock()
+	defer k.Unlock()
+	if k.watching[ns] {
+		return
+	}
+	k.watching[ns] = true
+
+	go k.watchPods(ns)
+}
+
+// watchPods notifies the manager when the pods of the services of a namespace change. The watch is
+// started again when the api server ends it, which it does periodically.
+func (k *kubernetes) watchPods(ns string) {
+	for {
+		w, err := k.client.Watch(
+			&client.Resource{Kind: "pod"},
+			client.WatchNamespace(ns),
+			// the pods of the services are labelled with their type
+			client.WatchParams(map[string]string{"labelSelector": "micro"}),
+		)
+		if err != nil {
+			logger.Warnf("Error watching the pods of namespace %v: %v", ns, err)
+			time.Sleep(watchRetry)
+			continue
+		}
+
+		var events int
+		for ev := range w.Chan() {
+			if ev.Type == client.Error {
+				logger.Warnf("Error watching the pods of namespace %v: %s", ns, ev.Object)
+				continue
+			}
+			events++
+			k.notify(ns)
+		}
+		w.Stop()
+
+		// the pods may have changed before the watch is started again
+		k.notify(ns)
+		if events == 0 {
+			time.Sleep(watchRetry)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package notifier
+
+import (
+	"sync"
+	"time"
+
+	"github.com/micro/go-micro/v2/runtime"
+)
+
+// exitDelay is how long after a process exits the manager is notified, the local runtime updates
+// the status of the service once it has waited for the process
+var exitDelay = time.Millisecond * 250
+
+type local struct {
+	*notifier
+	once sync.Once
+	err  error
+
+	sync.Mutex
+	// pids are the namespaces of the processes of the services by pid
+	pids map[int]string
+}
+
+// Local returns the local runtime which notifies the manager when the processes of its services
+// exit. The manager is notified of the namespaces of the processes which exited, or of every
+// namespace if the processes can't be listed.
+func Local(rt runtime.Runtime) runtime.Runtime {
+	return &local{notifier: newNotifier(rt), pids: make(map[int]string)}
+}
+
+// Create creates the service and tracks the namespace of its process
+func (l *local) Create(srv *runtime.Service, opts ...runtime.CreateOption) error {
+	err := l.notifier.Create(srv, opts...)
+	l.track()
+	return err
+}
+
+// Update updates the service and tracks the namespace of its new process
+func (l *local) Update(srv *runtime.Service, opts ...runtime.UpdateOption) error {
+	err := l.notifier.Update(srv, opts...)
+	l.track()
+	return err
+}
+
+// track the namespaces of the processes which have started, the processes which have exited are
+// only removed by exited so their namespaces are still notified
+func (l *local) track() {
+	current, err := childNamespaces()
+	if err != nil {
+		return
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	for pid, ns := range current {
+		l.pids[pid] = ns
+	}
+}
+
+// exited returns the namespaces of the tracked processes which have exited and tracks the processes
+// running now. It returns false if the processes can't be listed.
+func (l *local) exited() ([]string, bool) {
+	current, err := childNamespaces()
+	if err != nil {
+		return nil, false
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	seen := make(map[string]bool)
+	var namespaces []string
+	for pid, ns := range l.pids {
+		if _, ok := current[pid]; ok || seen[ns] {
+			continue
+		}
+		seen[ns] = true
+		namespaces = append(namespaces, ns)
+	}
+	l.pids = current
+	return namespaces, true
+}
+
+// NotifyStatus starts watching the exits of the processes and returns the notifications
+func (l *local) NotifyStatus() (<-chan runtime.Event, error) {
+	l.once.Do(func() {
+		l.track()
+		l.err = watchExits(func() {
+			time.Sleep(exitDelay)
+			namespaces, ok := l.exited()
+			if !ok {
+				l.notify("")
+				return
+			}
+			for _, ns := range namespaces {
+				l.notify(ns)
+			}
+		})
+	})
+	if l.err != nil {
+		return nil, l.err
+	}
+	return l.events, nil
+}
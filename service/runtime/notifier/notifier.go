@@ -0,0 +1,68 @@
+// Package notifier notifies the runtime manager when the status of the services of the local and
+// kubernetes runtimes may have changed, so the changes reach the timeline without waiting for the
+// manager to poll the runtime.
+package notifier
+
+import (
+	"github.com/micro/go-micro/v2/runtime"
+)
+
+// notifyBuffer is the number of pending notifications, a notification which doesn't fit is dropped
+// since the pending notification of the namespace will read its current status anyway
+var notifyBuffer = 64
+
+// notifier wraps a runtime and sends an event without a service when the status of the services of
+// a namespace may have changed, the manager reads their status from the runtime then
+type notifier struct {
+	runtime.Runtime
+	events chan runtime.Event
+}
+
+func newNotifier(rt runtime.Runtime) *notifier {
+	return &notifier{
+		Runtime: rt,
+		events:  make(chan runtime.Event, notifyBuffer),
+	}
+}
+
+// notify asks the manager to read the status of the services of the namespace, or of every
+// namespace if it's not set
+func (n *notifier) notify(ns string) {
+	ev := runtime.Event{
+		Type:    runtime.Update,
+		Options: &runtime.CreateOptions{Namespace: ns},
+	}
+	select {
+	case n.events <- ev:
+	default:
+	}
+}
+
+// Create creates the service and notifies the manager of its status
+func (n *notifier) Create(srv *runtime.Service, opts ...runtime.CreateOption) error {
+	var options runtime.CreateOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	err := n.Runtime.Create(srv, opts...)
+	n.notify(options.Namespace)
+	return err
+}
+
+// Update updates the service and notifies the manager of its status
+func (n *notifier) Update(srv *runtime.Service, opts ...runtime.UpdateOption) error {
+	var options runtime.UpdateOptions
+	for _, o := range opts {
+		o(&options)
+	}
+
+	err := n.Runtime.Update(srv, opts...)
+	n.notify(options.Namespace)
+	return err
+}
+
+// NotifyStatus returns the notifications of the runtime
+func (n *notifier) NotifyStatus() (<-chan runtime.Event, error) {
+	return n.events, nil
+}
@@ -0,0 +1,139 @@
+package notifier
+
+import (
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+
+	mruntime "github.com/micro/go-micro/v2/runtime"
+	"github.com/micro/go-micro/v2/util/kubernetes/client"
+)
+
+type testRuntime struct {
+	mruntime.Runtime
+}
+
+func (r *testRuntime) Create(srv *mruntime.Service, opts ...mruntime.CreateOption) error {
+	return nil
+}
+
+func (r *testRuntime) Read(opts ...mruntime.ReadOption) ([]*mruntime.Service, error) {
+	return nil, nil
+}
+
+// expect returns the namespace of the next notification
+func expect(t *testing.T, events <-chan mruntime.Event) string {
+	select {
+	case ev := <-events:
+		if ev.Service != nil || ev.Options == nil {
+			t.Fatalf("Expected a notification without a service, got %+v", ev)
+		}
+		return ev.Options.Namespace
+	case <-time.After(time.Second):
+		t.Fatalf("Expected a notification")
+	}
+	return ""
+}
+
+func TestLocal(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the processes of the services can only be listed on linux")
+	}
+	exitDelay = 0
+
+	// a process of a service in namespace baz which exits once it's tracked
+	cmd := exec.Command("sleep", "0.2")
+	cmd.Env = []string{"MICRO_NAMESPACE=baz"}
+	if err := cmd.Start(); err != nil {
+		t.Skip("sleep can't be run: ", err)
+	}
+
+	rt := Local(&testRuntime{}).(*local)
+	events, err := rt.NotifyStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// creating a service notifies the manager of the status of its namespace
+	if err := rt.Create(&mruntime.Service{Name: "foo"}, mruntime.CreateNamespace("bar")); err != nil {
+		t.Fatal(err)
+	}
+	if ns := expect(t, events); ns != "bar" {
+		t.Errorf("Expected a notification for namespace bar, got %v", ns)
+	}
+
+	// processes which aren't services exiting don't notify the manager
+	if err := exec.Command("true").Run(); err != nil {
+		t.Skip("true can't be run: ", err)
+	}
+	select {
+	case ev := <-events:
+		t.Fatalf("Expected no notification, got %+v", ev)
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	// the process of the service exiting notifies the manager of the status of its namespace
+	cmd.Wait()
+	if ns := expect(t, events); ns != "baz" {
+		t.Errorf("Expected a notification for namespace baz, got %v", ns)
+	}
+}
+
+type testClient struct {
+	client.Client
+	namespaces chan string
+	events     chan client.Event
+}
+
+func (c *testClient) Watch(r *client.Resource, opts ...client.WatchOption) (client.Watcher, error) {
+	var options client.WatchOptions
+	for _, o := range opts {
+		o(&options)
+	}
+	c.namespaces <- options.Namespace
+	return &testWatcher{events: c.events}, nil
+}
+
+type testWatcher struct {
+	events chan client.Event
+}
+
+func (w *testWatcher) Chan() <-chan client.Event {
+	return w.events
+}
+
+func (w *testWatcher) Stop() {}
+
+func TestKubernetes(t *testing.T) {
+	c := &testClient{namespaces: make(chan string, 2), events: make(chan client.Event)}
+	rt := Kubernetes(&testRuntime{}, c).(*kubernetes)
+	events, err := rt.NotifyStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the pods of a namespace are watched once it's read, once
+	rt.Read(mruntime.ReadNamespace("foo"))
+	rt.Read(mruntime.ReadNamespace("foo"))
+	select {
+	case ns := <-c.namespaces:
+		if ns != "foo" {
+			t.Errorf("Expected the pods of namespace foo to be watched, got %v", ns)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the pods of the namespace to be watched")
+	}
+
+	// a pod changing notifies the manager of the status of its namespace
+	c.events <- client.Event{Type: client.Modified}
+	if ns := expect(t, events); ns != "foo" {
+		t.Errorf("Expected a notification for namespace foo, got %v", ns)
+	}
+
+	select {
+	case ns := <-c.namespaces:
+		t.Errorf("Expected namespace %v to be watched once", ns)
+	case <-time.After(time.Millisecond * 50):
+	}
+}
@@ -0,0 +1,65 @@
+// +build linux
+
+package notifier
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/micro/micro/v2/internal/namespace"
+)
+
+// namespaceEnv is set by the manager on the processes of the services to their namespace
+const namespaceEnv = "MICRO_NAMESPACE="
+
+// childNamespaces returns the namespaces of the running child processes of the runtime by pid. The
+// processes without the namespace env var, e.g. those fetching the source of a service, and those
+// which have exited but haven't been waited for yet are skipped.
+func childNamespaces() (map[int]string, error) {
+	dirs, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+
+	ppid := strconv.Itoa(os.Getpid())
+	namespaces := make(map[int]string)
+
+	for _, d := range dirs {
+		pid, err := strconv.Atoi(d.Name())
+		if err != nil {
+			continue
+		}
+
+		// the command in the stat is in parens and can contain spaces, it's followed by the state
+		// and the parent pid
+		stat, err := ioutil.ReadFile(filepath.Join("/proc", d.Name(), "stat"))
+		if err != nil {
+			continue
+		}
+		fields := strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+		if len(fields) < 2 || fields[0] == "Z" || fields[1] != ppid {
+			continue
+		}
+
+		env, err := ioutil.ReadFile(filepath.Join("/proc", d.Name(), "environ"))
+		if err != nil {
+			continue
+		}
+		for _, v := range bytes.Split(env, []byte{0}) {
+			if !bytes.HasPrefix(v, []byte(namespaceEnv)) {
+				continue
+			}
+			ns := string(bytes.TrimPrefix(v, []byte(namespaceEnv)))
+			if len(ns) == 0 {
+				ns = namespace.DefaultNamespace
+			}
+			namespaces[pid] = ns
+		}
+	}
+
+	return namespaces, nil
+}
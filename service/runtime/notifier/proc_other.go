@@ -0,0 +1,11 @@
+// +build !linux
+
+package notifier
+
+import "errors"
+
+// childNamespaces isn't supported outside of linux, the manager is notified of the status of every
+// namespace when a process exits instead
+func childNamespaces() (map[int]string, error) {
+	return nil, errors.New("the processes of the services can't be listed")
+}
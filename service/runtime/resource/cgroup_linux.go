@@ -0,0 +1,194 @@
+// +build linux
+
+package resource
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// CgroupRoot is the mount point of the cgroups v2 unified hierarchy
+	CgroupRoot = "/sys/fs/cgroup"
+	// CgroupParent is the group all services are created under
+	CgroupParent = "micro"
+	// cpuPeriod is the cpu.max period in microseconds
+	cpuPeriod = int64(100000)
+)
+
+// Cgroup manages the cgroup v2 group for a single service
+type Cgroup struct {
+	path string
+
+	sync.Mutex
+	// the last cpu sample used to calculate usage
+	lastCPU  int64
+	lastTime time.Time
+}
+
+// NewCgroup returns the cgroup for the name provided, e.g. micro/foo-latest
+func NewCgroup(name string) *Cgroup {
+	name = strings.NewReplacer("/", "-", ":", "-").Replace(name)
+	return &Cgroup{path: filepath.Join(CgroupRoot, CgroupParent, name)}
+}
+
+// Apply creates the cgroup if it doesn't exist and sets the limits
+func (c *Cgroup) Apply(r *Resources) error {
+	if _, err := os.Stat(filepath.Join(CgroupRoot, "cgroup.controllers")); err != nil {
+		return ErrNotSupported
+	}
+
+	// the controllers need enabling in each parent before they can be used in a child
+	for _, dir := range []string{CgroupRoot, filepath.Dir(c.path)} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		if err := write(dir, "cgroup.subtree_control", "+cpu +memory +pids"); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(c.path, 0755); err != nil {
+		return err
+	}
+
+	cpu := fmt.Sprintf("max %d", cpuPeriod)
+	memory, pids := "max", "max"
+	if r.CPU > 0 {
+		cpu = fmt.Sprintf("%d %d", int64(r.CPU*float64(cpuPeriod)), cpuPeriod)
+	}
+	if r.Memory > 0 {
+		memory = strconv.FormatInt(r.Memory, 10)
+	}
+	if r.Processes > 0 {
+		pids = strconv.FormatInt(r.Processes, 10)
+	}
+
+	if err := write(c.path, "cpu.max", cpu); err != nil {
+		return err
+	}
+	if err := write(c.path, "memory.max", memory); err != nil {
+		return err
+	}
+	return write(c.path, "pids.max", pids)
+}
+
+// Attach moves the process into the cgroup
+func (c *Cgroup) Attach(pid int) error {
+	return write(c.path, "cgroup.procs", strconv.Itoa(pid))
+}
+
+// Usage samples the resources consumed by the processes in the cgroup. CPU is
+// averaged over the time since the previous sample.
+func (c *Cgroup) Usage() (*Usage, error) {
+	var u Usage
+	var err error
+
+	if u.Memory, err = readInt(c.path, "memory.current"); err != nil {
+		return nil, err
+	}
+	if u.Processes, err = readInt(c.path, "pids.current"); err != nil {
+		return nil, err
+	}
+
+	stat, err := ioutil.ReadFile(filepath.Join(c.path, "cpu.stat"))
+	if err != nil {
+		return nil, err
+	}
+	var usec int64
+	for _, line := range strings.Split(string(stat), "\n") {
+		if f := strings.Fields(line); len(f) == 2 && f[0] == "usage_usec" {
+			usec, _ = strconv.ParseInt(f[1], 10, 64)
+		}
+	}
+
+	c.Lock()
+	now := time.Now()
+	if !c.lastTime.IsZero() && usec >= c.lastCPU {
+		elapsed := now.Sub(c.lastTime).Microseconds()
+		if elapsed > 0 {
+			u.CPU = float64(usec-c.lastCPU) / float64(elapsed)
+		}
+	}
+	c.lastCPU = usec
+	c.lastTime = now
+	c.Unlock()
+
+	return &u, nil
+}
+
+// Delete removes the cgroup. It will fail if there are still processes in it.
+func (c *Cgroup) Delete() error {
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// ListCgroups returns the names of the cgroups created under CgroupParent
+func ListCgroups() ([]string, error) {
+	dirs, err := ioutil.ReadDir(filepath.Join(CgroupRoot, CgroupParent))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, d := range dirs {
+		if d.IsDir() {
+			names = append(names, d.Name())
+		}
+	}
+	return names, nil
+}
+
+// FindProcesses returns the pids of all the processes which have the
+// environment variable provided, e.g. MICRO_RUNTIME_CGROUP=foo
+func FindProcesses(env string) ([]int, error) {
+	dirs, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+
+	match := []byte(env)
+	var pids []int
+
+	for _, d := range dirs {
+		pid, err := strconv.Atoi(d.Name())
+		if err != nil {
+			continue
+		}
+		// processes can exit or be owned by another user, skip those
+		b, err := ioutil.ReadFile(filepath.Join("/proc", d.Name(), "environ"))
+		if err != nil {
+			continue
+		}
+		for _, v := range bytes.Split(b, []byte{0}) {
+			if bytes.Equal(v, match) {
+				pids = append(pids, pid)
+				break
+			}
+		}
+	}
+
+	return pids, nil
+}
+
+func write(dir, file, val string) error {
+	return ioutil.WriteFile(filepath.Join(dir, file), []byte(val), 0644)
+}
+
+func readInt(dir, file string) (int64, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+}
@@ -0,0 +1,41 @@
+// +build !linux
+
+package resource
+
+// Cgroup is not supported outside of linux
+type Cgroup struct{}
+
+// NewCgroup returns a no-op cgroup
+func NewCgroup(name string) *Cgroup {
+	return &Cgroup{}
+}
+
+// Apply returns ErrNotSupported
+func (c *Cgroup) Apply(r *Resources) error {
+	return ErrNotSupported
+}
+
+// Attach returns ErrNotSupported
+func (c *Cgroup) Attach(pid int) error {
+	return ErrNotSupported
+}
+
+// Usage returns ErrNotSupported
+func (c *Cgroup) Usage() (*Usage, error) {
+	return nil, ErrNotSupported
+}
+
+// Delete is a no-op
+func (c *Cgroup) Delete() error {
+	return nil
+}
+
+// ListCgroups returns ErrNotSupported
+func ListCgroups() ([]string, error) {
+	return nil, ErrNotSupported
+}
+
+// FindProcesses returns ErrNotSupported
+func FindProcesses(env string) ([]int, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,186 @@
+// Package resource defines the compute resources a runtime service may consume
+package resource
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// CPUKey is the service metadata key for the cpu limit, in cores e.g. 0.5
+	CPUKey = "cpu"
+	// MemoryKey is the service metadata key for the memory limit, e.g. 256Mi
+	MemoryKey = "memory"
+	// ProcessesKey is the service metadata key for the max number of processes
+	ProcessesKey = "processes"
+	// UsageKey is the service metadata key the current usage is returned under
+	UsageKey = "usage"
+)
+
+// ErrNotSupported is returned when limits can't be enforced on the host
+var ErrNotSupported = errors.New("resource limits are not supported on this platform")
+
+// Resources are the limits applied to a service
+type Resources struct {
+	// CPU in cores, e.g. 0.5 is half a core
+	CPU float64 `json:"cpu"`
+	// Memory in bytes
+	Memory int64 `json:"memory"`
+	// Processes is the max number of processes / threads
+	Processes int64 `json:"processes"`
+}
+
+// Usage is a sample of the resources consumed by a service
+type Usage struct {
+	// CPU in cores averaged since the last sample
+	CPU float64 `json:"cpu"`
+	// Memory in bytes
+	Memory int64 `json:"memory"`
+	// Processes currently running
+	Processes int64 `json:"processes"`
+}
+
+// FromMetadata parses the resources set in the service metadata. A nil value is
+// returned if no limits have been set.
+func FromMetadata(md map[string]string) (*Resources, error) {
+	if md == nil {
+		return nil, nil
+	}
+
+	var res Resources
+	var err error
+
+	if v := md[CPUKey]; len(v) > 0 {
+		if res.CPU, err = ParseCPU(v); err != nil {
+			return nil, err
+		}
+	}
+	if v := md[MemoryKey]; len(v) > 0 {
+		if res.Memory, err = ParseMemory(v); err != nil {
+			return nil, err
+		}
+	}
+	if v := md[ProcessesKey]; len(v) > 0 {
+		if res.Processes, err = strconv.ParseInt(v, 10, 64); err != nil || res.Processes < 0 {
+			return nil, fmt.Errorf("Invalid processes limit: %v", v)
+		}
+	}
+
+	if res.IsZero() {
+		return nil, nil
+	}
+
+	return &res, nil
+}
+
+// IsZero returns true if no limits are set
+func (r *Resources) IsZero() bool {
+	return r == nil || (r.CPU == 0 && r.Memory == 0 && r.Processes == 0)
+}
+
+// Metadata writes the resources to the service metadata
+func (r *Resources) Metadata(md map[string]string) {
+	if r.CPU > 0 {
+		md[CPUKey] = strconv.FormatFloat(r.CPU, 'f', -1, 64)
+	}
+	if r.Memory > 0 {
+		md[MemoryKey] = FormatMemory(r.Memory)
+	}
+	if r.Processes > 0 {
+		md[ProcessesKey] = strconv.FormatInt(r.Processes, 10)
+	}
+}
+
+// String formats the limits, e.g. cpu=0.5,memory=256Mi
+func (r *Resources) String() string {
+	md := make(map[string]string)
+	r.Metadata(md)
+
+	var parts []string
+	for _, k := range []string{CPUKey, MemoryKey, ProcessesKey} {
+		if v, ok := md[k]; ok {
+			parts = append(parts, k+"="+v)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// String formats the usage against the limits, e.g. cpu=0.1/0.5,memory=20Mi/256Mi
+func (u *Usage) String(r *Resources) string {
+	var parts []string
+	if r.CPU > 0 {
+		parts = append(parts, fmt.Sprintf("cpu=%.2f/%v", u.CPU, strconv.FormatFloat(r.CPU, 'f', -1, 64)))
+	}
+	if r.Memory > 0 {
+		parts = append(parts, fmt.Sprintf("memory=%v/%v", FormatMemory(u.Memory), FormatMemory(r.Memory)))
+	}
+	if r.Processes > 0 {
+		parts = append(parts, fmt.Sprintf("processes=%d/%d", u.Processes, r.Processes))
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParseCPU parses a cpu value in cores (0.5) or millicores (500m)
+func ParseCPU(v string) (float64, error) {
+	if strings.HasSuffix(v, "m") {
+		m, err := strconv.ParseInt(strings.TrimSuffix(v, "m"), 10, 64)
+		if err != nil || m <= 0 {
+			return 0, fmt.Errorf("Invalid cpu limit: %v", v)
+		}
+		return float64(m) / 1000, nil
+	}
+
+	c, err := strconv.ParseFloat(v, 64)
+	if err != nil || c <= 0 {
+		return 0, fmt.Errorf("Invalid cpu limit: %v", v)
+	}
+	return c, nil
+}
+
+var memoryUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"Ki", 1 << 10},
+	{"Mi", 1 << 20},
+	{"Gi", 1 << 30},
+	{"K", 1000},
+	{"M", 1000 * 1000},
+	{"G", 1000 * 1000 * 1000},
+}
+
+// ParseMemory parses a memory value in bytes, e.g. 1048576, 256Mi or 1G
+func ParseMemory(v string) (int64, error) {
+	for _, u := range memoryUnits {
+		if !strings.HasSuffix(v, u.suffix) {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSuffix(v, u.suffix), 10, 64)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("Invalid memory limit: %v", v)
+		}
+		return n * u.size, nil
+	}
+
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("Invalid memory limit: %v", v)
+	}
+	return n, nil
+}
+
+// FormatMemory formats bytes using the largest binary unit which divides it
+func FormatMemory(b int64) string {
+	for i := 2; i >= 0; i-- {
+		u := memoryUnits[i]
+		if b >= u.size && b%u.size == 0 {
+			return strconv.FormatInt(b/u.size, 10) + u.suffix
+		}
+	}
+	if b >= 1<<20 {
+		return fmt.Sprintf("%.1fMi", float64(b)/(1<<20))
+	}
+	return strconv.FormatInt(b, 10)
+}
@@ -0,0 +1,74 @@
+package resource
+
+import "testing"
+
+func TestParseCPU(t *testing.T) {
+	testData := []struct {
+		value string
+		cores float64
+		err   bool
+	}{
+		{"1", 1, false},
+		{"0.5", 0.5, false},
+		{"250m", 0.25, false},
+		{"0", 0, true},
+		{"-1", 0, true},
+		{"abc", 0, true},
+	}
+
+	for _, d := range testData {
+		c, err := ParseCPU(d.value)
+		if d.err != (err != nil) {
+			t.Fatalf("Expected error %v for %v, got %v", d.err, d.value, err)
+		}
+		if c != d.cores {
+			t.Fatalf("Expected %v cores for %v, got %v", d.cores, d.value, c)
+		}
+	}
+}
+
+func TestParseMemory(t *testing.T) {
+	testData := []struct {
+		value string
+		bytes int64
+		err   bool
+	}{
+		{"1024", 1024, false},
+		{"256Mi", 256 << 20, false},
+		{"1Gi", 1 << 30, false},
+		{"1G", 1000 * 1000 * 1000, false},
+		{"10Ki", 10 << 10, false},
+		{"0", 0, true},
+		{"Mi", 0, true},
+	}
+
+	for _, d := range testData {
+		b, err := ParseMemory(d.value)
+		if d.err != (err != nil) {
+			t.Fatalf("Expected error %v for %v, got %v", d.err, d.value, err)
+		}
+		if b != d.bytes {
+			t.Fatalf("Expected %v bytes for %v, got %v", d.bytes, d.value, b)
+		}
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	res := &Resources{CPU: 0.5, Memory: 256 << 20, Processes: 100}
+
+	md := make(map[string]string)
+	res.Metadata(md)
+
+	parsed, err := FromMetadata(md)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing metadata: %v", err)
+	}
+	if *parsed != *res {
+		t.Fatalf("Expected %+v, got %+v", res, parsed)
+	}
+
+	if res, err := FromMetadata(map[string]string{"owner": "foo"}); err != nil || res != nil {
+		t.Fatalf("Expected no resources, got %+v %v", res, err)
+	}
+
+}
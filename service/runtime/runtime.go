@@ -10,9 +10,14 @@ import (
 	log "github.com/micro/go-micro/v2/logger"
 	"github.com/micro/go-micro/v2/runtime"
 	pb "github.com/micro/go-micro/v2/runtime/service/proto"
+	"github.com/micro/go-micro/v2/util/kubernetes/client"
+	"github.com/micro/micro/v2/internal/secret"
 	"github.com/micro/micro/v2/service/runtime/handler"
 	"github.com/micro/micro/v2/service/runtime/manager"
+	"github.com/micro/micro/v2/service/runtime/notifier"
 	"github.com/micro/micro/v2/service/runtime/profile"
+	runtimepb "github.com/micro/micro/v2/service/runtime/proto"
+	"github.com/micro/micro/v2/service/runtime/scale"
 )
 
 var (
@@ -62,20 +67,38 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 		muRuntime.Init(runtime.WithSource(ctx.String("source")))
 	}
 
+	// notify the manager when the status of the services changes so it doesn't wait to poll it
+	switch muRuntime.String() {
+	case "local":
+		muRuntime = notifier.Local(muRuntime)
+	case "kubernetes":
+		muRuntime = notifier.Kubernetes(muRuntime, client.NewClusterClient())
+	}
+
 	// append name
 	srvOpts = append(srvOpts, micro.Name(Name))
 
 	// new service
 	service := micro.NewService(srvOpts...)
 
+	// get the key to encrypt secrets with
+	key, err := secret.LoadKey("runtime", ctx.String("secret_key"), service.Options().Store)
+	if err != nil {
+		log.Errorf("failed to load secret key, set it with --secret_key or MICRO_RUNTIME_SECRET_KEY: %s", err)
+		os.Exit(1)
+	}
+
 	// create a new runtime manager
-	manager := manager.New(muRuntime,
+	mgr := manager.New(muRuntime,
 		manager.Store(service.Options().Store),
 		manager.Profile(prof),
+		manager.Publisher(micro.NewEvent(manager.StatusTopic, service.Client())),
+		manager.SecretKey(key),
+		manager.Metrics(scale.NewMetrics(service.Client())),
 	)
 
 	// start the manager
-	if err := manager.Start(); err != nil {
+	if err := mgr.Start(); err != nil {
 		log.Errorf("failed to start: %s", err)
 		os.Exit(1)
 	}
@@ -85,7 +108,27 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 		// Client to publish events
 		Client: micro.NewEvent("go.micro.runtime.events", service.Client()),
 		// using the micro runtime
-		Runtime: manager,
+		Runtime: mgr,
+	})
+
+	// register the status handler
+	runtimepb.RegisterStatusHandler(service.Server(), &handler.Status{
+		Manager: mgr.(manager.Timeline),
+	})
+
+	// register the jobs handler
+	runtimepb.RegisterJobsHandler(service.Server(), &handler.Jobs{
+		Manager: mgr.(manager.Jobs),
+	})
+
+	// register the secrets handler
+	runtimepb.RegisterSecretsHandler(service.Server(), &handler.Secrets{
+		Manager: mgr.(manager.Secrets),
+	})
+
+	// register the scale handler
+	runtimepb.RegisterScaleHandler(service.Server(), &handler.Scale{
+		Manager: mgr.(manager.Scaler),
 	})
 
 	// start runtime service
@@ -94,7 +137,7 @@ func Run(ctx *cli.Context, srvOpts ...micro.Option) {
 	}
 
 	// stop the manager
-	if err := manager.Stop(); err != nil {
+	if err := mgr.Stop(); err != nil {
 		log.Errorf("failed to stop: %s", err)
 		os.Exit(1)
 	}
@@ -127,6 +170,52 @@ func Flags() []cli.Flag {
 			Name:  "env_vars",
 			Usage: "Set the environment variables e.g. foo=bar",
 		},
+		&cli.StringFlag{
+			Name:  "cpu",
+			Usage: "Set the cpu limit in cores e.g. 0.5 or 500m. Only supported by the local runtime",
+		},
+		&cli.StringFlag{
+			Name:  "memory",
+			Usage: "Set the memory limit e.g. 256Mi or 1G. Only supported by the local runtime",
+		},
+		&cli.IntFlag{
+			Name:  "processes",
+			Usage: "Set the max number of processes. Only supported by the local runtime",
+		},
+		&cli.StringFlag{
+			Name:  "schedule",
+			Usage: "Run the service as a job on a cron schedule e.g. \"*/5 * * * *\"",
+		},
+		&cli.StringFlag{
+			Name:  "concurrency",
+			Usage: "Set the concurrency policy of a scheduled job e.g. allow, forbid, replace",
+		},
+		&cli.IntFlag{
+			Name:  "retries",
+			Usage: "Set the number of times a failed job is retried",
+		},
+		&cli.IntFlag{
+			Name:  "replicas",
+			Usage: "Set the number of replicas of the service to run. Only supported by the local runtime",
+		},
+	}
+}
+
+// scaleFlags are the flags used to autoscale a service
+func scaleFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:  "min_replicas",
+			Usage: "Set the min replicas when autoscaling",
+		},
+		&cli.IntFlag{
+			Name:  "max_replicas",
+			Usage: "Set the max replicas, the service is autoscaled between the min and max if set. Only supported by the local runtime in the default namespace",
+		},
+		&cli.Float64Flag{
+			Name:  "target_rate",
+			Usage: "Set the requests per second each replica should handle when autoscaling",
+		},
 	}
 }
 
@@ -156,6 +245,11 @@ func Commands(options ...micro.Option) []*cli.Command {
 					Usage:   "Set the max retries per service",
 					EnvVars: []string{"MICRO_RUNTIME_RETRIES"},
 				},
+				&cli.StringFlag{
+					Name:    "secret_key",
+					Usage:   "Set the key used to encrypt secrets (base64 encoded, 32 bytes). Required unless the store is local, one is generated then",
+					EnvVars: []string{"MICRO_RUNTIME_SECRET_KEY"},
+				},
 			},
 			Action: func(ctx *cli.Context) error {
 				Run(ctx, options...)
@@ -172,8 +266,10 @@ func Commands(options ...micro.Option) []*cli.Command {
 			micro run ../path/to/folder # deploy local folder to your local micro server
 			micro run helloworld # deploy latest version, translates to micro run github.com/micro/services/helloworld
 			micro run helloworld@9342934e6180 # deploy certain version
-			micro run helloworld@branchname	# deploy certain branch`,
-			Flags: Flags(),
+			micro run helloworld@branchname	# deploy certain branch
+			micro run --replicas 3 helloworld # run 3 replicas
+			micro run --min_replicas 1 --max_replicas 5 helloworld # autoscale between 1 and 5 replicas`,
+			Flags: append(Flags(), scaleFlags()...),
 			Action: func(ctx *cli.Context) error {
 				runService(ctx, options...)
 				return nil
@@ -212,12 +308,96 @@ func Commands(options ...micro.Option) []*cli.Command {
 		{
 			Name:  "status",
 			Usage: GetUsage,
-			Flags: Flags(),
+			Flags: append(Flags(), &cli.BoolFlag{
+				Name:    "watch",
+				Aliases: []string{"w"},
+				Usage:   "Watch the status timeline of services as it changes",
+			}, &cli.IntFlag{
+				Name:    "lines",
+				Aliases: []string{"n"},
+				Usage:   "Set the number of past status events to show when watching",
+				Value:   10,
+			}),
 			Action: func(ctx *cli.Context) error {
 				getService(ctx, options...)
 				return nil
 			},
 		},
+		{
+			Name:  "scale",
+			Usage: ScaleUsage,
+			Description: `Examples:
+			micro scale helloworld 3 # run 3 replicas
+			micro scale helloworld 0 # stop every replica but keep the service
+			micro scale --max_replicas 5 --target_rate 100 helloworld # autoscale between 1 and 5 replicas`,
+			Flags: scaleFlags(),
+			Action: func(ctx *cli.Context) error {
+				scaleService(ctx, options...)
+				return nil
+			},
+		},
+		{
+			Name:  "jobs",
+			Usage: "Manage jobs which run to completion, once or on a schedule",
+			Subcommands: []*cli.Command{
+				{
+					Name:  "list",
+					Usage: "List jobs",
+					Action: func(ctx *cli.Context) error {
+						listJobs(ctx, options...)
+						return nil
+					},
+				},
+				{
+					Name:  "history",
+					Usage: "Show the recent runs of a job: micro jobs history [source] [version]",
+					Flags: []cli.Flag{
+						&cli.IntFlag{
+							Name:    "lines",
+							Aliases: []string{"n"},
+							Usage:   "Set the number of log lines to show for each run",
+						},
+					},
+					Action: func(ctx *cli.Context) error {
+						jobHistory(ctx, options...)
+						return nil
+					},
+				},
+			},
+		},
+		{
+			Name:  "secrets",
+			Usage: "Manage the secrets referenced by services",
+			Description: `Secrets are referenced by name in the env vars of a service and are only resolved when it runs e.g.
+			micro secrets set foo db_password hunter2
+			micro run --env_vars DB_PASSWORD=secret:db_password helloworld`,
+			Subcommands: []*cli.Command{
+				{
+					Name:  "set",
+					Usage: SecretsSetUsage,
+					Action: func(ctx *cli.Context) error {
+						setSecret(ctx, options...)
+						return nil
+					},
+				},
+				{
+					Name:  "delete",
+					Usage: SecretsDeleteUsage,
+					Action: func(ctx *cli.Context) error {
+						deleteSecret(ctx, options...)
+						return nil
+					},
+				},
+				{
+					Name:  "list",
+					Usage: SecretsListUsage,
+					Action: func(ctx *cli.Context) error {
+						listSecrets(ctx, options...)
+						return nil
+					},
+				},
+			},
+		},
 		{
 			Name:  "logs",
 			Usage: "Get logs for a service",
@@ -0,0 +1,93 @@
+package scale
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/micro/go-micro/v2/client"
+	"github.com/micro/micro/v2/internal/namespace"
+	stats "github.com/micro/micro/v2/service/debug/stats/proto"
+)
+
+// MetricsWindow is the period the request and error rates are averaged over
+var MetricsWindow = time.Minute
+
+// ServicePrefix is the prefix of the names services run by the runtime are registered with, e.g.
+// go.micro.service.helloworld
+var ServicePrefix = "go.micro.service"
+
+// ErrNoMetrics is returned when the load on the services of a namespace isn't known
+var ErrNoMetrics = errors.New("no metrics for the namespace")
+
+// Metrics returns the load on a service, used to autoscale it
+type Metrics interface {
+	// Rate returns the requests and errors per second across every node of the service in the
+	// namespace
+	Rate(ns, name string) (requests float64, errors float64, err error)
+}
+
+// NewMetrics returns Metrics using the snapshots scraped by the debug stats service
+func NewMetrics(c client.Client) Metrics {
+	return &statsMetrics{stats.NewStatsService("go.micro.debug", c)}
+}
+
+type statsMetrics struct {
+	stats stats.StatsService
+}
+
+// matches returns true if the registered service is the runtime service. Services are registered
+// under their full name, e.g. go.micro.service.helloworld, while the runtime uses the name
+// from the source, e.g. helloworld. Other services with the same name, e.g. go.micro.api.helloworld,
+// aren't matched.
+func matches(registered, name string) bool {
+	return registered == name || registered == ServicePrefix+"."+name
+}
+
+// Rate returns the load on the service in the namespace. The debug service scrapes the services
+// registered in the default namespace, the services of other namespaces have the same names so
+// their load isn't known.
+func (m *statsMetrics) Rate(ns, name string) (float64, float64, error) {
+	if ns != namespace.DefaultNamespace {
+		return 0, 0, ErrNoMetrics
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	rsp, err := m.stats.Read(ctx, &stats.ReadRequest{Past: true})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// group the snapshots in the window by node, the counters are per node
+	since := uint64(time.Now().Add(-MetricsWindow).Unix())
+	nodes := make(map[string][]*stats.Snapshot)
+	for _, s := range rsp.Stats {
+		if s.Service == nil || s.Service.Node == nil || s.Timestamp < since {
+			continue
+		}
+		if !matches(s.Service.Name, name) {
+			continue
+		}
+		nodes[s.Service.Node.Id] = append(nodes[s.Service.Node.Id], s)
+	}
+
+	var requests, errors float64
+	for _, snaps := range nodes {
+		sort.Slice(snaps, func(i, j int) bool { return snaps[i].Timestamp < snaps[j].Timestamp })
+		first, last := snaps[0], snaps[len(snaps)-1]
+
+		// skip nodes which restarted during the window since their counters were reset
+		if last.Timestamp <= first.Timestamp || last.Requests < first.Requests || last.Errors < first.Errors {
+			continue
+		}
+
+		secs := float64(last.Timestamp - first.Timestamp)
+		requests += float64(last.Requests-first.Requests) / secs
+		errors += float64(last.Errors-first.Errors) / secs
+	}
+
+	return requests, errors, nil
+}
@@ -0,0 +1,135 @@
+// Package scale defines the number of replicas of a runtime service and how they're autoscaled
+package scale
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+const (
+	// ReplicasKey is the service metadata key for the desired number of replicas
+	ReplicasKey = "replicas"
+	// MinReplicasKey is the service metadata key for the min replicas when autoscaling
+	MinReplicasKey = "min_replicas"
+	// MaxReplicasKey is the service metadata key for the max replicas when autoscaling, the
+	// service is autoscaled if it's set
+	MaxReplicasKey = "max_replicas"
+	// TargetRateKey is the service metadata key for the requests per second each replica
+	// should handle when autoscaling
+	TargetRateKey = "target_rate"
+)
+
+var (
+	// DefaultTargetRate is the requests per second each replica should handle if not set
+	DefaultTargetRate = 50.0
+	// ErrorThreshold is the ratio of errors to requests above which the service is assumed to be
+	// saturated and another replica is added
+	ErrorThreshold = 0.1
+)
+
+// Scale of a service
+type Scale struct {
+	// Replicas is the desired number of replicas
+	Replicas int
+	// Min replicas when autoscaling
+	Min int
+	// Max replicas when autoscaling, zero if the service isn't autoscaled
+	Max int
+	// TargetRate is the requests per second each replica should handle
+	TargetRate float64
+}
+
+// FromMetadata parses the scale from the service metadata, services have one replica by default
+func FromMetadata(md map[string]string) (*Scale, error) {
+	s := &Scale{Replicas: 1, Min: 1}
+
+	parse := func(key string, min int) (int, error) {
+		n, err := strconv.Atoi(md[key])
+		if err != nil || n < min {
+			return 0, fmt.Errorf("Invalid %v: %v", key, md[key])
+		}
+		return n, nil
+	}
+
+	var err error
+	if len(md[ReplicasKey]) > 0 {
+		if s.Replicas, err = parse(ReplicasKey, 0); err != nil {
+			return nil, err
+		}
+	}
+	if len(md[MinReplicasKey]) > 0 {
+		if s.Min, err = parse(MinReplicasKey, 1); err != nil {
+			return nil, err
+		}
+	}
+	if len(md[MaxReplicasKey]) > 0 {
+		if s.Max, err = parse(MaxReplicasKey, 1); err != nil {
+			return nil, err
+		}
+		if s.Max < s.Min {
+			return nil, fmt.Errorf("Invalid %v: %v is less than %v %v", MaxReplicasKey, s.Max, MinReplicasKey, s.Min)
+		}
+	}
+	if v := md[TargetRateKey]; len(v) > 0 {
+		if s.TargetRate, err = strconv.ParseFloat(v, 64); err != nil || s.TargetRate <= 0 {
+			return nil, fmt.Errorf("Invalid %v: %v", TargetRateKey, v)
+		}
+	}
+
+	return s, nil
+}
+
+// Autoscale returns true if the service should be autoscaled
+func (s *Scale) Autoscale() bool {
+	return s.Max > 0
+}
+
+// Metadata writes the scale to the service metadata
+func (s *Scale) Metadata(md map[string]string) {
+	md[ReplicasKey] = strconv.Itoa(s.Replicas)
+
+	if !s.Autoscale() {
+		delete(md, MinReplicasKey)
+		delete(md, MaxReplicasKey)
+		delete(md, TargetRateKey)
+		return
+	}
+
+	md[MinReplicasKey] = strconv.Itoa(s.Min)
+	md[MaxReplicasKey] = strconv.Itoa(s.Max)
+	if s.TargetRate > 0 {
+		md[TargetRateKey] = strconv.FormatFloat(s.TargetRate, 'f', -1, 64)
+	} else {
+		delete(md, TargetRateKey)
+	}
+}
+
+// Desired returns the number of replicas needed to handle the request rate, within the min and
+// max bounds. Replicas are added as soon as they're needed but are removed one at a time to
+// avoid flapping.
+func (s *Scale) Desired(current int, requests, errors float64) int {
+	target := s.TargetRate
+	if target <= 0 {
+		target = DefaultTargetRate
+	}
+
+	desired := int(math.Ceil(requests / target))
+
+	// a high error rate suggests the replicas are saturated
+	if requests > 0 && errors/requests > ErrorThreshold && desired <= current {
+		desired = current + 1
+	}
+
+	if desired < current-1 {
+		desired = current - 1
+	}
+	if desired < s.Min {
+		desired = s.Min
+	}
+	if desired > s.Max {
+		desired = s.Max
+	}
+
+	return desired
+}
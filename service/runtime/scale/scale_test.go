@@ -0,0 +1,71 @@
+package scale
+
+import "testing"
+
+func TestFromMetadata(t *testing.T) {
+	s, err := FromMetadata(map[string]string{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.Replicas != 1 || s.Autoscale() {
+		t.Errorf("Expected a single replica without autoscaling by default but got %+v", s)
+	}
+
+	s, err = FromMetadata(map[string]string{ReplicasKey: "2", MinReplicasKey: "2", MaxReplicasKey: "5"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.Replicas != 2 || s.Min != 2 || s.Max != 5 || !s.Autoscale() {
+		t.Errorf("Unexpected scale %+v", s)
+	}
+
+	invalid := []map[string]string{
+		{ReplicasKey: "-1"},
+		{ReplicasKey: "foo"},
+		{MinReplicasKey: "3", MaxReplicasKey: "2"},
+		{MaxReplicasKey: "2", TargetRateKey: "0"},
+	}
+	for _, md := range invalid {
+		if _, err := FromMetadata(md); err == nil {
+			t.Errorf("Expected %v to be invalid", md)
+		}
+	}
+}
+
+func TestDesired(t *testing.T) {
+	s := &Scale{Min: 1, Max: 5, TargetRate: 10}
+
+	tt := []struct {
+		Name     string
+		Current  int
+		Requests float64
+		Errors   float64
+		Desired  int
+	}{
+		{"Idle", 1, 0, 0, 1},
+		{"ScaleUp", 1, 35, 0, 4},
+		{"MaxBound", 2, 100, 0, 5},
+		{"ScaleDownOneAtATime", 5, 5, 0, 4},
+		{"Steady", 3, 25, 0, 3},
+		{"Errors", 3, 25, 5, 4},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			if d := s.Desired(tc.Current, tc.Requests, tc.Errors); d != tc.Desired {
+				t.Errorf("Expected %v replicas but got %v", tc.Desired, d)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	if !matches("go.micro.service.helloworld", "helloworld") || !matches("helloworld", "helloworld") {
+		t.Error("Expected the service to match the runtime name")
+	}
+	for _, name := range []string{"go.micro.api.helloworld", "go.micro.web.helloworld", "foo.helloworld"} {
+		if matches(name, "helloworld") {
+			t.Errorf("Expected %v not to match helloworld", name)
+		}
+	}
+}
@@ -2,6 +2,7 @@
 package runtime
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -23,7 +24,12 @@ import (
 	"github.com/micro/go-micro/v2/util/file"
 	cliutil "github.com/micro/micro/v2/client/cli/util"
 	"github.com/micro/micro/v2/internal/client"
+	"github.com/micro/micro/v2/internal/namespace"
 	"github.com/micro/micro/v2/service/runtime/handler"
+	"github.com/micro/micro/v2/service/runtime/job"
+	pb "github.com/micro/micro/v2/service/runtime/proto"
+	"github.com/micro/micro/v2/service/runtime/resource"
+	"github.com/micro/micro/v2/service/runtime/scale"
 )
 
 const (
@@ -39,6 +45,22 @@ const (
 	ServicesUsage = "micro services"
 	// CannotWatch message for the run command
 	CannotWatch = "Cannot watch filesystem on this runtime"
+	// CannotWatchStatus message for the status command
+	CannotWatchStatus = "Cannot watch status on this runtime"
+	// CannotSchedule message for the run command
+	CannotSchedule = "Cannot schedule jobs on this runtime"
+	// CannotScale message for the run and scale commands
+	CannotScale = "Cannot scale services on this runtime"
+	// ScaleUsage message for the scale command
+	ScaleUsage = "Scale a service: micro scale [source] [replicas]"
+	// JobHistoryUsage message for the jobs history command
+	JobHistoryUsage = "Required usage: micro jobs history [source] [version]"
+	// SecretsSetUsage message for the secrets set command
+	SecretsSetUsage = "Set a secret: micro secrets set [namespace] [name] [value]"
+	// SecretsDeleteUsage message for the secrets delete command
+	SecretsDeleteUsage = "Delete a secret: micro secrets delete [namespace] [name]"
+	// SecretsListUsage message for the secrets list command
+	SecretsListUsage = "List the names of secrets: micro secrets list [namespace]"
 )
 
 var (
@@ -175,6 +197,53 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 		Metadata: make(map[string]string),
 	}
 
+	// set the resource limits
+	res, err := resourcesFromContext(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if res != nil {
+		res.Metadata(service.Metadata)
+	}
+
+	// set the replicas, or the bounds to autoscale between
+	sc, err := scaleFromContext(ctx)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if sc != nil {
+		if cliutil.IsLocal(ctx) {
+			fmt.Println(CannotScale)
+			os.Exit(1)
+		}
+		sc.Metadata(service.Metadata)
+	}
+
+	// a service with a schedule is run as a job
+	if schedule := ctx.String("schedule"); len(schedule) > 0 {
+		if cliutil.IsLocal(ctx) {
+			fmt.Println(CannotSchedule)
+			os.Exit(1)
+		}
+		service.Metadata[job.ScheduleKey] = schedule
+		typ = job.Type
+	}
+	if typ == job.Type {
+		if v := ctx.String("concurrency"); len(v) > 0 {
+			service.Metadata[job.ConcurrencyKey] = v
+		}
+		if ctx.IsSet("retries") {
+			service.Metadata[job.RetriesKey] = fmt.Sprintf("%d", retries)
+		}
+		if _, err := job.FromMetadata(service.Metadata); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		opts = append(opts, runtime.CreateType(typ))
+	}
+
 	if err := r.Create(service, opts...); err != nil {
 		fmt.Println(err)
 		return
@@ -190,6 +259,83 @@ func runService(ctx *cli.Context, srvOpts ...micro.Option) {
 	}
 }
 
+// resourcesFromContext parses the resource limit flags
+func resourcesFromContext(ctx *cli.Context) (*resource.Resources, error) {
+	md := make(map[string]string)
+	if v := ctx.String("cpu"); len(v) > 0 {
+		md[resource.CPUKey] = v
+	}
+	if v := ctx.String("memory"); len(v) > 0 {
+		md[resource.MemoryKey] = v
+	}
+	if v := ctx.Int("processes"); v > 0 {
+		md[resource.ProcessesKey] = fmt.Sprintf("%d", v)
+	}
+	return resource.FromMetadata(md)
+}
+
+// scaleFromContext parses the replica flags, nil is returned if none are set
+func scaleFromContext(ctx *cli.Context) (*scale.Scale, error) {
+	md := make(map[string]string)
+	if ctx.IsSet("replicas") {
+		md[scale.ReplicasKey] = fmt.Sprintf("%d", ctx.Int("replicas"))
+	}
+	if ctx.IsSet("min_replicas") {
+		md[scale.MinReplicasKey] = fmt.Sprintf("%d", ctx.Int("min_replicas"))
+	}
+	if ctx.IsSet("max_replicas") {
+		md[scale.MaxReplicasKey] = fmt.Sprintf("%d", ctx.Int("max_replicas"))
+	}
+	if ctx.IsSet("target_rate") {
+		md[scale.TargetRateKey] = fmt.Sprintf("%v", ctx.Float64("target_rate"))
+	}
+	if len(md) == 0 {
+		return nil, nil
+	}
+	return scale.FromMetadata(md)
+}
+
+func scaleService(ctx *cli.Context, srvOpts ...micro.Option) {
+	if cliutil.IsLocal(ctx) {
+		fmt.Println(CannotScale)
+		os.Exit(1)
+	}
+	if ctx.Args().Len() == 0 || (ctx.Args().Len() == 1 && !ctx.IsSet("max_replicas")) {
+		fmt.Println(ScaleUsage)
+		return
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	source, err := git.ParseSourceLocal(wd, ctx.Args().Get(0))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	req := &pb.ScaleRequest{
+		Service:     source.RuntimeName(),
+		Version:     source.Ref,
+		MinReplicas: int64(ctx.Int("min_replicas")),
+		MaxReplicas: int64(ctx.Int("max_replicas")),
+		TargetRate:  ctx.Float64("target_rate"),
+	}
+	if ctx.Args().Len() > 1 {
+		if _, err := fmt.Sscanf(ctx.Args().Get(1), "%d", &req.Replicas); err != nil || req.Replicas < 0 {
+			fmt.Println(ScaleUsage)
+			os.Exit(1)
+		}
+	}
+
+	if _, err := pb.NewScaleService(Name, client.New(ctx)).Set(context.TODO(), req); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
 func killService(ctx *cli.Context, srvOpts ...micro.Option) {
 	// we need some args to run
 	if ctx.Args().Len() == 0 {
@@ -335,6 +481,15 @@ func getService(ctx *cli.Context, srvOpts ...micro.Option) {
 		version = ctx.Args().Get(1)
 	}
 
+	// stream the status timeline
+	if ctx.Bool("watch") {
+		if len(name) == 0 {
+			version = ""
+		}
+		watchStatus(ctx, name, version)
+		return
+	}
+
 	// should we list sevices
 	var list bool
 
@@ -397,7 +552,7 @@ func getService(ctx *cli.Context, srvOpts ...micro.Option) {
 	sort.Slice(services, func(i, j int) bool { return services[i].Name < services[j].Name })
 
 	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
-	fmt.Fprintln(writer, "NAME\tVERSION\tSOURCE\tSTATUS\tBUILD\tUPDATED\tMETADATA")
+	fmt.Fprintln(writer, "NAME\tVERSION\tSOURCE\tSTATUS\tBUILD\tUPDATED\tRESOURCES\tMETADATA")
 	for _, service := range services {
 		status := parse(service.Metadata["status"])
 		if status == "error" {
@@ -413,18 +568,247 @@ func getService(ctx *cli.Context, srvOpts ...micro.Option) {
 		// parse when the service was started
 		updated := parse(timeAgo(service.Metadata["started"]))
 
-		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		// show the usage against the limits, falling back to just the limits if not yet sampled
+		resources := service.Metadata[resource.UsageKey]
+		if res, _ := resource.FromMetadata(service.Metadata); len(resources) == 0 && res != nil {
+			resources = res.String()
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 			service.Name,
 			parse(service.Version),
 			parse(service.Source),
 			strings.ToLower(status),
 			build,
 			updated,
+			parse(resources),
 			fmt.Sprintf("owner=%s,group=%s", parse(service.Metadata["owner"]), parse(service.Metadata["group"])))
 	}
 	writer.Flush()
 }
 
+// watchStatus prints the recent status events for services and then streams new events as the
+// status of services change
+func watchStatus(ctx *cli.Context, name, version string) {
+	if cliutil.IsLocal(ctx) {
+		fmt.Println(CannotWatchStatus)
+		os.Exit(1)
+	}
+
+	srv := pb.NewStatusService(Name, client.New(ctx))
+
+	// print the recent history before watching
+	rsp, err := srv.Timeline(context.TODO(), &pb.TimelineRequest{
+		Service: name,
+		Version: version,
+		Limit:   int64(ctx.Int("lines")),
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	for _, ev := range rsp.Events {
+		printStatusEvent(ev)
+	}
+
+	stream, err := srv.Watch(context.TODO(), &pb.WatchRequest{Service: name, Version: version})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer stream.Close()
+
+	for {
+		ev, err := stream.Recv()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		printStatusEvent(ev)
+	}
+}
+
+// printStatusEvent prints an event in the status timeline, e.g.
+// 2020-07-01 10:00:00 go.micro.service.foo:latest crashed exit code 1: exit status 1
+func printStatusEvent(ev *pb.StatusEvent) {
+	line := fmt.Sprintf("%v %v:%v %v",
+		time.Unix(0, ev.Timestamp).Format("2006-01-02 15:04:05"),
+		ev.Service,
+		ev.Version,
+		ev.Type,
+	)
+	if ev.ExitCode > 0 {
+		line += fmt.Sprintf(" exit code %d", ev.ExitCode)
+	}
+	if ev.Restarts > 0 {
+		line += fmt.Sprintf(" restarts %d", ev.Restarts)
+	}
+	if len(ev.Error) > 0 {
+		line += ": " + ev.Error
+	}
+	fmt.Println(line)
+}
+
+func listJobs(ctx *cli.Context, srvOpts ...micro.Option) {
+	rsp, err := pb.NewJobsService(Name, client.New(ctx)).List(context.TODO(), &pb.ListJobsRequest{})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	if len(rsp.Jobs) == 0 {
+		return
+	}
+
+	sort.Slice(rsp.Jobs, func(i, j int) bool { return rsp.Jobs[i].Service < rsp.Jobs[j].Service })
+
+	parse := func(m string) string {
+		if len(m) == 0 {
+			return "n/a"
+		}
+		return m
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "NAME\tVERSION\tSCHEDULE\tCONCURRENCY\tNEXT RUN\tLAST RUN\tSTATUS")
+	for _, j := range rsp.Jobs {
+		next := "n/a"
+		if j.NextRun > 0 {
+			next = fmt.Sprintf("in %v", time.Until(time.Unix(j.NextRun, 0)).Truncate(time.Second))
+		}
+
+		last, status := "n/a", "n/a"
+		if j.LastRun != nil {
+			last = fmt.Sprintf("%v ago", time.Since(time.Unix(j.LastRun.Started, 0)).Truncate(time.Second))
+			status = j.LastRun.Status
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			j.Service,
+			parse(j.Version),
+			parse(j.Schedule),
+			j.Concurrency,
+			next,
+			last,
+			status)
+	}
+	writer.Flush()
+}
+
+func jobHistory(ctx *cli.Context, srvOpts ...micro.Option) {
+	if ctx.Args().Len() == 0 {
+		fmt.Println(JobHistoryUsage)
+		return
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	source, err := git.ParseSourceLocal(wd, ctx.Args().Get(0))
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	version := "latest"
+	if ctx.Args().Len() > 1 {
+		version = ctx.Args().Get(1)
+	}
+
+	rsp, err := pb.NewJobsService(Name, client.New(ctx)).History(context.TODO(), &pb.JobHistoryRequest{
+		Service: source.RuntimeName(),
+		Version: version,
+	})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	lines := ctx.Int("lines")
+	writer := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+	fmt.Fprintln(writer, "ID\tSTARTED\tDURATION\tSTATUS\tEXIT CODE\tRETRIES\tERROR")
+	for _, run := range rsp.Runs {
+		started := time.Unix(run.Started, 0)
+		duration := "n/a"
+		if run.Finished > 0 {
+			duration = time.Unix(run.Finished, 0).Sub(started).String()
+		}
+		errMsg := run.Error
+		if len(errMsg) == 0 {
+			errMsg = "n/a"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\t%d\t%s\n",
+			run.Id,
+			started.Format("2006-01-02 15:04:05"),
+			duration,
+			run.Status,
+			run.ExitCode,
+			run.Retries,
+			errMsg)
+
+		if lines <= 0 {
+			continue
+		}
+		logs := run.Logs
+		if len(logs) > lines {
+			logs = logs[len(logs)-lines:]
+		}
+		for _, l := range logs {
+			fmt.Fprintf(writer, "\t%s\n", l)
+		}
+	}
+	writer.Flush()
+}
+
+func setSecret(ctx *cli.Context, srvOpts ...micro.Option) {
+	if ctx.Args().Len() != 3 {
+		fmt.Println(SecretsSetUsage)
+		return
+	}
+
+	sctx := namespace.ContextWithNamespace(context.TODO(), ctx.Args().Get(0))
+	req := &pb.SetSecretRequest{Name: ctx.Args().Get(1), Value: ctx.Args().Get(2)}
+
+	if _, err := pb.NewSecretsService(Name, client.New(ctx)).Set(sctx, req); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func deleteSecret(ctx *cli.Context, srvOpts ...micro.Option) {
+	if ctx.Args().Len() != 2 {
+		fmt.Println(SecretsDeleteUsage)
+		return
+	}
+
+	sctx := namespace.ContextWithNamespace(context.TODO(), ctx.Args().Get(0))
+	req := &pb.DeleteSecretRequest{Name: ctx.Args().Get(1)}
+
+	if _, err := pb.NewSecretsService(Name, client.New(ctx)).Delete(sctx, req); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func listSecrets(ctx *cli.Context, srvOpts ...micro.Option) {
+	if ctx.Args().Len() != 1 {
+		fmt.Println(SecretsListUsage)
+		return
+	}
+
+	sctx := namespace.ContextWithNamespace(context.TODO(), ctx.Args().Get(0))
+	rsp, err := pb.NewSecretsService(Name, client.New(ctx)).List(sctx, &pb.ListSecretsRequest{})
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	for _, name := range rsp.Names {
+		fmt.Println(name)
+	}
+}
+
 const (
 	// logUsage message for logs command
 	logUsage = "Required usage: micro log example"